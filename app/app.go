@@ -6,10 +6,10 @@ import (
 	"os"
 	"time"
 
-	"github.com/gdamore/tcell/v2"
 	"github.com/niiharamegumu/chronowork/container"
 	"github.com/niiharamegumu/chronowork/db"
 	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
 	"github.com/niiharamegumu/chronowork/widgets"
 	"github.com/rivo/tview"
 )
@@ -19,6 +19,17 @@ var (
 )
 
 func init() {
+	// The database is locked before it is opened and migrated: a restore
+	// replaces the file and must not run while anything else uses it
+	lock := db.LockShared
+	if len(os.Args) > 1 && os.Args[1] == "restore" {
+		lock = db.LockExclusive
+	}
+	if err := lock(); err != nil {
+		fmt.Println("error", err)
+		os.Exit(1)
+	}
+
 	err := db.ConnectDB()
 	if err != nil {
 		fmt.Println("database connection error", err)
@@ -32,6 +43,9 @@ func Execute() {
 		if err := db.CloseDB(); err != nil {
 			log.Println("error closing database", err)
 		}
+		if err := db.Unlock(); err != nil {
+			log.Println("error unlocking database", err)
+		}
 		log.Println("database connection closed")
 	}()
 
@@ -42,6 +56,14 @@ func Execute() {
 	// 	log.Println("error creating test data", err)
 	// }
 
+	if len(os.Args) > 1 {
+		if err := runCommand(container.New(db.DB), os.Args[1:]); err != nil {
+			fmt.Println("error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := initialSetting(); err != nil {
 		log.Println("error", err)
 		os.Exit(1)
@@ -51,9 +73,19 @@ func Execute() {
 func initialSetting() error {
 	var err error
 
+	// Load key bindings and detect conflicts
+	keymap, err := service.LoadKeymap(service.KeymapPath())
+	if err != nil {
+		return err
+	}
+	tui.Keymap = keymap
+
 	// Initialize DI container
 	c := container.New(db.DB)
 
+	// Create today's works from recurring templates before the work table is loaded
+	templateCount, templateErr := c.WorkTemplateUC.Materialize(time.Now())
+
 	// Get relative days from setting
 	setting, err := c.SettingUC.Get()
 	if err != nil {
@@ -61,34 +93,41 @@ func initialSetting() error {
 	}
 	relativeDays := int(setting.RelativeDate)
 
-	header := tview.NewTextView().SetTextAlign(tview.AlignCenter).SetText("ChronoWork")
+	// Select the language of the UI before creating widgets
+	i18n.SetLocale(i18n.Resolve(setting.Language))
 
-	mainTitle := tview.NewTextView().
-		SetTextAlign(tview.AlignCenter).
-		SetText(fmt.Sprintf("Today is %s (%v)", time.Now().Format("2006/01/02"), time.Now().Weekday())).SetTextColor(tcell.ColorPurple)
+	// Load themes and apply the selected one before creating widgets
+	themes, err := service.LoadThemes(service.ThemePath())
+	if err != nil {
+		return err
+	}
+	theme := themes.Get(setting.Theme)
+	tui.ApplyTheme(theme)
+
+	header := tview.NewTextView().SetTextAlign(tview.AlignCenter).SetText("ChronoWork")
 
 	// Initialize ErrorHandler
 	errorHandler := service.NewErrorHandler(tui)
 
 	// Initialize widgets with use cases and error handler
-	timer := widgets.NewTimer(c.ChronoWorkUC)
+	timer := widgets.NewTimer(c.ChronoWorkUC, theme)
 	err = timer.CheckActiveTracking(tui)
 	if err != nil {
 		return err
 	}
 
-	work := widgets.NewWork(c.ChronoWorkUC, c.SettingUC, errorHandler)
+	work := widgets.NewWork(c.ChronoWorkUC, c.SettingUC, errorHandler, theme)
 	work, err = work.GenerateInitWork(tui, relativeDays)
 	if err != nil {
 		return err
 	}
 
-	form := widgets.NewForm(c.ChronoWorkUC, c.ProjectTypeUC, errorHandler)
-	form = form.GenerateInitForm(tui, work, relativeDays)
+	form := widgets.NewForm(c.ChronoWorkUC, c.ProjectTypeUC, c.SettingUC, c.QuickAddUC, errorHandler, theme)
+	form = form.GenerateInitForm(tui, work)
 
 	// add page
 	// setting page
-	settingWidget := widgets.NewSetting(c.SettingUC, errorHandler)
+	settingWidget := widgets.NewSetting(c.SettingUC, errorHandler, themes, theme)
 	settingWidget.GenerateInitSetting(tui)
 	tui.SetMainPage("setting", settingWidget.Form, false)
 	if err = tui.SetWidget("settingForm", settingWidget.Form); err != nil {
@@ -96,7 +135,7 @@ func initialSetting() error {
 	}
 
 	// project page
-	project := widgets.NewProject(c.ProjectTypeUC, c.TagUC, c.ChronoWorkUC, errorHandler)
+	project := widgets.NewProject(c.ProjectTypeUC, c.TagUC, c.ChronoWorkUC, errorHandler, theme)
 	tui.SetMainPage("project", project.Layout, false)
 	if err = tui.SetWidget("projectForm", project.Form); err != nil {
 		return err
@@ -107,7 +146,7 @@ func initialSetting() error {
 	project.GenerateInitProject(tui)
 
 	// tag page
-	tagPage := widgets.NewTag(c.TagUC, errorHandler)
+	tagPage := widgets.NewTag(c.TagUC, errorHandler, theme)
 	tagPage.GenerateInitTag(tui)
 	tui.SetMainPage("tag", tagPage.Layout, false)
 	if err = tui.SetWidget("tagForm", tagPage.Form); err != nil {
@@ -117,21 +156,102 @@ func initialSetting() error {
 		return err
 	}
 
+	// template page
+	template := widgets.NewTemplate(c.WorkTemplateUC, c.ProjectTypeUC, errorHandler, theme)
+	tui.SetMainPage("template", template.Layout, false)
+	if err = tui.SetWidget("templateForm", template.Form); err != nil {
+		return err
+	}
+	if err = tui.SetWidget("templateTable", template.Table); err != nil {
+		return err
+	}
+	template.GenerateInitTemplate(tui, work)
+
+	// calendar page
+	calendar := widgets.NewCalendar(c.ChronoWorkUC, c.SettingUC, errorHandler, theme)
+	tui.SetMainPage("calendar", calendar.Layout, false)
+	if err = tui.SetWidget("calendarTable", calendar.Table); err != nil {
+		return err
+	}
+	calendar.GenerateInitCalendar(tui, work)
+
+	// timeline page
+	timeline := widgets.NewTimeline(c.ChronoWorkUC, errorHandler, theme)
+	tui.SetMainPage("timeline", timeline.View, false)
+	if err = tui.SetWidget("timelineView", timeline.View); err != nil {
+		return err
+	}
+	timeline.GenerateInitTimeline(tui)
+
+	// chart page
+	chart := widgets.NewChart(c.ChronoWorkUC, errorHandler, theme)
+	chart.GenerateInitChart(tui)
+	tui.SetMainPage("chart", chart.Layout, false)
+	if err = tui.SetWidget("chartForm", chart.Form); err != nil {
+		return err
+	}
+
+	// timesheet page
+	timesheet := widgets.NewTimesheet(c.ChronoWorkUC, errorHandler, theme)
+	tui.SetMainPage("timesheet", timesheet.Layout, false)
+	if err = tui.SetWidget("timesheetForm", timesheet.Form); err != nil {
+		return err
+	}
+	if err = tui.SetWidget("timesheetTable", timesheet.Table); err != nil {
+		return err
+	}
+	timesheet.GenerateInitTimesheet(tui)
+
 	// export page
-	export := widgets.NewExport(c.ChronoWorkUC, c.SettingUC, errorHandler)
+	export := widgets.NewExport(c.ChronoWorkUC, c.SettingUC, errorHandler, theme)
 	export.GenerateInitExport(tui)
 	tui.SetMainPage("export", export.Form, false)
 	if err = tui.SetWidget("exportForm", export.Form); err != nil {
 		return err
 	}
 
-	menu := widgets.NewMenu(c.SettingUC)
-	menu = menu.GenerateInitMenu(tui, work, settingWidget, project)
+	// import page
+	imp := widgets.NewImport(c.ICSImportUC, c.ReportImportUC, c.TimewImportUC, errorHandler, theme)
+	imp.GenerateInitImport(tui)
+	tui.SetMainPage("import", imp.Layout, false)
+	if err = tui.SetWidget("importForm", imp.Form); err != nil {
+		return err
+	}
+
+	// audit page
+	audit := widgets.NewAudit(c.AuditLogUC, errorHandler, theme)
+	audit.GenerateInitAudit(tui)
+	tui.SetMainPage("audit", audit.Layout, false)
+	if err = tui.SetWidget("auditForm", audit.Form); err != nil {
+		return err
+	}
+	if err = tui.SetWidget("auditTable", audit.Table); err != nil {
+		return err
+	}
+
+	// notification page
+	notification := widgets.NewNotification(theme)
+	tui.SetMainPage("notification", notification.Table, false)
+	if err = tui.SetWidget("notificationTable", notification.Table); err != nil {
+		return err
+	}
+
+	menu := widgets.NewMenu(c.SettingUC, c.BackupUC, errorHandler)
+	menu = menu.GenerateInitMenu(tui, work, settingWidget, project, notification, template, calendar, timeline, chart, timesheet, imp)
+
+	palette := widgets.NewPalette(c.ChronoWorkUC, c.ProjectTypeUC, errorHandler, theme)
+	palette.GenerateInitPalette(tui, menu, work, form, timer, export, template)
 
 	tui.SetHeader(header, false)
 	tui.SetMenu(menu.List, false)
-	tui.SetWork(mainTitle, form.Form, timer.Wrapper, work.Table, true) // default focus
-	work.TableCapture(tui, form, timer, relativeDays)
+	tui.SetWork(work.Title, form.Form, timer.Wrapper, work.Table, true) // default focus
+	tui.SetStatusBar()
+	if templateErr != nil {
+		errorHandler.ShowErrorWithErr(templateErr, "mainWorkContent")
+	} else if templateCount > 0 {
+		tui.Status.Info("Created %d works from templates.", templateCount)
+	}
+	work.TableCapture(tui, form, timer, audit)
 	form.FormCapture(tui)
 
 	tui.GlobalKeyActions()
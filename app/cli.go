@@ -0,0 +1,404 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/container"
+	"github.com/niiharamegumu/chronowork/db"
+	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/timereport"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+	"github.com/niiharamegumu/chronowork/util/timew"
+)
+
+// runCommand runs a command line subcommand instead of starting the TUI.
+func runCommand(c *container.Container, args []string) error {
+	switch args[0] {
+	case "audit":
+		return auditCommand(c, args[1:])
+	case "keys":
+		return keysCommand(args[1:])
+	case "export-ics":
+		return exportICSCommand(c, args[1:])
+	case "import-ics":
+		return importICSCommand(c, args[1:])
+	case "import-csv":
+		return importCSVCommand(c, args[1:])
+	case "import-timew":
+		return importTimewCommand(c, args[1:])
+	case "export-timew":
+		return exportTimewCommand(c, args[1:])
+	case "timew-report":
+		return timewReportCommand(c, args[1:])
+	case "backup":
+		return backupCommand(c, args[1:])
+	case "restore":
+		return restoreCommand(c, args[1:])
+	default:
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+}
+
+// auditCommand prints the audit history of a single work. The work id 0
+// prints the changes of the closed period.
+//
+//	chronowork audit <work id>
+func auditCommand(c *container.Container, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: chronowork audit <work id>")
+	}
+	id, err := strconv.ParseUint(args[0], 10, 0)
+	if err != nil {
+		return fmt.Errorf("invalid work id: %s", args[0])
+	}
+
+	logs, err := c.AuditLogUC.FindByWorkID(uint(id))
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tACTION\tACTOR\tOLD\tNEW")
+	for _, log := range logs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			log.CreatedAt.Format("2006/01/02 15:04:05"),
+			log.Action,
+			log.Actor,
+			log.OldValue,
+			log.NewValue,
+		)
+	}
+	return w.Flush()
+}
+
+// exportICSCommand writes the tracking intervals from the day of from to the
+// day of to as an iCalendar file in the download path.
+//
+//	chronowork export-ics <from YYYY/MM/DD> <to YYYY/MM/DD>
+func exportICSCommand(c *container.Container, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: chronowork export-ics <from YYYY/MM/DD> <to YYYY/MM/DD>")
+	}
+	start, end, err := parseDateRange(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	path, count, err := c.ChronoWorkUC.ExportICS(start, end, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := printUntracked(os.Stdout, c, start, end); err != nil {
+		return err
+	}
+	if count == 0 {
+		fmt.Println("no tracked intervals to export")
+		return nil
+	}
+	fmt.Printf("exported %d intervals to %s\n", count, path)
+	return nil
+}
+
+// importICSCommand creates works from the events of an iCalendar file from the
+// day of from to the day of to, with the rules of the rule file, and prints the
+// events that were skipped.
+//
+//	chronowork import-ics <file> <from YYYY/MM/DD> <to YYYY/MM/DD>
+func importICSCommand(c *container.Container, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: chronowork import-ics <file> <from YYYY/MM/DD> <to YYYY/MM/DD>")
+	}
+	start, end, err := parseDateRange(args[1], args[2])
+	if err != nil {
+		return err
+	}
+	rules, err := service.LoadICSRules(service.ICSRulesPath())
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	result, err := c.ICSImportUC.Import(f, start, end, rules)
+	if err != nil {
+		return err
+	}
+	for _, reason := range result.Skipped {
+		fmt.Printf("skipped %s\n", reason)
+	}
+	fmt.Printf("imported %d works\n", result.Created)
+	return nil
+}
+
+// importCSVCommand creates works from the entries of a Toggl Track or Clockify
+// detailed report, optionally from the day of from to the day of to, with the
+// report mapping file, and prints the unmapped, duplicate, conflicting and
+// skipped items.
+//
+//	chronowork import-csv <toggl|clockify> <file> [<from YYYY/MM/DD> <to YYYY/MM/DD>]
+func importCSVCommand(c *container.Container, args []string) error {
+	if len(args) != 2 && len(args) != 4 {
+		return fmt.Errorf("usage: chronowork import-csv <toggl|clockify> <file> [<from YYYY/MM/DD> <to YYYY/MM/DD>]")
+	}
+	format, err := timereport.ParseFormat(args[0])
+	if err != nil {
+		return err
+	}
+	var start, end time.Time
+	if len(args) == 4 {
+		if start, end, err = parseDateRange(args[2], args[3]); err != nil {
+			return err
+		}
+	}
+	mapping, err := service.LoadReportMapping(service.ReportMappingPath())
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(args[1])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	result, err := c.ReportImportUC.Import(f, format, start, end, mapping)
+	if err != nil {
+		return err
+	}
+	for _, item := range result.Unmapped {
+		fmt.Printf("unmapped %s\n", item)
+	}
+	for _, item := range result.Duplicates {
+		fmt.Printf("duplicate %s\n", item)
+	}
+	for _, item := range result.Conflicts {
+		fmt.Printf("conflict %s\n", item)
+	}
+	for _, reason := range result.Skipped {
+		fmt.Printf("skipped %s\n", reason)
+	}
+	fmt.Printf("imported %d works\n", result.Created)
+	return nil
+}
+
+// importTimewCommand creates works from the intervals of a Timewarrior data
+// file, a data directory or the JSON of "timew export", optionally from the day
+// of from to the day of to, and prints the intervals and works that were
+// skipped.
+//
+//	chronowork import-timew <file|dir> [<from YYYY/MM/DD> <to YYYY/MM/DD>]
+func importTimewCommand(c *container.Container, args []string) error {
+	if len(args) != 1 && len(args) != 3 {
+		return fmt.Errorf("usage: chronowork import-timew <file|dir> [<from YYYY/MM/DD> <to YYYY/MM/DD>]")
+	}
+	var start, end time.Time
+	if len(args) == 3 {
+		var err error
+		if start, end, err = parseDateRange(args[1], args[2]); err != nil {
+			return err
+		}
+	}
+	intervals, err := timew.ReadPath(args[0])
+	if err != nil {
+		return err
+	}
+
+	result, err := c.TimewImportUC.Import(intervals, start, end)
+	if err != nil {
+		return err
+	}
+	for _, reason := range result.Skipped {
+		fmt.Printf("skipped %s\n", reason)
+	}
+	fmt.Printf("imported %d works\n", result.Created)
+	return nil
+}
+
+// exportTimewCommand writes the tracking intervals from the day of from to the
+// day of to as Timewarrior data files to a new directory in the download path.
+//
+//	chronowork export-timew <from YYYY/MM/DD> <to YYYY/MM/DD>
+func exportTimewCommand(c *container.Container, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: chronowork export-timew <from YYYY/MM/DD> <to YYYY/MM/DD>")
+	}
+	start, end, err := parseDateRange(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	dir, count, err := c.ChronoWorkUC.ExportTimew(start, end, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := printUntracked(os.Stdout, c, start, end); err != nil {
+		return err
+	}
+	if count == 0 {
+		fmt.Println("no tracked intervals to export")
+		return nil
+	}
+	fmt.Printf("exported %d intervals to %s\n", count, dir)
+	return nil
+}
+
+// timewReportCommand writes the tracking intervals from the day of from to the
+// day of to to the standard output as the input of a Timewarrior report
+// extension, so that the extensions can be used on chronowork data.
+//
+//	chronowork timew-report <from YYYY/MM/DD> <to YYYY/MM/DD> | python3 ~/.timewarrior/extensions/totals.py
+func timewReportCommand(c *container.Container, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: chronowork timew-report <from YYYY/MM/DD> <to YYYY/MM/DD>")
+	}
+	start, end, err := parseDateRange(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	intervals, err := c.ChronoWorkUC.TimewIntervals(start, end)
+	if err != nil {
+		return err
+	}
+	// the standard output is read by the extension
+	if err := printUntracked(os.Stderr, c, start, end); err != nil {
+		return err
+	}
+	return timew.WriteReport(os.Stdout, intervals, start, end)
+}
+
+// printUntracked prints the works of the range whose time is not covered by
+// tracking intervals and is therefore missing from an export of the intervals.
+func printUntracked(w io.Writer, c *container.Container, start, end time.Time) error {
+	untracked, err := c.ChronoWorkUC.UntrackedWorks(start, end)
+	if err != nil {
+		return err
+	}
+	for _, u := range untracked {
+		fmt.Fprintf(w, "not exported %s %s: %s without intervals\n",
+			u.Work.CreatedAt.Format("2006/01/02"), u.Work.Title, timeutil.FormatTime(u.Seconds))
+	}
+	return nil
+}
+
+// parseDateRange parses the dates of a command as the range from the start of
+// the day of from to the end of the day of to.
+func parseDateRange(from, to string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006/01/02", from, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date: %s", from)
+	}
+	end, err := time.ParseInLocation("2006/01/02", to, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date: %s", to)
+	}
+	return timeutil.StartOfDay(start), timeutil.EndOfDay(end), nil
+}
+
+// backupCommand writes a snapshot of the database to a new archive in the
+// backup directory and removes the oldest archives beyond the number to keep.
+// It can run while the TUI is open.
+//
+//	chronowork backup
+func backupCommand(c *container.Container, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: chronowork backup")
+	}
+	config, err := service.LoadBackupConfig(service.BackupConfigPath())
+	if err != nil {
+		return err
+	}
+
+	path, err := c.BackupUC.Backup(config.Dir, config.Keep, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("backed up to %s\n", path)
+	return nil
+}
+
+// restoreCommand replaces the database with the database of an archive, or of
+// the latest archive in the backup directory, after verifying its integrity.
+// The current database is backed up first. It refuses to run while a TUI or
+// another command has the database open, as the exclusive lock is taken before
+// the database is opened.
+//
+//	chronowork restore [<archive>]
+func restoreCommand(c *container.Container, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: chronowork restore [<archive>]")
+	}
+	config, err := service.LoadBackupConfig(service.BackupConfigPath())
+	if err != nil {
+		return err
+	}
+	var archive string
+	if len(args) == 1 {
+		archive = args[0]
+	} else {
+		archives, err := c.BackupUC.List(config.Dir)
+		if err != nil {
+			return err
+		}
+		if len(archives) == 0 {
+			return fmt.Errorf("no backups in %s", config.Dir)
+		}
+		archive = archives[0]
+	}
+
+	// nothing is pruned so that the restored archive is not removed
+	current, err := c.BackupUC.Backup(config.Dir, 0, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("backed up the current database to %s\n", current)
+
+	if err := db.CloseDB(); err != nil {
+		return err
+	}
+	if err := c.BackupUC.Restore(archive, db.Path()); err != nil {
+		return err
+	}
+	fmt.Printf("restored %s\n", archive)
+	return nil
+}
+
+// keysCommand prints the key bindings of every context, including overrides
+// from the keymap config file.
+//
+//	chronowork keys
+func keysCommand(args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: chronowork keys")
+	}
+	keymap, err := service.LoadKeymap(service.KeymapPath())
+	if err != nil {
+		return err
+	}
+	contexts := []string{
+		service.ContextGlobal,
+		service.ContextMenu,
+		service.ContextWork,
+		service.ContextForm,
+		service.ContextProject,
+		service.ContextTag,
+		service.ContextTemplate,
+		service.ContextCalendar,
+		service.ContextTimeline,
+		service.ContextTimesheet,
+	}
+	for i, context := range contexts {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("[%s]\n%s\n", context, keymap.Help(context))
+	}
+	return nil
+}
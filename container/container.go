@@ -12,16 +12,26 @@ type Container struct {
 	DB *gorm.DB
 
 	// Repositories
-	ChronoWorkRepo  repository.ChronoWorkRepository
-	TagRepo         repository.TagRepository
-	ProjectTypeRepo repository.ProjectTypeRepository
-	SettingRepo     repository.SettingRepository
+	ChronoWorkRepo   repository.ChronoWorkRepository
+	TagRepo          repository.TagRepository
+	ProjectTypeRepo  repository.ProjectTypeRepository
+	SettingRepo      repository.SettingRepository
+	AuditLogRepo     repository.AuditLogRepository
+	WorkTemplateRepo repository.WorkTemplateRepository
+	BackupRepo       repository.BackupRepository
 
 	// Use Cases
-	ChronoWorkUC  *usecase.ChronoWorkUseCase
-	TagUC         *usecase.TagUseCase
-	ProjectTypeUC *usecase.ProjectTypeUseCase
-	SettingUC     *usecase.SettingUseCase
+	ChronoWorkUC   *usecase.ChronoWorkUseCase
+	TagUC          *usecase.TagUseCase
+	ProjectTypeUC  *usecase.ProjectTypeUseCase
+	SettingUC      *usecase.SettingUseCase
+	AuditLogUC     *usecase.AuditLogUseCase
+	QuickAddUC     *usecase.QuickAddUseCase
+	WorkTemplateUC *usecase.WorkTemplateUseCase
+	ICSImportUC    *usecase.ICSImportUseCase
+	ReportImportUC *usecase.ReportImportUseCase
+	TimewImportUC  *usecase.TimewImportUseCase
+	BackupUC       *usecase.BackupUseCase
 }
 
 // New creates a new Container with all dependencies initialized.
@@ -31,24 +41,44 @@ func New(db *gorm.DB) *Container {
 	tagRepo := repository.NewGormTagRepository(db)
 	projectTypeRepo := repository.NewGormProjectTypeRepository(db)
 	settingRepo := repository.NewGormSettingRepository(db)
+	auditLogRepo := repository.NewGormAuditLogRepository(db)
+	workTemplateRepo := repository.NewGormWorkTemplateRepository(db)
+	backupRepo := repository.NewGormBackupRepository(db)
 
 	// Initialize use cases
-	chronoWorkUC := usecase.NewChronoWorkUseCase(chronoWorkRepo)
+	chronoWorkUC := usecase.NewChronoWorkUseCase(chronoWorkRepo, auditLogRepo, settingRepo)
 	tagUC := usecase.NewTagUseCase(tagRepo)
-	projectTypeUC := usecase.NewProjectTypeUseCase(projectTypeRepo)
+	projectTypeUC := usecase.NewProjectTypeUseCase(projectTypeRepo, tagRepo, settingRepo)
 	settingUC := usecase.NewSettingUseCase(settingRepo)
+	auditLogUC := usecase.NewAuditLogUseCase(auditLogRepo)
+	quickAddUC := usecase.NewQuickAddUseCase(chronoWorkUC, projectTypeUC)
+	workTemplateUC := usecase.NewWorkTemplateUseCase(workTemplateRepo, chronoWorkUC, projectTypeUC)
+	icsImportUC := usecase.NewICSImportUseCase(chronoWorkUC, projectTypeUC)
+	reportImportUC := usecase.NewReportImportUseCase(chronoWorkUC, projectTypeUC)
+	timewImportUC := usecase.NewTimewImportUseCase(chronoWorkUC, projectTypeUC)
+	backupUC := usecase.NewBackupUseCase(backupRepo)
 
 	return &Container{
 		DB: db,
 
-		ChronoWorkRepo:  chronoWorkRepo,
-		TagRepo:         tagRepo,
-		ProjectTypeRepo: projectTypeRepo,
-		SettingRepo:     settingRepo,
+		ChronoWorkRepo:   chronoWorkRepo,
+		TagRepo:          tagRepo,
+		ProjectTypeRepo:  projectTypeRepo,
+		SettingRepo:      settingRepo,
+		AuditLogRepo:     auditLogRepo,
+		WorkTemplateRepo: workTemplateRepo,
+		BackupRepo:       backupRepo,
 
-		ChronoWorkUC:  chronoWorkUC,
-		TagUC:         tagUC,
-		ProjectTypeUC: projectTypeUC,
-		SettingUC:     settingUC,
+		ChronoWorkUC:   chronoWorkUC,
+		TagUC:          tagUC,
+		ProjectTypeUC:  projectTypeUC,
+		SettingUC:      settingUC,
+		AuditLogUC:     auditLogUC,
+		QuickAddUC:     quickAddUC,
+		WorkTemplateUC: workTemplateUC,
+		ICSImportUC:    icsImportUC,
+		ReportImportUC: reportImportUC,
+		TimewImportUC:  timewImportUC,
+		BackupUC:       backupUC,
 	}
 }
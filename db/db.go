@@ -20,36 +20,50 @@ func ConnectDB() error {
 		return nil
 	}
 
-	var dbPath string
-	rootPath := os.Getenv("CHRONOWORK_ROOT_PATH")
-
-	databaseName := os.Getenv("DATABASE_NAME")
-	if databaseName == "" {
-		databaseName = "sqlite.db"
-	}
-	if rootPath != "" {
-		dbPath = fmt.Sprintf("%s/%s", os.Getenv("CHRONOWORK_ROOT_PATH"), databaseName)
-	} else {
+	if os.Getenv("CHRONOWORK_ROOT_PATH") == "" {
 		log.Println("CHRONOWORK_ROOT_PATH is not set")
-		dbPath = fmt.Sprintf("%s/%s", ".", databaseName)
 	}
 
-	DB, err = gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	DB, err = gorm.Open(sqlite.Open(Path()), &gorm.Config{})
 	if err != nil {
 		return err
 	}
 
+	// the intervals of works tracked before they were recorded are restored once
+	backfillIntervals := !DB.Migrator().HasTable(&models.WorkInterval{})
+
 	// auto migration for models
 	DB.AutoMigrate(
 		&models.ChronoWork{},
 		&models.ProjectType{},
 		&models.Tag{},
 		&models.Setting{},
+		&models.AuditLog{},
+		&models.WorkTemplate{},
+		&models.WorkInterval{},
 	)
 
+	if backfillIntervals {
+		if err := models.BackfillWorkIntervals(DB); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// Path returns the path of the database file.
+func Path() string {
+	databaseName := os.Getenv("DATABASE_NAME")
+	if databaseName == "" {
+		databaseName = "sqlite.db"
+	}
+	if rootPath := os.Getenv("CHRONOWORK_ROOT_PATH"); rootPath != "" {
+		return fmt.Sprintf("%s/%s", rootPath, databaseName)
+	}
+	return fmt.Sprintf("%s/%s", ".", databaseName)
+}
+
 func CloseDB() error {
 	if DB == nil {
 		return nil
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrInUse is returned when the database is locked by another chronowork.
+var ErrInUse = errors.New("the database is in use by another chronowork; quit it and try again")
+
+var lockFile *os.File
+
+// LockShared locks the database for the TUI and the commands. Any number of
+// them can hold the lock, but a restore cannot run while they do.
+func LockShared() error {
+	return lock(false)
+}
+
+// LockExclusive locks the database for a restore, which replaces the database
+// file. It fails with ErrInUse while a TUI or a command is running.
+func LockExclusive() error {
+	return lock(true)
+}
+
+// Unlock releases the lock of the database.
+func Unlock() error {
+	if lockFile == nil {
+		return nil
+	}
+	err := unlockFile(lockFile)
+	if closeErr := lockFile.Close(); err == nil {
+		err = closeErr
+	}
+	lockFile = nil
+	return err
+}
+
+// lock locks the lock file next to the database, which is never replaced by a
+// restore unlike the database file itself.
+func lock(exclusive bool) error {
+	if lockFile != nil {
+		return nil
+	}
+	f, err := os.OpenFile(Path()+".lock", os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := lockFileNB(f, exclusive); err != nil {
+		f.Close()
+		return err
+	}
+	lockFile = f
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package db
+
+import "os"
+
+// the database is not locked on platforms without flock
+func lockFileNB(f *os.File, exclusive bool) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// AuditAction identifies the kind of change recorded in an AuditLog.
+type AuditAction string
+
+const (
+	// AuditActionCreate records the creation of a work.
+	AuditActionCreate AuditAction = "create"
+	// AuditActionUpdate records a change of title, project or tag.
+	AuditActionUpdate AuditAction = "update"
+	// AuditActionUpdateTotalSeconds records an overwrite of the total seconds.
+	AuditActionUpdateTotalSeconds AuditAction = "update_total_seconds"
+	// AuditActionMove records a move of the work to another date.
+	AuditActionMove AuditAction = "move"
+	// AuditActionConfirm records a toggle of the confirmed flag.
+	AuditActionConfirm AuditAction = "confirm"
+	// AuditActionStartTracking records the start of tracking.
+	AuditActionStartTracking AuditAction = "start_tracking"
+	// AuditActionStopTracking records the end of tracking and the added time.
+	AuditActionStopTracking AuditAction = "stop_tracking"
+	// AuditActionClosePeriod records a confirmation done by closing a period.
+	AuditActionClosePeriod AuditAction = "close_period"
+	// AuditActionCloseUntil records a move of the end of the closed period.
+	// It is not about a single work and is recorded with a WorkID of 0.
+	AuditActionCloseUntil AuditAction = "close_until"
+	// AuditActionReopen records the explicit reopening of a confirmed work.
+	AuditActionReopen AuditAction = "reopen"
+	// AuditActionDelete records the deletion of a work.
+	AuditActionDelete AuditAction = "delete"
+)
+
+// AuditLog represents a single append-only record of a change to a work.
+type AuditLog struct {
+	ID        uint
+	WorkID    uint
+	Action    AuditAction
+	Actor     string
+	OldValue  string
+	NewValue  string
+	CreatedAt time.Time
+}
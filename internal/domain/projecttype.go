@@ -19,3 +19,13 @@ func (p *ProjectType) GetTagNames() []string {
 	}
 	return tagNames
 }
+
+// HasTag reports whether the tag named name is allowed on the project.
+func (p *ProjectType) HasTag(name string) bool {
+	for _, tag := range p.Tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
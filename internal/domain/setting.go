@@ -9,6 +9,10 @@ type Setting struct {
 	PersonDay          uint
 	DisplayAsPersonDay bool
 	DownloadPath       string
+	Theme              string
+	Language           string
+	AllowTagCreation   bool
+	ClosedUntil        time.Time
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// WorkInterval is a period during which a ChronoWork was tracked.
+type WorkInterval struct {
+	ID           uint
+	ChronoWorkID uint
+	StartTime    time.Time
+	// EndTime is zero while the interval is still being tracked.
+	EndTime time.Time
+}
+
+// IsOpen reports whether the interval is still being tracked.
+func (i WorkInterval) IsOpen() bool {
+	return i.EndTime.IsZero()
+}
+
+// Seconds returns the whole seconds of a finished interval, as they are added
+// to the total time of its work.
+func (i WorkInterval) Seconds() int {
+	return int(math.Floor(i.EndTime.Sub(i.StartTime).Seconds()))
+}
+
+// ResizeIntervals returns the finished intervals of a work, sorted by start,
+// changed so that their seconds add up to totalSeconds. Missing time is added
+// as a new interval with a zero ID that follows the last interval or, without
+// one, ends at the creation time of the work but does not start before its
+// day. Excess time is cut from the latest intervals, which are dropped once
+// they are empty.
+func ResizeIntervals(intervals []WorkInterval, totalSeconds int, createdAt time.Time) []WorkInterval {
+	resized := append([]WorkInterval(nil), intervals...)
+	sort.SliceStable(resized, func(i, j int) bool {
+		return resized[i].StartTime.Before(resized[j].StartTime)
+	})
+	sum := 0
+	for _, interval := range resized {
+		sum += interval.Seconds()
+	}
+
+	if missing := totalSeconds - sum; missing > 0 {
+		duration := time.Duration(missing) * time.Second
+		var start time.Time
+		if len(resized) > 0 {
+			start = resized[len(resized)-1].EndTime
+		} else {
+			dayStart := time.Date(createdAt.Year(), createdAt.Month(), createdAt.Day(), 0, 0, 0, 0, createdAt.Location())
+			start = createdAt.Add(-duration)
+			if start.Before(dayStart) {
+				start = dayStart
+			}
+		}
+		return append(resized, WorkInterval{StartTime: start, EndTime: start.Add(duration)})
+	}
+
+	excess := sum - max(totalSeconds, 0)
+	for excess > 0 && len(resized) > 0 {
+		last := &resized[len(resized)-1]
+		seconds := last.Seconds()
+		if seconds <= excess {
+			resized = resized[:len(resized)-1]
+			excess -= seconds
+			continue
+		}
+		last.EndTime = last.StartTime.Add(time.Duration(seconds-excess) * time.Second)
+		excess = 0
+	}
+	return resized
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// WorkTemplate describes a work that is created automatically on the days
+// matching its recurrence rule.
+type WorkTemplate struct {
+	ID              uint
+	Title           string
+	ProjectTypeID   uint
+	TagID           uint
+	EstimateSeconds int
+	Recurrence      string
+	LastCreatedOn   time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	// Relationships (loaded when needed)
+	ProjectType *ProjectType
+	Tag         *Tag
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/models"
+	"gorm.io/gorm"
+)
+
+// GormAuditLogRepository is a GORM implementation of AuditLogRepository.
+type GormAuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewGormAuditLogRepository creates a new GormAuditLogRepository.
+func NewGormAuditLogRepository(db *gorm.DB) *GormAuditLogRepository {
+	return &GormAuditLogRepository{db: db}
+}
+
+// WithTx returns an AuditLogRepository bound to the transaction.
+func (r *GormAuditLogRepository) WithTx(tx Tx) AuditLogRepository {
+	return NewGormAuditLogRepository(tx.(*gorm.DB))
+}
+
+// Create appends a new AuditLog entry.
+func (r *GormAuditLogRepository) Create(entry *domain.AuditLog) error {
+	auditLog := models.AuditLog{
+		WorkID:   entry.WorkID,
+		Action:   string(entry.Action),
+		Actor:    entry.Actor,
+		OldValue: entry.OldValue,
+		NewValue: entry.NewValue,
+	}
+	if err := r.db.Create(&auditLog).Error; err != nil {
+		return err
+	}
+	entry.ID = auditLog.ID
+	entry.CreatedAt = auditLog.CreatedAt
+	return nil
+}
+
+// FindByWorkID finds all AuditLogs of a work in chronological order.
+func (r *GormAuditLogRepository) FindByWorkID(workID uint) ([]domain.AuditLog, error) {
+	var auditLogs []models.AuditLog
+	err := r.db.
+		Where("work_id = ?", workID).
+		Order("created_at asc").
+		Order("id asc").
+		Find(&auditLogs).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainSlice(auditLogs), nil
+}
+
+// toDomain converts a GORM model to a domain entity.
+func (r *GormAuditLogRepository) toDomain(m *models.AuditLog) *domain.AuditLog {
+	return &domain.AuditLog{
+		ID:        m.ID,
+		WorkID:    m.WorkID,
+		Action:    domain.AuditAction(m.Action),
+		Actor:     m.Actor,
+		OldValue:  m.OldValue,
+		NewValue:  m.NewValue,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// toDomainSlice converts a slice of GORM models to domain entities.
+func (r *GormAuditLogRepository) toDomainSlice(ms []models.AuditLog) []domain.AuditLog {
+	ds := make([]domain.AuditLog, len(ms))
+	for i, m := range ms {
+		ds[i] = *r.toDomain(&m)
+	}
+	return ds
+}
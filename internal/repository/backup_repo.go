@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/niiharamegumu/chronowork/models"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// GormBackupRepository is a GORM implementation of BackupRepository for SQLite.
+type GormBackupRepository struct {
+	db *gorm.DB
+}
+
+// NewGormBackupRepository creates a new GormBackupRepository.
+func NewGormBackupRepository(db *gorm.DB) *GormBackupRepository {
+	return &GormBackupRepository{db: db}
+}
+
+// Snapshot writes a consistent copy of the open database to a new file at path.
+// VACUUM INTO reads the database in a single transaction, so the copy is
+// consistent even while the TUI writes to it.
+func (r *GormBackupRepository) Snapshot(path string) error {
+	return r.db.Exec("VACUUM INTO ?", path).Error
+}
+
+// Verify checks that the database file at path is intact and is a ChronoWork database.
+func (r *GormBackupRepository) Verify(path string) error {
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=ro", path)), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	var results []string
+	if err := db.Raw("PRAGMA integrity_check").Scan(&results).Error; err != nil {
+		return err
+	}
+	if len(results) != 1 || results[0] != "ok" {
+		return fmt.Errorf("integrity check failed: %s", strings.Join(results, "; "))
+	}
+	if !db.Migrator().HasTable(&models.ChronoWork{}) {
+		return fmt.Errorf("not a ChronoWork database")
+	}
+	return nil
+}
@@ -19,6 +19,19 @@ func NewGormChronoWorkRepository(db *gorm.DB) *GormChronoWorkRepository {
 	return &GormChronoWorkRepository{db: db}
 }
 
+// Transaction runs fn in a transaction and rolls it back if fn returns an error.
+// Inside another transaction, fn runs in a savepoint of it.
+func (r *GormChronoWorkRepository) Transaction(fn func(tx Tx) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(tx)
+	})
+}
+
+// WithTx returns a ChronoWorkRepository bound to the transaction.
+func (r *GormChronoWorkRepository) WithTx(tx Tx) ChronoWorkRepository {
+	return NewGormChronoWorkRepository(tx.(*gorm.DB))
+}
+
 // Create creates a new ChronoWork entry.
 func (r *GormChronoWorkRepository) Create(title string, projectTypeID, tagID uint) (*domain.ChronoWork, error) {
 	chronoWork := models.ChronoWork{
@@ -37,6 +50,25 @@ func (r *GormChronoWorkRepository) Create(title string, projectTypeID, tagID uin
 	return r.toDomain(&chronoWork), nil
 }
 
+// CreateAt creates a new ChronoWork entry with the given creation time.
+func (r *GormChronoWorkRepository) CreateAt(title string, projectTypeID, tagID uint, createdAt time.Time) (*domain.ChronoWork, error) {
+	chronoWork := models.ChronoWork{
+		Title:         title,
+		ProjectTypeID: projectTypeID,
+		TagID:         tagID,
+		StartTime:     time.Time{},
+		EndTime:       time.Time{},
+		IsTracking:    false,
+		TotalSeconds:  0,
+		Confirmed:     false,
+	}
+	chronoWork.CreatedAt = createdAt
+	if err := r.db.Create(&chronoWork).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomain(&chronoWork), nil
+}
+
 // FindByID finds a ChronoWork by its ID.
 func (r *GormChronoWorkRepository) FindByID(id uint) (*domain.ChronoWork, error) {
 	var chronoWork models.ChronoWork
@@ -76,10 +108,14 @@ func (r *GormChronoWorkRepository) FindTracking() ([]domain.ChronoWork, error) {
 
 // FindByTitleToday finds a ChronoWork by title created today.
 func (r *GormChronoWorkRepository) FindByTitleToday(title string) (*domain.ChronoWork, error) {
+	return r.FindByTitleOnDate(title, time.Now())
+}
+
+// FindByTitleOnDate finds a ChronoWork by title created on the day of date.
+func (r *GormChronoWorkRepository) FindByTitleOnDate(title string, date time.Time) (*domain.ChronoWork, error) {
 	var chronoWorks []models.ChronoWork
-	today := time.Now()
-	startOfDay := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
-	endOfDay := time.Date(today.Year(), today.Month(), today.Day(), 23, 59, 59, 0, time.Local)
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	endOfDay := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, time.Local)
 
 	err := r.db.
 		Preload("ProjectType").
@@ -138,13 +174,55 @@ func (r *GormChronoWorkRepository) Update(id uint, title string, projectTypeID,
 		}).Error
 }
 
-// UpdateTotalSeconds updates the total seconds of a ChronoWork.
+// UpdateTotalSeconds updates the total seconds of a ChronoWork and resizes its
+// finished tracking intervals to add up to them.
 func (r *GormChronoWorkRepository) UpdateTotalSeconds(id uint, totalSeconds int) error {
-	return r.db.Model(&models.ChronoWork{}).Where("id = ?", id).
-		Select("total_seconds").
-		Updates(map[string]interface{}{
-			"total_seconds": totalSeconds,
-		}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var chronoWork models.ChronoWork
+		if err := tx.First(&chronoWork, id).Error; err != nil {
+			return err
+		}
+		var intervals []models.WorkInterval
+		if err := tx.Find(&intervals, "chrono_work_id = ? AND end_time <> ?", id, time.Time{}).Error; err != nil {
+			return err
+		}
+		current := make([]domain.WorkInterval, len(intervals))
+		for i, m := range intervals {
+			current[i] = domain.WorkInterval{ID: m.ID, ChronoWorkID: m.ChronoWorkID, StartTime: m.StartTime, EndTime: m.EndTime}
+		}
+		resized := domain.ResizeIntervals(current, totalSeconds, chronoWork.CreatedAt)
+
+		kept := map[uint]domain.WorkInterval{}
+		for _, interval := range resized {
+			if interval.ID == 0 {
+				if err := tx.Create(&models.WorkInterval{ChronoWorkID: id, StartTime: interval.StartTime, EndTime: interval.EndTime}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			kept[interval.ID] = interval
+		}
+		for _, interval := range current {
+			resizedInterval, ok := kept[interval.ID]
+			if !ok {
+				if err := tx.Unscoped().Delete(&models.WorkInterval{}, interval.ID).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if !resizedInterval.EndTime.Equal(interval.EndTime) {
+				if err := tx.Model(&models.WorkInterval{}).Where("id = ?", interval.ID).Update("end_time", resizedInterval.EndTime).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Model(&models.ChronoWork{}).Where("id = ?", id).
+			Select("total_seconds").
+			Updates(map[string]interface{}{
+				"total_seconds": totalSeconds,
+			}).Error
+	})
 }
 
 // UpdateConfirmed updates the confirmed status of a ChronoWork.
@@ -156,17 +234,82 @@ func (r *GormChronoWorkRepository) UpdateConfirmed(id uint, confirmed bool) erro
 		}).Error
 }
 
-// StartTracking starts tracking a ChronoWork.
+// UpdateCreatedAt moves a ChronoWork to another creation time and shifts its
+// tracking intervals by the same amount.
+func (r *GormChronoWorkRepository) UpdateCreatedAt(id uint, createdAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var chronoWork models.ChronoWork
+		if err := tx.First(&chronoWork, id).Error; err != nil {
+			return err
+		}
+		offset := createdAt.Sub(chronoWork.CreatedAt)
+		var intervals []models.WorkInterval
+		if err := tx.Find(&intervals, "chrono_work_id = ?", id).Error; err != nil {
+			return err
+		}
+		for _, interval := range intervals {
+			updates := map[string]interface{}{"start_time": interval.StartTime.Add(offset)}
+			if !interval.EndTime.IsZero() {
+				updates["end_time"] = interval.EndTime.Add(offset)
+			}
+			if err := tx.Model(&models.WorkInterval{}).Where("id = ?", interval.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.ChronoWork{}).Where("id = ?", id).
+			Select("created_at").
+			Updates(map[string]interface{}{
+				"created_at": createdAt,
+			}).Error
+	})
+}
+
+// FindIntervalsInRange finds the tracking intervals overlapping a time range,
+// including the intervals still being tracked.
+func (r *GormChronoWorkRepository) FindIntervalsInRange(startTime, endTime time.Time) ([]domain.WorkInterval, error) {
+	var intervals []models.WorkInterval
+	err := r.db.
+		Order("start_time").
+		Find(&intervals, "start_time <= ? AND (end_time >= ? OR end_time = ?)", endTime, startTime, time.Time{}).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.WorkInterval, len(intervals))
+	for i, m := range intervals {
+		result[i] = domain.WorkInterval{
+			ID:           m.ID,
+			ChronoWorkID: m.ChronoWorkID,
+			StartTime:    m.StartTime,
+			EndTime:      m.EndTime,
+		}
+	}
+	return result, nil
+}
+
+// CreateInterval records a finished tracking interval of a ChronoWork without changing its total time.
+func (r *GormChronoWorkRepository) CreateInterval(chronoWorkID uint, startTime, endTime time.Time) error {
+	return r.db.Create(&models.WorkInterval{ChronoWorkID: chronoWorkID, StartTime: startTime, EndTime: endTime}).Error
+}
+
+// StartTracking starts tracking a ChronoWork and opens a tracking interval.
 func (r *GormChronoWorkRepository) StartTracking(id uint) error {
-	return r.db.Model(&models.ChronoWork{}).Where("id = ?", id).
-		Updates(map[string]interface{}{
-			"start_time":  time.Now(),
-			"end_time":    time.Time{},
-			"is_tracking": true,
-		}).Error
+	startTime := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.ChronoWork{}).Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"start_time":  startTime,
+				"end_time":    time.Time{},
+				"is_tracking": true,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&models.WorkInterval{ChronoWorkID: id, StartTime: startTime}).Error
+	})
 }
 
-// StopTracking stops tracking a ChronoWork and calculates total time.
+// StopTracking stops tracking a ChronoWork, closes its tracking interval and calculates total time.
 func (r *GormChronoWorkRepository) StopTracking(id uint) error {
 	var chronoWork models.ChronoWork
 	if err := r.db.First(&chronoWork, id).Error; err != nil {
@@ -176,17 +319,30 @@ func (r *GormChronoWorkRepository) StopTracking(id uint) error {
 	endTime := time.Now()
 	elapsed := int(math.Floor(endTime.Sub(chronoWork.StartTime).Seconds()))
 
-	return r.db.Model(&models.ChronoWork{}).Where("id = ?", id).
-		Updates(map[string]interface{}{
-			"end_time":      endTime,
-			"is_tracking":   false,
-			"total_seconds": gorm.Expr("total_seconds + ?", elapsed),
-		}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.ChronoWork{}).Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"end_time":      endTime,
+				"is_tracking":   false,
+				"total_seconds": gorm.Expr("total_seconds + ?", elapsed),
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.WorkInterval{}).
+			Where("chrono_work_id = ? AND end_time = ?", id, time.Time{}).
+			Update("end_time", endTime).Error
+	})
 }
 
-// Delete permanently deletes a ChronoWork.
+// Delete permanently deletes a ChronoWork and its tracking intervals.
 func (r *GormChronoWorkRepository) Delete(id uint) error {
-	return r.db.Unscoped().Delete(&models.ChronoWork{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("chrono_work_id = ?", id).Delete(&models.WorkInterval{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&models.ChronoWork{}, id).Error
+	})
 }
 
 // toDomain converts a GORM model to a domain entity.
@@ -7,33 +7,54 @@ import (
 	"github.com/niiharamegumu/chronowork/internal/domain"
 )
 
+// Tx is an open transaction of a repository implementation.
+// Repositories bound to it with WithTx read and write inside the transaction.
+type Tx any
+
 // ChronoWorkRepository defines operations for ChronoWork persistence.
 type ChronoWorkRepository interface {
+	// Transaction runs fn in a transaction and rolls it back if fn returns an error.
+	Transaction(fn func(tx Tx) error) error
+	// WithTx returns a ChronoWorkRepository bound to the transaction.
+	WithTx(tx Tx) ChronoWorkRepository
 	// Create creates a new ChronoWork entry.
 	Create(title string, projectTypeID, tagID uint) (*domain.ChronoWork, error)
+	// CreateAt creates a new ChronoWork entry with the given creation time.
+	CreateAt(title string, projectTypeID, tagID uint, createdAt time.Time) (*domain.ChronoWork, error)
 	// FindByID finds a ChronoWork by its ID.
 	FindByID(id uint) (*domain.ChronoWork, error)
 	// FindInRange finds ChronoWorks within a time range.
 	FindInRange(startTime, endTime time.Time) ([]domain.ChronoWork, error)
 	// FindByTitleToday finds a ChronoWork by title created today.
 	FindByTitleToday(title string) (*domain.ChronoWork, error)
+	// FindByTitleOnDate finds a ChronoWork by title created on the day of date.
+	FindByTitleOnDate(title string, date time.Time) (*domain.ChronoWork, error)
 	// FindTracking finds all currently tracking ChronoWorks.
 	FindTracking() ([]domain.ChronoWork, error)
+	// FindIntervalsInRange finds the tracking intervals overlapping a time range,
+	// including the intervals still being tracked.
+	FindIntervalsInRange(startTime, endTime time.Time) ([]domain.WorkInterval, error)
+	// CreateInterval records a finished tracking interval of a ChronoWork without changing its total time.
+	CreateInterval(chronoWorkID uint, startTime, endTime time.Time) error
 	// FindByProjectTypeID finds ChronoWorks by project type ID.
 	FindByProjectTypeID(projectTypeID uint) ([]domain.ChronoWork, error)
 	// GetAll returns all ChronoWorks with optional ordering and limit.
 	GetAll(orderField string, limit int) ([]domain.ChronoWork, error)
 	// Update updates a ChronoWork's title, projectTypeID, and tagID.
 	Update(id uint, title string, projectTypeID, tagID uint) error
-	// UpdateTotalSeconds updates the total seconds of a ChronoWork.
+	// UpdateTotalSeconds updates the total seconds of a ChronoWork and resizes its
+	// finished tracking intervals to add up to them (see domain.ResizeIntervals).
 	UpdateTotalSeconds(id uint, totalSeconds int) error
 	// UpdateConfirmed updates the confirmed status of a ChronoWork.
 	UpdateConfirmed(id uint, confirmed bool) error
-	// StartTracking starts tracking a ChronoWork.
+	// UpdateCreatedAt moves a ChronoWork to another creation time and shifts its
+	// tracking intervals by the same amount.
+	UpdateCreatedAt(id uint, createdAt time.Time) error
+	// StartTracking starts tracking a ChronoWork and opens a tracking interval.
 	StartTracking(id uint) error
-	// StopTracking stops tracking a ChronoWork and calculates total time.
+	// StopTracking stops tracking a ChronoWork, closes its tracking interval and calculates total time.
 	StopTracking(id uint) error
-	// Delete permanently deletes a ChronoWork.
+	// Delete permanently deletes a ChronoWork and its tracking intervals.
 	Delete(id uint) error
 }
 
@@ -75,8 +96,46 @@ type ProjectTypeRepository interface {
 
 // SettingRepository defines operations for Setting persistence.
 type SettingRepository interface {
+	// WithTx returns a SettingRepository bound to the transaction.
+	WithTx(tx Tx) SettingRepository
 	// Get retrieves the current setting or creates a default one.
 	Get() (*domain.Setting, error)
 	// Update updates the setting.
 	Update(setting *domain.Setting) error
+	// UpdateClosedUntil updates the end of the last closed accounting period.
+	UpdateClosedUntil(closedUntil time.Time) error
+}
+
+// AuditLogRepository defines append-only operations for AuditLog persistence.
+type AuditLogRepository interface {
+	// WithTx returns an AuditLogRepository bound to the transaction.
+	WithTx(tx Tx) AuditLogRepository
+	// Create appends a new AuditLog entry.
+	Create(entry *domain.AuditLog) error
+	// FindByWorkID finds all AuditLogs of a work in chronological order.
+	FindByWorkID(workID uint) ([]domain.AuditLog, error)
+}
+
+// WorkTemplateRepository defines operations for WorkTemplate persistence.
+type WorkTemplateRepository interface {
+	// Create creates a new WorkTemplate and sets its ID.
+	Create(template *domain.WorkTemplate) error
+	// FindByID finds a WorkTemplate by its ID.
+	FindByID(id uint) (*domain.WorkTemplate, error)
+	// FindAll finds all WorkTemplates with project and tag preloaded.
+	FindAll() ([]domain.WorkTemplate, error)
+	// Update updates a WorkTemplate's title, project, tag, estimate and recurrence.
+	Update(template *domain.WorkTemplate) error
+	// UpdateLastCreatedOn records the day a work was last created from the WorkTemplate.
+	UpdateLastCreatedOn(id uint, date time.Time) error
+	// Delete permanently deletes a WorkTemplate.
+	Delete(id uint) error
+}
+
+// BackupRepository defines operations for database snapshots.
+type BackupRepository interface {
+	// Snapshot writes a consistent copy of the open database to a new file at path.
+	Snapshot(path string) error
+	// Verify checks that the database file at path is intact and is a ChronoWork database.
+	Verify(path string) error
 }
@@ -0,0 +1,67 @@
+package mock
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository"
+)
+
+// AuditLogRepository is an in-memory mock of repository.AuditLogRepository.
+type AuditLogRepository struct {
+	mu        sync.RWMutex
+	data      []domain.AuditLog
+	nextID    uint
+	createErr error
+}
+
+// NewAuditLogRepository creates a new mock AuditLogRepository.
+func NewAuditLogRepository() *AuditLogRepository {
+	return &AuditLogRepository{
+		nextID: 1,
+	}
+}
+
+// SetCreateError sets an error to be returned by Create (for testing error cases).
+func (r *AuditLogRepository) SetCreateError(err error) {
+	r.createErr = err
+}
+
+// WithTx returns the repository itself, which sees every change at once.
+func (r *AuditLogRepository) WithTx(tx repository.Tx) repository.AuditLogRepository {
+	return r
+}
+
+// Create appends a new AuditLog entry.
+func (r *AuditLogRepository) Create(entry *domain.AuditLog) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.ID = r.nextID
+	entry.CreatedAt = time.Now()
+	r.data = append(r.data, *entry)
+	r.nextID++
+	return nil
+}
+
+// FindByWorkID finds all AuditLogs of a work in chronological order.
+func (r *AuditLogRepository) FindByWorkID(workID uint) ([]domain.AuditLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []domain.AuditLog
+	for _, entry := range r.data {
+		if entry.WorkID == workID {
+			result = append(result, entry)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"bytes"
+	"errors"
+	"os"
+)
+
+// backupContent is the content of the database files written by the mock.
+var backupContent = []byte("chronowork mock database")
+
+// BackupRepository is a file-based mock of repository.BackupRepository.
+// Its snapshots hold a fixed content and only files with that content verify.
+type BackupRepository struct{}
+
+// NewBackupRepository creates a new mock BackupRepository.
+func NewBackupRepository() *BackupRepository {
+	return &BackupRepository{}
+}
+
+// Snapshot writes the mock database to a new file at path.
+func (r *BackupRepository) Snapshot(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(backupContent)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Verify checks that the file at path has the content of the mock database.
+func (r *BackupRepository) Verify(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(data, backupContent) {
+		return errors.New("integrity check failed")
+	}
+	return nil
+}
@@ -3,17 +3,21 @@ package mock
 
 import (
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository"
 )
 
 // ChronoWorkRepository is an in-memory mock of repository.ChronoWorkRepository.
 type ChronoWorkRepository struct {
 	mu          sync.RWMutex
 	data        map[uint]*domain.ChronoWork
+	intervals   []domain.WorkInterval
 	nextID      uint
+	intervalID  uint
 	findByIDErr error
 }
 
@@ -25,6 +29,48 @@ func NewChronoWorkRepository() *ChronoWorkRepository {
 	}
 }
 
+// Transaction runs fn and restores the works and intervals as they were
+// before it if fn returns an error.
+func (r *ChronoWorkRepository) Transaction(fn func(tx repository.Tx) error) error {
+	r.mu.RLock()
+	data := make(map[uint]domain.ChronoWork, len(r.data))
+	for id, cw := range r.data {
+		data[id] = *cw
+	}
+	intervals := append([]domain.WorkInterval(nil), r.intervals...)
+	nextID, intervalID := r.nextID, r.intervalID
+	r.mu.RUnlock()
+
+	err := fn(r)
+	if err == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id := range r.data {
+		if _, ok := data[id]; !ok {
+			delete(r.data, id)
+		}
+	}
+	for id, cw := range data {
+		// the instances handed out before keep their identity
+		if current, ok := r.data[id]; ok {
+			*current = cw
+		} else {
+			restored := cw
+			r.data[id] = &restored
+		}
+	}
+	r.intervals, r.nextID, r.intervalID = intervals, nextID, intervalID
+	return err
+}
+
+// WithTx returns the repository itself, which sees every change at once.
+func (r *ChronoWorkRepository) WithTx(tx repository.Tx) repository.ChronoWorkRepository {
+	return r
+}
+
 // SetFindByIDError sets an error to be returned by FindByID (for testing error cases).
 func (r *ChronoWorkRepository) SetFindByIDError(err error) {
 	r.findByIDErr = err
@@ -54,6 +100,18 @@ func (r *ChronoWorkRepository) Create(title string, projectTypeID, tagID uint) (
 	return cw, nil
 }
 
+// CreateAt creates a new ChronoWork entry with the given creation time.
+func (r *ChronoWorkRepository) CreateAt(title string, projectTypeID, tagID uint, createdAt time.Time) (*domain.ChronoWork, error) {
+	cw, err := r.Create(title, projectTypeID, tagID)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cw.CreatedAt = createdAt
+	return cw, nil
+}
+
 // FindByID finds a ChronoWork by its ID.
 func (r *ChronoWorkRepository) FindByID(id uint) (*domain.ChronoWork, error) {
 	if r.findByIDErr != nil {
@@ -83,6 +141,45 @@ func (r *ChronoWorkRepository) FindInRange(startTime, endTime time.Time) ([]doma
 	return result, nil
 }
 
+// AddInterval records a tracking interval of a ChronoWork (for testing).
+// A zero endTime leaves the interval open.
+func (r *ChronoWorkRepository) AddInterval(chronoWorkID uint, startTime, endTime time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.intervalID++
+	r.intervals = append(r.intervals, domain.WorkInterval{
+		ID:           r.intervalID,
+		ChronoWorkID: chronoWorkID,
+		StartTime:    startTime,
+		EndTime:      endTime,
+	})
+}
+
+// CreateInterval records a finished tracking interval of a ChronoWork without changing its total time.
+func (r *ChronoWorkRepository) CreateInterval(chronoWorkID uint, startTime, endTime time.Time) error {
+	r.AddInterval(chronoWorkID, startTime, endTime)
+	return nil
+}
+
+// FindIntervalsInRange finds the tracking intervals overlapping a time range,
+// including the intervals still being tracked.
+func (r *ChronoWorkRepository) FindIntervalsInRange(startTime, endTime time.Time) ([]domain.WorkInterval, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []domain.WorkInterval
+	for _, interval := range r.intervals {
+		if !interval.StartTime.After(endTime) && (interval.IsOpen() || !interval.EndTime.Before(startTime)) {
+			result = append(result, interval)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+	return result, nil
+}
+
 // FindTracking finds all currently tracking ChronoWorks.
 func (r *ChronoWorkRepository) FindTracking() ([]domain.ChronoWork, error) {
 	r.mu.RLock()
@@ -99,15 +196,19 @@ func (r *ChronoWorkRepository) FindTracking() ([]domain.ChronoWork, error) {
 
 // FindByTitleToday finds a ChronoWork by title created today.
 func (r *ChronoWorkRepository) FindByTitleToday(title string) (*domain.ChronoWork, error) {
+	return r.FindByTitleOnDate(title, time.Now())
+}
+
+// FindByTitleOnDate finds a ChronoWork by title created on the day of date.
+func (r *ChronoWorkRepository) FindByTitleOnDate(title string, date time.Time) (*domain.ChronoWork, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	today := time.Now()
-	startOfDay := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
-	endOfDay := time.Date(today.Year(), today.Month(), today.Day(), 23, 59, 59, 0, time.Local)
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	endOfDay := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, time.Local)
 
 	for _, cw := range r.data {
-		if cw.Title == title && cw.CreatedAt.After(startOfDay) && cw.CreatedAt.Before(endOfDay) {
+		if cw.Title == title && !cw.CreatedAt.Before(startOfDay) && !cw.CreatedAt.After(endOfDay) {
 			return cw, nil
 		}
 	}
@@ -161,7 +262,8 @@ func (r *ChronoWorkRepository) Update(id uint, title string, projectTypeID, tagI
 	return nil
 }
 
-// UpdateTotalSeconds updates the total seconds of a ChronoWork.
+// UpdateTotalSeconds updates the total seconds of a ChronoWork and resizes its
+// finished tracking intervals to add up to them.
 func (r *ChronoWorkRepository) UpdateTotalSeconds(id uint, totalSeconds int) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -170,6 +272,23 @@ func (r *ChronoWorkRepository) UpdateTotalSeconds(id uint, totalSeconds int) err
 	if !ok {
 		return errors.New("record not found")
 	}
+	var finished, others []domain.WorkInterval
+	for _, interval := range r.intervals {
+		if interval.ChronoWorkID == id && !interval.IsOpen() {
+			finished = append(finished, interval)
+		} else {
+			others = append(others, interval)
+		}
+	}
+	for _, interval := range domain.ResizeIntervals(finished, totalSeconds, cw.CreatedAt) {
+		if interval.ID == 0 {
+			r.intervalID++
+			interval.ID = r.intervalID
+			interval.ChronoWorkID = id
+		}
+		others = append(others, interval)
+	}
+	r.intervals = others
 	cw.TotalSeconds = totalSeconds
 	cw.UpdatedAt = time.Now()
 	return nil
@@ -189,6 +308,30 @@ func (r *ChronoWorkRepository) UpdateConfirmed(id uint, confirmed bool) error {
 	return nil
 }
 
+// UpdateCreatedAt moves a ChronoWork to another creation time and shifts its
+// tracking intervals by the same amount.
+func (r *ChronoWorkRepository) UpdateCreatedAt(id uint, createdAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cw, ok := r.data[id]
+	if !ok {
+		return errors.New("record not found")
+	}
+	offset := createdAt.Sub(cw.CreatedAt)
+	for i := range r.intervals {
+		if r.intervals[i].ChronoWorkID == id {
+			r.intervals[i].StartTime = r.intervals[i].StartTime.Add(offset)
+			if !r.intervals[i].IsOpen() {
+				r.intervals[i].EndTime = r.intervals[i].EndTime.Add(offset)
+			}
+		}
+	}
+	cw.CreatedAt = createdAt
+	cw.UpdatedAt = time.Now()
+	return nil
+}
+
 // StartTracking starts tracking a ChronoWork.
 func (r *ChronoWorkRepository) StartTracking(id uint) error {
 	r.mu.Lock()
@@ -202,6 +345,12 @@ func (r *ChronoWorkRepository) StartTracking(id uint) error {
 	cw.EndTime = time.Time{}
 	cw.IsTracking = true
 	cw.UpdatedAt = time.Now()
+	r.intervalID++
+	r.intervals = append(r.intervals, domain.WorkInterval{
+		ID:           r.intervalID,
+		ChronoWorkID: id,
+		StartTime:    cw.StartTime,
+	})
 	return nil
 }
 
@@ -219,6 +368,11 @@ func (r *ChronoWorkRepository) StopTracking(id uint) error {
 	elapsed := int(cw.EndTime.Sub(cw.StartTime).Seconds())
 	cw.TotalSeconds += elapsed
 	cw.UpdatedAt = time.Now()
+	for i := range r.intervals {
+		if r.intervals[i].ChronoWorkID == id && r.intervals[i].IsOpen() {
+			r.intervals[i].EndTime = cw.EndTime
+		}
+	}
 	return nil
 }
 
@@ -231,5 +385,12 @@ func (r *ChronoWorkRepository) Delete(id uint) error {
 		return errors.New("record not found")
 	}
 	delete(r.data, id)
+	intervals := r.intervals[:0]
+	for _, interval := range r.intervals {
+		if interval.ChronoWorkID != id {
+			intervals = append(intervals, interval)
+		}
+	}
+	r.intervals = intervals
 	return nil
 }
@@ -5,12 +5,15 @@ import (
 	"time"
 
 	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository"
 )
 
 // SettingRepository is an in-memory mock of repository.SettingRepository.
 type SettingRepository struct {
 	mu      sync.RWMutex
 	setting *domain.Setting
+
+	updateClosedUntilErr error
 }
 
 // NewSettingRepository creates a new mock SettingRepository.
@@ -18,6 +21,11 @@ func NewSettingRepository() *SettingRepository {
 	return &SettingRepository{}
 }
 
+// WithTx returns the repository itself, which sees every change at once.
+func (r *SettingRepository) WithTx(tx repository.Tx) repository.SettingRepository {
+	return r
+}
+
 // Get retrieves the current setting or creates a default one.
 func (r *SettingRepository) Get() (*domain.Setting, error) {
 	r.mu.Lock()
@@ -31,6 +39,8 @@ func (r *SettingRepository) Get() (*domain.Setting, error) {
 			PersonDay:          8,
 			DisplayAsPersonDay: true,
 			DownloadPath:       "./",
+			Theme:              "default",
+			Language:           "auto",
 			CreatedAt:          now,
 			UpdatedAt:          now,
 		}
@@ -54,6 +64,37 @@ func (r *SettingRepository) Update(setting *domain.Setting) error {
 	r.setting.PersonDay = setting.PersonDay
 	r.setting.DisplayAsPersonDay = setting.DisplayAsPersonDay
 	r.setting.DownloadPath = setting.DownloadPath
+	r.setting.Theme = setting.Theme
+	r.setting.Language = setting.Language
+	r.setting.AllowTagCreation = setting.AllowTagCreation
+	r.setting.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetUpdateClosedUntilError sets an error to be returned by UpdateClosedUntil (for testing error cases).
+func (r *SettingRepository) SetUpdateClosedUntilError(err error) {
+	r.updateClosedUntilErr = err
+}
+
+// UpdateClosedUntil updates the end of the last closed accounting period.
+func (r *SettingRepository) UpdateClosedUntil(closedUntil time.Time) error {
+	if r.updateClosedUntilErr != nil {
+		return r.updateClosedUntilErr
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.setting == nil {
+		now := time.Now()
+		r.setting = &domain.Setting{
+			ID:                 1,
+			PersonDay:          8,
+			DisplayAsPersonDay: true,
+			DownloadPath:       "./",
+			CreatedAt:          now,
+		}
+	}
+	r.setting.ClosedUntil = closedUntil
 	r.setting.UpdatedAt = time.Now()
 	return nil
 }
@@ -0,0 +1,111 @@
+package mock
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+)
+
+// WorkTemplateRepository is an in-memory mock of repository.WorkTemplateRepository.
+type WorkTemplateRepository struct {
+	mu     sync.RWMutex
+	data   map[uint]*domain.WorkTemplate
+	nextID uint
+}
+
+// NewWorkTemplateRepository creates a new mock WorkTemplateRepository.
+func NewWorkTemplateRepository() *WorkTemplateRepository {
+	return &WorkTemplateRepository{
+		data:   make(map[uint]*domain.WorkTemplate),
+		nextID: 1,
+	}
+}
+
+// Create creates a new WorkTemplate and sets its ID.
+func (r *WorkTemplateRepository) Create(template *domain.WorkTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	template.ID = r.nextID
+	template.CreatedAt = now
+	template.UpdatedAt = now
+	stored := *template
+	r.data[r.nextID] = &stored
+	r.nextID++
+	return nil
+}
+
+// FindByID finds a WorkTemplate by its ID.
+func (r *WorkTemplateRepository) FindByID(id uint) (*domain.WorkTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	template, ok := r.data[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	found := *template
+	return &found, nil
+}
+
+// FindAll finds all WorkTemplates ordered by ID.
+func (r *WorkTemplateRepository) FindAll() ([]domain.WorkTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]domain.WorkTemplate, 0, len(r.data))
+	for _, template := range r.data {
+		result = append(result, *template)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+// Update updates a WorkTemplate's title, project, tag, estimate and recurrence.
+func (r *WorkTemplateRepository) Update(template *domain.WorkTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.data[template.ID]
+	if !ok {
+		return errors.New("record not found")
+	}
+	stored.Title = template.Title
+	stored.ProjectTypeID = template.ProjectTypeID
+	stored.TagID = template.TagID
+	stored.EstimateSeconds = template.EstimateSeconds
+	stored.Recurrence = template.Recurrence
+	stored.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateLastCreatedOn records the day a work was last created from the WorkTemplate.
+func (r *WorkTemplateRepository) UpdateLastCreatedOn(id uint, date time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.data[id]
+	if !ok {
+		return errors.New("record not found")
+	}
+	stored.LastCreatedOn = date
+	return nil
+}
+
+// Delete permanently deletes a WorkTemplate.
+func (r *WorkTemplateRepository) Delete(id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.data[id]; !ok {
+		return errors.New("record not found")
+	}
+	delete(r.data, id)
+	return nil
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/niiharamegumu/chronowork/internal/domain"
 	"github.com/niiharamegumu/chronowork/models"
 	"gorm.io/gorm"
@@ -16,6 +18,11 @@ func NewGormSettingRepository(db *gorm.DB) *GormSettingRepository {
 	return &GormSettingRepository{db: db}
 }
 
+// WithTx returns a SettingRepository bound to the transaction.
+func (r *GormSettingRepository) WithTx(tx Tx) SettingRepository {
+	return NewGormSettingRepository(tx.(*gorm.DB))
+}
+
 // Get retrieves the current setting or creates a default one.
 func (r *GormSettingRepository) Get() (*domain.Setting, error) {
 	var setting models.Setting
@@ -28,15 +35,27 @@ func (r *GormSettingRepository) Get() (*domain.Setting, error) {
 // Update updates the setting.
 func (r *GormSettingRepository) Update(setting *domain.Setting) error {
 	return r.db.Model(&models.Setting{}).Where("id = ?", setting.ID).
-		Select("relative_date", "person_day", "display_as_person_day", "download_path").
+		Select("relative_date", "person_day", "display_as_person_day", "download_path", "theme", "language", "allow_tag_creation").
 		Updates(map[string]interface{}{
 			"relative_date":         setting.RelativeDate,
 			"person_day":            setting.PersonDay,
 			"display_as_person_day": setting.DisplayAsPersonDay,
 			"download_path":         setting.DownloadPath,
+			"theme":                 setting.Theme,
+			"language":              setting.Language,
+			"allow_tag_creation":    setting.AllowTagCreation,
 		}).Error
 }
 
+// UpdateClosedUntil updates the end of the last closed accounting period.
+func (r *GormSettingRepository) UpdateClosedUntil(closedUntil time.Time) error {
+	var setting models.Setting
+	if err := r.db.FirstOrCreate(&setting).Error; err != nil {
+		return err
+	}
+	return r.db.Model(&setting).Update("closed_until", closedUntil).Error
+}
+
 // toDomain converts a GORM model to a domain entity.
 func (r *GormSettingRepository) toDomain(m *models.Setting) *domain.Setting {
 	return &domain.Setting{
@@ -45,6 +64,10 @@ func (r *GormSettingRepository) toDomain(m *models.Setting) *domain.Setting {
 		PersonDay:          m.PersonDay,
 		DisplayAsPersonDay: m.DisplayAsPersonDay,
 		DownloadPath:       m.DownloadPath,
+		Theme:              m.Theme,
+		Language:           m.Language,
+		AllowTagCreation:   m.AllowTagCreation,
+		ClosedUntil:        m.ClosedUntil,
 		CreatedAt:          m.CreatedAt,
 		UpdatedAt:          m.UpdatedAt,
 	}
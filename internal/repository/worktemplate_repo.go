@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/models"
+	"gorm.io/gorm"
+)
+
+// GormWorkTemplateRepository is a GORM implementation of WorkTemplateRepository.
+type GormWorkTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewGormWorkTemplateRepository creates a new GormWorkTemplateRepository.
+func NewGormWorkTemplateRepository(db *gorm.DB) *GormWorkTemplateRepository {
+	return &GormWorkTemplateRepository{db: db}
+}
+
+// Create creates a new WorkTemplate and sets its ID.
+func (r *GormWorkTemplateRepository) Create(template *domain.WorkTemplate) error {
+	workTemplate := models.WorkTemplate{
+		Title:           template.Title,
+		ProjectTypeID:   template.ProjectTypeID,
+		TagID:           template.TagID,
+		EstimateSeconds: template.EstimateSeconds,
+		Recurrence:      template.Recurrence,
+	}
+	if err := r.db.Create(&workTemplate).Error; err != nil {
+		return err
+	}
+	template.ID = workTemplate.ID
+	template.CreatedAt = workTemplate.CreatedAt
+	template.UpdatedAt = workTemplate.UpdatedAt
+	return nil
+}
+
+// FindByID finds a WorkTemplate by its ID.
+func (r *GormWorkTemplateRepository) FindByID(id uint) (*domain.WorkTemplate, error) {
+	var workTemplate models.WorkTemplate
+	if err := r.db.Preload("ProjectType").Preload("Tag").First(&workTemplate, id).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomain(&workTemplate), nil
+}
+
+// FindAll finds all WorkTemplates with project and tag preloaded.
+func (r *GormWorkTemplateRepository) FindAll() ([]domain.WorkTemplate, error) {
+	var workTemplates []models.WorkTemplate
+	if err := r.db.Preload("ProjectType").Preload("Tag").Order("id asc").Find(&workTemplates).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomainSlice(workTemplates), nil
+}
+
+// Update updates a WorkTemplate's title, project, tag, estimate and recurrence.
+func (r *GormWorkTemplateRepository) Update(template *domain.WorkTemplate) error {
+	return r.db.Model(&models.WorkTemplate{}).Where("id = ?", template.ID).
+		Select("title", "project_type_id", "tag_id", "estimate_seconds", "recurrence").
+		Updates(map[string]interface{}{
+			"title":            template.Title,
+			"project_type_id":  template.ProjectTypeID,
+			"tag_id":           template.TagID,
+			"estimate_seconds": template.EstimateSeconds,
+			"recurrence":       template.Recurrence,
+		}).Error
+}
+
+// UpdateLastCreatedOn records the day a work was last created from the WorkTemplate.
+func (r *GormWorkTemplateRepository) UpdateLastCreatedOn(id uint, date time.Time) error {
+	return r.db.Model(&models.WorkTemplate{}).Where("id = ?", id).Update("last_created_on", date).Error
+}
+
+// Delete permanently deletes a WorkTemplate.
+func (r *GormWorkTemplateRepository) Delete(id uint) error {
+	return r.db.Unscoped().Delete(&models.WorkTemplate{}, id).Error
+}
+
+// toDomain converts a GORM model to a domain entity.
+func (r *GormWorkTemplateRepository) toDomain(m *models.WorkTemplate) *domain.WorkTemplate {
+	d := &domain.WorkTemplate{
+		ID:              m.ID,
+		Title:           m.Title,
+		ProjectTypeID:   m.ProjectTypeID,
+		TagID:           m.TagID,
+		EstimateSeconds: m.EstimateSeconds,
+		Recurrence:      m.Recurrence,
+		LastCreatedOn:   m.LastCreatedOn,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+	if m.ProjectType.ID != 0 {
+		d.ProjectType = &domain.ProjectType{
+			ID:   m.ProjectType.ID,
+			Name: m.ProjectType.Name,
+		}
+	}
+	if m.Tag.ID != 0 {
+		d.Tag = &domain.Tag{
+			ID:   m.Tag.ID,
+			Name: m.Tag.Name,
+		}
+	}
+	return d
+}
+
+// toDomainSlice converts a slice of GORM models to domain entities.
+func (r *GormWorkTemplateRepository) toDomainSlice(ms []models.WorkTemplate) []domain.WorkTemplate {
+	ds := make([]domain.WorkTemplate, len(ms))
+	for i, m := range ms {
+		ds[i] = *r.toDomain(&m)
+	}
+	return ds
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"encoding/json"
+	"os"
+	"os/user"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository"
+)
+
+// AuditLogUseCase handles business logic for reading the audit history.
+type AuditLogUseCase struct {
+	repo repository.AuditLogRepository
+}
+
+// NewAuditLogUseCase creates a new AuditLogUseCase.
+func NewAuditLogUseCase(repo repository.AuditLogRepository) *AuditLogUseCase {
+	return &AuditLogUseCase{repo: repo}
+}
+
+// FindByWorkID finds all AuditLogs of a work in chronological order.
+func (uc *AuditLogUseCase) FindByWorkID(workID uint) ([]domain.AuditLog, error) {
+	return uc.repo.FindByWorkID(workID)
+}
+
+// auditSnapshot is the subset of a work stored as old/new value of an AuditLog.
+type auditSnapshot struct {
+	Date          string `json:"date"`
+	Title         string `json:"title"`
+	ProjectTypeID uint   `json:"project_type_id"`
+	TagID         uint   `json:"tag_id"`
+	TotalSeconds  int    `json:"total_seconds"`
+	IsTracking    bool   `json:"is_tracking"`
+	Confirmed     bool   `json:"confirmed"`
+}
+
+// snapshotOf serializes the audited fields of a work. A nil work yields "".
+func snapshotOf(cw *domain.ChronoWork) string {
+	if cw == nil {
+		return ""
+	}
+	b, err := json.Marshal(auditSnapshot{
+		Date:          cw.CreatedAt.Format("2006/01/02"),
+		Title:         cw.Title,
+		ProjectTypeID: cw.ProjectTypeID,
+		TagID:         cw.TagID,
+		TotalSeconds:  cw.TotalSeconds,
+		IsTracking:    cw.IsTracking,
+		Confirmed:     cw.Confirmed,
+	})
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// currentActor returns the name of the OS user performing the change.
+func currentActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
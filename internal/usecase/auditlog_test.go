@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func TestChronoWorkUseCase_AuditLog(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	auditRepo := mock.NewAuditLogRepository()
+	uc := NewChronoWorkUseCase(repo, auditRepo, mock.NewSettingRepository())
+	auditUC := NewAuditLogUseCase(auditRepo)
+
+	created, _ := uc.Create("Audit Test", 0, 0)
+	_ = uc.Update(created.ID, "Audit Test Updated", 1, 2)
+	_ = uc.UpdateTotalSeconds(created.ID, 1800)
+	_ = uc.UpdateConfirmed(created.ID, true)
+	_ = uc.Reopen(created.ID)
+	_ = uc.Delete(created.ID)
+
+	logs, err := auditUC.FindByWorkID(created.ID)
+	if err != nil {
+		t.Fatalf("FindByWorkID failed: %v", err)
+	}
+
+	expected := []domain.AuditAction{
+		domain.AuditActionCreate,
+		domain.AuditActionUpdate,
+		domain.AuditActionUpdateTotalSeconds,
+		domain.AuditActionConfirm,
+		domain.AuditActionReopen,
+		domain.AuditActionDelete,
+	}
+	if len(logs) != len(expected) {
+		t.Fatalf("expected %d audit logs, got %d", len(expected), len(logs))
+	}
+	for i, action := range expected {
+		if logs[i].Action != action {
+			t.Errorf("expected action %s at %d, got %s", action, i, logs[i].Action)
+		}
+		if logs[i].Actor == "" {
+			t.Errorf("expected actor to be set at %d", i)
+		}
+	}
+
+	// Create has no old value, delete has no new value
+	if logs[0].OldValue != "" || logs[0].NewValue == "" {
+		t.Errorf("unexpected create values: old=%q new=%q", logs[0].OldValue, logs[0].NewValue)
+	}
+	if logs[5].OldValue == "" || logs[5].NewValue != "" {
+		t.Errorf("unexpected delete values: old=%q new=%q", logs[5].OldValue, logs[5].NewValue)
+	}
+
+	// Old and new values of the overwrite keep both totals
+	overwrite := logs[2]
+	if overwrite.OldValue == overwrite.NewValue {
+		t.Error("expected old and new values to differ for total seconds overwrite")
+	}
+	if want := `"total_seconds":1800`; !strings.Contains(overwrite.NewValue, want) {
+		t.Errorf("expected new value to contain %s, got %s", want, overwrite.NewValue)
+	}
+	if want := `"total_seconds":0`; !strings.Contains(overwrite.OldValue, want) {
+		t.Errorf("expected old value to contain %s, got %s", want, overwrite.OldValue)
+	}
+}
+
+func TestChronoWorkUseCase_AuditLog_FailedChangeNotRecorded(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	auditRepo := mock.NewAuditLogRepository()
+	uc := NewChronoWorkUseCase(repo, auditRepo, mock.NewSettingRepository())
+
+	if err := uc.UpdateTotalSeconds(999, 60); err == nil {
+		t.Error("expected error for non-existent ID")
+	}
+	logs, _ := auditRepo.FindByWorkID(999)
+	if len(logs) != 0 {
+		t.Errorf("expected no audit logs, got %d", len(logs))
+	}
+}
+
+func TestChronoWorkUseCase_AuditLogFailure(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	auditRepo := mock.NewAuditLogRepository()
+	uc := NewChronoWorkUseCase(repo, auditRepo, mock.NewSettingRepository())
+	created, _ := uc.Create("Audit Test", 0, 0)
+
+	auditRepo.SetCreateError(errors.New("disk full"))
+	if err := uc.UpdateTotalSeconds(created.ID, 1800); err == nil {
+		t.Fatal("expected the failed audit entry to fail the change")
+	}
+	if cw, _ := uc.FindByID(created.ID); cw.TotalSeconds != 0 {
+		t.Errorf("expected the unaudited change to be rolled back, got %d seconds", cw.TotalSeconds)
+	}
+	if _, err := uc.Create("Unaudited", 0, 0); err == nil {
+		t.Fatal("expected the failed audit entry to fail the creation")
+	}
+	if works, _ := uc.GetAll("", 0); len(works) != 1 {
+		t.Errorf("expected the unaudited work to be rolled back, got %d works", len(works))
+	}
+	if err := uc.Delete(created.ID); err == nil {
+		t.Fatal("expected the failed audit entry to fail the deletion")
+	}
+	if _, err := uc.FindByID(created.ID); err != nil {
+		t.Errorf("expected the unaudited deletion to be rolled back: %v", err)
+	}
+}
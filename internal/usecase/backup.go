@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/repository"
+)
+
+// backupArchive matches the names of the backup archives, such as
+// "chronowork_20240506093000.db.gz", with a counter such as "_2" for the
+// archives of the same second.
+var backupArchive = regexp.MustCompile(`^chronowork_(\d{14})(?:_(\d+))?\.db\.gz$`)
+
+// BackupUseCase backs up the database to compressed archives and restores it.
+type BackupUseCase struct {
+	repo repository.BackupRepository
+}
+
+// NewBackupUseCase creates a new BackupUseCase.
+func NewBackupUseCase(repo repository.BackupRepository) *BackupUseCase {
+	return &BackupUseCase{repo: repo}
+}
+
+// Backup writes a snapshot of the database to a new archive in dir, named
+// after now, and removes the oldest archives beyond keep. A keep of 0 removes
+// nothing. Existing archives are never overwritten; an archive of the same
+// second gets a counter. It returns the path of the archive.
+func (uc *BackupUseCase) Backup(dir string, keep int, now time.Time) (string, error) {
+	if keep < 0 {
+		return "", NewValidationError("the number of backups to keep must not be negative")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	name, err := archiveName(dir, now)
+	if err != nil {
+		return "", err
+	}
+	snapshot := filepath.Join(dir, "."+name+".db")
+	// a snapshot left by an interrupted backup would make the new one fail
+	os.Remove(snapshot)
+	if err := uc.repo.Snapshot(snapshot); err != nil {
+		return "", err
+	}
+	defer os.Remove(snapshot)
+
+	path := filepath.Join(dir, name+".db.gz")
+	if err := compressFile(snapshot, path); err != nil {
+		return "", err
+	}
+	if keep > 0 {
+		if err := uc.prune(dir, keep); err != nil {
+			return path, err
+		}
+	}
+	return path, nil
+}
+
+// List returns the paths of the archives in dir, the latest first.
+func (uc *BackupUseCase) List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && backupArchive.MatchString(entry.Name()) {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return archiveNewer(filepath.Base(paths[i]), filepath.Base(paths[j]))
+	})
+	return paths, nil
+}
+
+// Restore replaces the database file at dbPath with the database of the
+// archive after verifying its integrity. The database must not be open while
+// it is restored, and it is left untouched if the archive is damaged.
+func (uc *BackupUseCase) Restore(archive, dbPath string) error {
+	restored := dbPath + ".restore"
+	if err := decompressFile(archive, restored); err != nil {
+		os.Remove(restored)
+		return err
+	}
+	if err := uc.repo.Verify(restored); err != nil {
+		os.Remove(restored)
+		return NewValidationErrorf("backup %s is damaged: %v", filepath.Base(archive), err)
+	}
+
+	// the journals of the replaced database would be applied to the restored one
+	for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !os.IsNotExist(err) {
+			os.Remove(restored)
+			return err
+		}
+	}
+	return os.Rename(restored, dbPath)
+}
+
+// archiveName returns the name without extension of a new archive of now that
+// is not taken in dir.
+func archiveName(dir string, now time.Time) (string, error) {
+	base := "chronowork_" + now.Format("20060102150405")
+	name := base
+	for n := 2; ; n++ {
+		_, err := os.Lstat(filepath.Join(dir, name+".db.gz"))
+		if os.IsNotExist(err) {
+			return name, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		name = fmt.Sprintf("%s_%d", base, n)
+	}
+}
+
+// archiveNewer reports whether the archive named a was made after the one
+// named b, by their timestamps and then their counters.
+func archiveNewer(a, b string) bool {
+	ma, mb := backupArchive.FindStringSubmatch(a), backupArchive.FindStringSubmatch(b)
+	if ma[1] != mb[1] {
+		return ma[1] > mb[1]
+	}
+	return archiveCounter(ma[2]) > archiveCounter(mb[2])
+}
+
+// archiveCounter returns the counter of an archive name, 1 for the first
+// archive of a second.
+func archiveCounter(counter string) int {
+	n, err := strconv.Atoi(counter)
+	if err != nil {
+		return 1
+	}
+	return n
+}
+
+func (uc *BackupUseCase) prune(dir string, keep int) error {
+	paths, err := uc.List(dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths[min(keep, len(paths)):] {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compressFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// an existing file is never touched, so that only a file created here is
+	// removed on failure
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	zw.Name = filepath.Base(dst[:len(dst)-len(".gz")])
+	_, err = io.Copy(zw, in)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dst)
+	}
+	return err
+}
+
+func decompressFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	zr, err := gzip.NewReader(in)
+	if err != nil {
+		return NewValidationErrorf("backup %s is not a gzip archive", filepath.Base(src))
+	}
+	defer zr.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, zr)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
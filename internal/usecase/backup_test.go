@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func TestBackupUseCase_Backup(t *testing.T) {
+	uc := NewBackupUseCase(mock.NewBackupRepository())
+	dir := filepath.Join(t.TempDir(), "backups")
+	now := time.Date(2024, 5, 6, 9, 0, 0, 0, time.Local)
+
+	var paths []string
+	for i := 0; i < 3; i++ {
+		path, err := uc.Backup(dir, 2, now.Add(time.Duration(i)*time.Hour))
+		if err != nil {
+			t.Fatalf("Backup failed: %v", err)
+		}
+		paths = append(paths, path)
+	}
+	if filepath.Base(paths[0]) != "chronowork_20240506090000.db.gz" {
+		t.Errorf("unexpected archive name %s", filepath.Base(paths[0]))
+	}
+
+	listed, err := uc.List(dir)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	// the oldest archive is removed and the snapshots are not left behind
+	if !reflect.DeepEqual(listed, []string{paths[2], paths[1]}) {
+		t.Errorf("List() = %q, want the 2 latest archives", listed)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("expected only the archives in the directory, got %d entries", len(entries))
+	}
+
+	if _, err := uc.Backup(dir, 0, now.Add(3*time.Hour)); err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	if listed, _ := uc.List(dir); len(listed) != 3 {
+		t.Errorf("expected a keep of 0 to remove nothing, got %d archives", len(listed))
+	}
+	if _, err := uc.Backup(dir, -1, now.Add(4*time.Hour)); err == nil {
+		t.Error("expected an error for a negative keep")
+	}
+
+	// a backup of the same second gets a new name and keeps the existing archive
+	existing := filepath.Join(dir, "chronowork_20240506120000.db.gz")
+	path, err := uc.Backup(dir, 2, now.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	if filepath.Base(path) != "chronowork_20240506120000_2.db.gz" {
+		t.Errorf("unexpected archive name %s", filepath.Base(path))
+	}
+	if listed, _ := uc.List(dir); !reflect.DeepEqual(listed, []string{path, existing}) {
+		t.Errorf("List() = %q, want the new and the existing archive", listed)
+	}
+	if err := uc.Restore(existing, filepath.Join(t.TempDir(), "sqlite.db")); err != nil {
+		t.Errorf("expected the existing archive to remain intact, got %v", err)
+	}
+}
+
+func TestBackupUseCase_ListOrder(t *testing.T) {
+	uc := NewBackupUseCase(mock.NewBackupRepository())
+	dir := t.TempDir()
+	names := []string{
+		"chronowork_20240506090000_10.db.gz",
+		"chronowork_20240506090000.db.gz",
+		"chronowork_20240506100000.db.gz",
+		"chronowork_20240506090000_2.db.gz",
+		"notes.txt",
+	}
+	for _, name := range names {
+		os.WriteFile(filepath.Join(dir, name), nil, 0o600)
+	}
+
+	listed, err := uc.List(dir)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	var got []string
+	for _, path := range listed {
+		got = append(got, filepath.Base(path))
+	}
+	expected := []string{
+		"chronowork_20240506100000.db.gz",
+		"chronowork_20240506090000_10.db.gz",
+		"chronowork_20240506090000_2.db.gz",
+		"chronowork_20240506090000.db.gz",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("List() = %q, want %q", got, expected)
+	}
+}
+
+func TestBackupUseCase_Restore(t *testing.T) {
+	uc := NewBackupUseCase(mock.NewBackupRepository())
+	dir := t.TempDir()
+	archive, err := uc.Backup(dir, 0, time.Now())
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+
+	dbPath := filepath.Join(dir, "sqlite.db")
+	os.WriteFile(dbPath, []byte("current"), 0o644)
+	os.WriteFile(dbPath+"-journal", []byte("journal"), 0o644)
+	if err := uc.Restore(archive, dbPath); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if data, _ := os.ReadFile(dbPath); string(data) != "chronowork mock database" {
+		t.Errorf("expected the database to be restored, got %q", data)
+	}
+	if _, err := os.Stat(dbPath + "-journal"); !os.IsNotExist(err) {
+		t.Error("expected the journal of the replaced database to be removed")
+	}
+}
+
+func TestBackupUseCase_RestoreDamaged(t *testing.T) {
+	uc := NewBackupUseCase(mock.NewBackupRepository())
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "sqlite.db")
+	os.WriteFile(dbPath, []byte("current"), 0o644)
+
+	damaged := filepath.Join(dir, "chronowork_20240506090000.db.gz")
+	f, _ := os.Create(damaged)
+	zw := gzip.NewWriter(f)
+	zw.Write([]byte("not a database"))
+	zw.Close()
+	f.Close()
+	notGzip := filepath.Join(dir, "chronowork_20240506100000.db.gz")
+	os.WriteFile(notGzip, []byte("plain"), 0o644)
+
+	for _, archive := range []string{damaged, notGzip} {
+		err := uc.Restore(archive, dbPath)
+		var ucErr *UseCaseError
+		if !errors.As(err, &ucErr) || ucErr.Code != ErrCodeValidation {
+			t.Errorf("expected a validation error for %s, got %v", filepath.Base(archive), err)
+		}
+	}
+	if data, _ := os.ReadFile(dbPath); string(data) != "current" {
+		t.Errorf("expected the database to be untouched, got %q", data)
+	}
+	if _, err := os.Stat(dbPath + ".restore"); !os.IsNotExist(err) {
+		t.Error("expected the extracted database to be removed")
+	}
+}
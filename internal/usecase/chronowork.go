@@ -6,16 +6,26 @@ import (
 
 	"github.com/niiharamegumu/chronowork/internal/domain"
 	"github.com/niiharamegumu/chronowork/internal/repository"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
 )
 
 // ChronoWorkUseCase handles business logic for ChronoWork operations.
+// Every change is recorded in the audit log, and confirmed works are locked.
 type ChronoWorkUseCase struct {
-	repo repository.ChronoWorkRepository
+	repo        repository.ChronoWorkRepository
+	auditRepo   repository.AuditLogRepository
+	settingRepo repository.SettingRepository
+	actor       string
 }
 
 // NewChronoWorkUseCase creates a new ChronoWorkUseCase.
-func NewChronoWorkUseCase(repo repository.ChronoWorkRepository) *ChronoWorkUseCase {
-	return &ChronoWorkUseCase{repo: repo}
+func NewChronoWorkUseCase(repo repository.ChronoWorkRepository, auditRepo repository.AuditLogRepository, settingRepo repository.SettingRepository) *ChronoWorkUseCase {
+	return &ChronoWorkUseCase{
+		repo:        repo,
+		auditRepo:   auditRepo,
+		settingRepo: settingRepo,
+		actor:       currentActor(),
+	}
 }
 
 // Create creates a new ChronoWork entry.
@@ -28,8 +38,104 @@ func (uc *ChronoWorkUseCase) Create(title string, projectTypeID, tagID uint) (*d
 	if existing != nil {
 		return nil, NewDuplicateError("work with this title already exists today")
 	}
+	if err := uc.ensureOpenPeriod(time.Now()); err != nil {
+		return nil, err
+	}
 
-	return uc.repo.Create(title, projectTypeID, tagID)
+	var chronoWork *domain.ChronoWork
+	err = uc.transaction(func(tx *ChronoWorkUseCase) error {
+		var err error
+		if chronoWork, err = tx.repo.Create(title, projectTypeID, tagID); err != nil {
+			return err
+		}
+		return tx.record(chronoWork.ID, domain.AuditActionCreate, "", snapshotOf(chronoWork))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return chronoWork, nil
+}
+
+// CreateOnDate creates a new ChronoWork entry on the given date.
+// Past dates keep the current time of day so that back-filled entries sort as if created now.
+func (uc *ChronoWorkUseCase) CreateOnDate(title string, projectTypeID, tagID uint, date time.Time) (*domain.ChronoWork, error) {
+	if timeutil.IsToday(date) {
+		return uc.Create(title, projectTypeID, tagID)
+	}
+	now := time.Now()
+	return uc.createAt(title, projectTypeID, tagID, time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), now.Second(), 0, time.Local))
+}
+
+// createAt creates a new ChronoWork entry created at the given time, refusing
+// future days, duplicate titles on the day and closed periods.
+func (uc *ChronoWorkUseCase) createAt(title string, projectTypeID, tagID uint, createdAt time.Time) (*domain.ChronoWork, error) {
+	if createdAt.After(timeutil.TodayEndTime()) {
+		return nil, NewValidationError("cannot create work on a future date")
+	}
+
+	existing, err := uc.repo.FindByTitleOnDate(title, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && timeutil.IsToday(createdAt) {
+		return nil, NewDuplicateError("work with this title already exists today")
+	}
+	if existing != nil {
+		return nil, NewDuplicateDateError("work with this title already exists on this date")
+	}
+	if err := uc.ensureOpenPeriod(createdAt); err != nil {
+		return nil, err
+	}
+
+	var chronoWork *domain.ChronoWork
+	err = uc.transaction(func(tx *ChronoWorkUseCase) error {
+		var err error
+		if chronoWork, err = tx.repo.CreateAt(title, projectTypeID, tagID, createdAt); err != nil {
+			return err
+		}
+		return tx.record(chronoWork.ID, domain.AuditActionCreate, "", snapshotOf(chronoWork))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return chronoWork, nil
+}
+
+// CreateWithIntervals creates a new ChronoWork entry created at the given time
+// with the finished tracking intervals and their total time, in one
+// transaction.
+func (uc *ChronoWorkUseCase) CreateWithIntervals(title string, projectTypeID, tagID uint, createdAt time.Time, intervals []domain.WorkInterval) (*domain.ChronoWork, error) {
+	for _, interval := range intervals {
+		if !interval.EndTime.After(interval.StartTime) {
+			return nil, NewValidationError("the interval must end after it starts")
+		}
+	}
+
+	var chronoWork *domain.ChronoWork
+	err := uc.transaction(func(tx *ChronoWorkUseCase) error {
+		created, err := tx.createAt(title, projectTypeID, tagID, createdAt)
+		if err != nil {
+			return err
+		}
+		seconds := 0
+		for _, interval := range intervals {
+			if err := tx.repo.CreateInterval(created.ID, interval.StartTime, interval.EndTime); err != nil {
+				return err
+			}
+			seconds += interval.Seconds()
+		}
+		if seconds > 0 {
+			if err := tx.UpdateTotalSeconds(created.ID, seconds); err != nil {
+				return err
+			}
+		}
+		chronoWork, err = tx.repo.FindByID(created.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return chronoWork, nil
 }
 
 // FindByID finds a ChronoWork by its ID.
@@ -59,30 +165,212 @@ func (uc *ChronoWorkUseCase) GetAll(orderField string, limit int) ([]domain.Chro
 
 // Update updates a ChronoWork's title, projectTypeID, and tagID.
 func (uc *ChronoWorkUseCase) Update(id uint, title string, projectTypeID, tagID uint) error {
-	return uc.repo.Update(id, title, projectTypeID, tagID)
+	return uc.change(id, domain.AuditActionUpdate, func(repo repository.ChronoWorkRepository) error {
+		return repo.Update(id, title, projectTypeID, tagID)
+	})
 }
 
 // UpdateTotalSeconds updates the total seconds of a ChronoWork.
 func (uc *ChronoWorkUseCase) UpdateTotalSeconds(id uint, totalSeconds int) error {
-	return uc.repo.UpdateTotalSeconds(id, totalSeconds)
+	return uc.change(id, domain.AuditActionUpdateTotalSeconds, func(repo repository.ChronoWorkRepository) error {
+		return repo.UpdateTotalSeconds(id, totalSeconds)
+	})
 }
 
 // UpdateConfirmed updates the confirmed status of a ChronoWork.
+// Confirming stops tracking first; a confirmed work can only be unconfirmed through Reopen.
 func (uc *ChronoWorkUseCase) UpdateConfirmed(id uint, confirmed bool) error {
-	return uc.repo.UpdateConfirmed(id, confirmed)
+	if !confirmed {
+		cw, err := uc.repo.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if cw.Confirmed {
+			return NewPermissionError("confirmed work must be reopened explicitly")
+		}
+		return nil
+	}
+	return uc.confirm(id, domain.AuditActionConfirm)
+}
+
+// Reopen unlocks a confirmed ChronoWork so that it can be changed again.
+func (uc *ChronoWorkUseCase) Reopen(id uint) error {
+	return uc.apply(id, domain.AuditActionReopen, func(repo repository.ChronoWorkRepository, old *domain.ChronoWork) error {
+		if !old.Confirmed {
+			return NewValidationError("work is not confirmed")
+		}
+		return repo.UpdateConfirmed(id, false)
+	})
+}
+
+// ClosePeriod confirms and locks every work created up to the end of the given day,
+// and refuses new works on those days. The works and the closed period are
+// written in one transaction. It returns the number of newly confirmed works.
+func (uc *ChronoWorkUseCase) ClosePeriod(until time.Time) (int, error) {
+	closedUntil := timeutil.EndOfDay(until)
+	count := 0
+	err := uc.transaction(func(tx *ChronoWorkUseCase) error {
+		chronoWorks, err := tx.repo.FindInRange(time.Time{}, closedUntil)
+		if err != nil {
+			return err
+		}
+		for _, cw := range chronoWorks {
+			if cw.Confirmed {
+				continue
+			}
+			if err := tx.confirm(cw.ID, domain.AuditActionClosePeriod); err != nil {
+				return err
+			}
+			count++
+		}
+
+		setting, err := tx.settingRepo.Get()
+		if err != nil {
+			return err
+		}
+		if !closedUntil.After(setting.ClosedUntil) {
+			return nil
+		}
+		oldValue := ""
+		if !setting.ClosedUntil.IsZero() {
+			oldValue = setting.ClosedUntil.Format("2006/01/02")
+		}
+		if err := tx.settingRepo.UpdateClosedUntil(closedUntil); err != nil {
+			return err
+		}
+		return tx.record(0, domain.AuditActionCloseUntil, oldValue, closedUntil.Format("2006/01/02"))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
 }
 
 // StartTracking starts tracking a ChronoWork.
 func (uc *ChronoWorkUseCase) StartTracking(id uint) error {
-	return uc.repo.StartTracking(id)
+	return uc.change(id, domain.AuditActionStartTracking, func(repo repository.ChronoWorkRepository) error {
+		return repo.StartTracking(id)
+	})
 }
 
 // StopTracking stops tracking a ChronoWork and calculates total time.
 func (uc *ChronoWorkUseCase) StopTracking(id uint) error {
-	return uc.repo.StopTracking(id)
+	return uc.change(id, domain.AuditActionStopTracking, func(repo repository.ChronoWorkRepository) error {
+		return repo.StopTracking(id)
+	})
 }
 
 // Delete permanently deletes a ChronoWork.
 func (uc *ChronoWorkUseCase) Delete(id uint) error {
-	return uc.repo.Delete(id)
+	old, err := uc.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if err := ensureUnlocked(old); err != nil {
+		return err
+	}
+	oldValue := snapshotOf(old)
+	return uc.transaction(func(tx *ChronoWorkUseCase) error {
+		if err := tx.repo.Delete(id); err != nil {
+			return err
+		}
+		return tx.record(id, domain.AuditActionDelete, oldValue, "")
+	})
+}
+
+// confirm stops tracking of an unconfirmed work if needed and confirms it.
+func (uc *ChronoWorkUseCase) confirm(id uint, action domain.AuditAction) error {
+	cw, err := uc.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if cw.Confirmed {
+		return nil
+	}
+	return uc.transaction(func(tx *ChronoWorkUseCase) error {
+		if cw.IsTracking {
+			if err := tx.StopTracking(id); err != nil {
+				return err
+			}
+		}
+		return tx.apply(id, action, func(repo repository.ChronoWorkRepository, old *domain.ChronoWork) error {
+			return repo.UpdateConfirmed(id, true)
+		})
+	})
+}
+
+// change applies fn to an unlocked work and records its state before and after.
+func (uc *ChronoWorkUseCase) change(id uint, action domain.AuditAction, fn func(repo repository.ChronoWorkRepository) error) error {
+	return uc.apply(id, action, func(repo repository.ChronoWorkRepository, old *domain.ChronoWork) error {
+		if err := ensureUnlocked(old); err != nil {
+			return err
+		}
+		return fn(repo)
+	})
+}
+
+// apply applies fn to an existing work and records its state before and after.
+// The change and its audit entry are written in one transaction, and fn gets
+// the repository bound to it.
+func (uc *ChronoWorkUseCase) apply(id uint, action domain.AuditAction, fn func(repo repository.ChronoWorkRepository, old *domain.ChronoWork) error) error {
+	return uc.transaction(func(tx *ChronoWorkUseCase) error {
+		old, err := tx.repo.FindByID(id)
+		if err != nil {
+			return err
+		}
+		// snapshot before fn runs: repositories may return a shared instance
+		oldValue := snapshotOf(old)
+		if err := fn(tx.repo, old); err != nil {
+			return err
+		}
+		updated, err := tx.repo.FindByID(id)
+		if err != nil {
+			return err
+		}
+		return tx.record(id, action, oldValue, snapshotOf(updated))
+	})
+}
+
+// transaction runs fn with a copy of the use case whose repositories are bound
+// to one transaction, which is rolled back if fn returns an error.
+func (uc *ChronoWorkUseCase) transaction(fn func(tx *ChronoWorkUseCase) error) error {
+	return uc.repo.Transaction(func(tx repository.Tx) error {
+		return fn(&ChronoWorkUseCase{
+			repo:        uc.repo.WithTx(tx),
+			auditRepo:   uc.auditRepo.WithTx(tx),
+			settingRepo: uc.settingRepo.WithTx(tx),
+			actor:       uc.actor,
+		})
+	})
+}
+
+// record appends an entry to the audit log.
+func (uc *ChronoWorkUseCase) record(workID uint, action domain.AuditAction, oldValue, newValue string) error {
+	return uc.auditRepo.Create(&domain.AuditLog{
+		WorkID:   workID,
+		Action:   action,
+		Actor:    uc.actor,
+		OldValue: oldValue,
+		NewValue: newValue,
+	})
+}
+
+// ensureUnlocked returns a permission error if the work is confirmed.
+func ensureUnlocked(cw *domain.ChronoWork) error {
+	if cw.Confirmed {
+		return NewPermissionError("confirmed work is locked")
+	}
+	return nil
+}
+
+// ensureOpenPeriod returns a permission error if the day of t is in a closed period.
+func (uc *ChronoWorkUseCase) ensureOpenPeriod(t time.Time) error {
+	setting, err := uc.settingRepo.Get()
+	if err != nil {
+		return err
+	}
+	if !setting.ClosedUntil.IsZero() && !t.After(setting.ClosedUntil) {
+		return NewPermissionError("accounting period is closed")
+	}
+	return nil
 }
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+)
+
+// BatchError collects the failures of a batch operation by work ID.
+type BatchError struct {
+	Total  int
+	Failed map[uint]error
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	ids := make([]uint, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	details := make([]string, 0, len(ids))
+	for _, id := range ids {
+		details = append(details, fmt.Sprintf("#%d %s", id, e.Failed[id]))
+	}
+	return fmt.Sprintf("%d of %d works failed: %s", len(e.Failed), e.Total, strings.Join(details, "; "))
+}
+
+// runBatch applies fn to every id, continuing past failures.
+// It returns the number of successes and a *BatchError if any id failed.
+func runBatch(ids []uint, fn func(id uint) error) (int, error) {
+	failed := map[uint]error{}
+	for _, id := range ids {
+		if err := fn(id); err != nil {
+			failed[id] = err
+		}
+	}
+	if len(failed) > 0 {
+		return len(ids) - len(failed), &BatchError{Total: len(ids), Failed: failed}
+	}
+	return len(ids), nil
+}
+
+// MoveToDate moves a ChronoWork to another date, keeping its time of day.
+func (uc *ChronoWorkUseCase) MoveToDate(id uint, date time.Time) error {
+	if date.After(timeutil.TodayEndTime()) {
+		return NewValidationError("cannot move work to a future date")
+	}
+	if err := uc.ensureOpenPeriod(date); err != nil {
+		return err
+	}
+	return uc.apply(id, domain.AuditActionMove, func(repo repository.ChronoWorkRepository, old *domain.ChronoWork) error {
+		if err := ensureUnlocked(old); err != nil {
+			return err
+		}
+		if old.IsTracking {
+			return NewValidationError("cannot move a tracking work")
+		}
+		existing, err := repo.FindByTitleOnDate(old.Title, date)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.ID != old.ID {
+			return NewDuplicateDateError("work with this title already exists on this date")
+		}
+		createdAt := time.Date(date.Year(), date.Month(), date.Day(),
+			old.CreatedAt.Hour(), old.CreatedAt.Minute(), old.CreatedAt.Second(), 0, time.Local)
+		return repo.UpdateCreatedAt(id, createdAt)
+	})
+}
+
+// CopyToToday creates a new work today with the title, project and tag of a past work.
+func (uc *ChronoWorkUseCase) CopyToToday(id uint) (*domain.ChronoWork, error) {
+	cw, err := uc.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if timeutil.IsToday(cw.CreatedAt) {
+		return nil, NewValidationError("work is already today")
+	}
+	return uc.Create(cw.Title, cw.ProjectTypeID, cw.TagID)
+}
+
+// BatchConfirm confirms the works, or reopens them explicitly when confirmed is false.
+func (uc *ChronoWorkUseCase) BatchConfirm(ids []uint, confirmed bool) (int, error) {
+	return runBatch(ids, func(id uint) error {
+		if confirmed {
+			return uc.UpdateConfirmed(id, true)
+		}
+		return uc.Reopen(id)
+	})
+}
+
+// BatchDelete permanently deletes the works.
+func (uc *ChronoWorkUseCase) BatchDelete(ids []uint) (int, error) {
+	return runBatch(ids, uc.Delete)
+}
+
+// BatchReassign sets the project and tag of the works, keeping their titles.
+func (uc *ChronoWorkUseCase) BatchReassign(ids []uint, projectTypeID, tagID uint) (int, error) {
+	return runBatch(ids, func(id uint) error {
+		cw, err := uc.repo.FindByID(id)
+		if err != nil {
+			return err
+		}
+		return uc.Update(id, cw.Title, projectTypeID, tagID)
+	})
+}
+
+// BatchMoveToDate moves the works to another date.
+func (uc *ChronoWorkUseCase) BatchMoveToDate(ids []uint, date time.Time) (int, error) {
+	return runBatch(ids, func(id uint) error {
+		return uc.MoveToDate(id, date)
+	})
+}
+
+// BatchCopyToToday copies the past works to today.
+func (uc *ChronoWorkUseCase) BatchCopyToToday(ids []uint) (int, error) {
+	return runBatch(ids, func(id uint) error {
+		_, err := uc.CopyToToday(id)
+		return err
+	})
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func TestChronoWorkUseCase_BatchConfirm(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	first, _ := uc.Create("First", 0, 0)
+	second, _ := uc.Create("Second", 0, 0)
+
+	count, err := uc.BatchConfirm([]uint{first.ID, second.ID}, true)
+	if err != nil {
+		t.Fatalf("BatchConfirm failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 confirmed works, got %d", count)
+	}
+
+	count, err = uc.BatchConfirm([]uint{first.ID, second.ID}, false)
+	if err != nil {
+		t.Fatalf("BatchConfirm(false) failed: %v", err)
+	}
+	found, _ := uc.FindByID(first.ID)
+	if found.Confirmed {
+		t.Error("expected work to be reopened")
+	}
+}
+
+func TestChronoWorkUseCase_BatchDelete_PartialFailure(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	deletable, _ := uc.Create("Deletable", 0, 0)
+	locked, _ := uc.Create("Locked", 0, 0)
+	_ = uc.UpdateConfirmed(locked.ID, true)
+
+	count, err := uc.BatchDelete([]uint{deletable.ID, locked.ID})
+	if count != 1 {
+		t.Errorf("expected 1 deleted work, got %d", count)
+	}
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expected BatchError, got %v", err)
+	}
+	if _, ok := batchErr.Failed[locked.ID]; !ok || len(batchErr.Failed) != 1 {
+		t.Errorf("expected only locked work to fail, got %v", batchErr.Failed)
+	}
+}
+
+func TestChronoWorkUseCase_BatchReassign(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	first, _ := uc.Create("First", 0, 0)
+	second, _ := uc.Create("Second", 0, 0)
+
+	if _, err := uc.BatchReassign([]uint{first.ID, second.ID}, 3, 4); err != nil {
+		t.Fatalf("BatchReassign failed: %v", err)
+	}
+	found, _ := uc.FindByID(second.ID)
+	if found.Title != "Second" || found.ProjectTypeID != 3 || found.TagID != 4 {
+		t.Errorf("unexpected reassigned work: %+v", found)
+	}
+}
+
+func TestChronoWorkUseCase_BatchMoveAndCopy(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	created, _ := uc.Create("Moved", 1, 2)
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	if _, err := uc.BatchMoveToDate([]uint{created.ID}, yesterday); err != nil {
+		t.Fatalf("BatchMoveToDate failed: %v", err)
+	}
+	moved, _ := uc.FindByID(created.ID)
+	if moved.CreatedAt.YearDay() != yesterday.YearDay() {
+		t.Errorf("expected work on %s, got %s", yesterday.Format("2006/01/02"), moved.CreatedAt.Format("2006/01/02"))
+	}
+
+	count, err := uc.BatchCopyToToday([]uint{created.ID})
+	if err != nil || count != 1 {
+		t.Fatalf("BatchCopyToToday failed: count=%d err=%v", count, err)
+	}
+	copied, _ := repo.FindByTitleToday("Moved")
+	if copied == nil || copied.ProjectTypeID != 1 || copied.TagID != 2 {
+		t.Errorf("expected copy today with same project and tag, got %+v", copied)
+	}
+
+	// Copying a work that is already today fails
+	if _, err := uc.BatchCopyToToday([]uint{copied.ID}); err == nil {
+		t.Error("expected error copying a work of today")
+	}
+}
+
+func TestChronoWorkUseCase_TotalSecondsResizeIntervals(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+	createdAt := time.Date(2024, 5, 6, 18, 0, 0, 0, time.Local)
+	cw, _ := repo.CreateAt("Review", 0, 0, createdAt)
+	day := [2]time.Time{createdAt.Add(-18 * time.Hour), createdAt.Add(6 * time.Hour)}
+
+	intervalsOf := func() [][2]string {
+		intervals, _ := repo.FindIntervalsInRange(day[0], day[1])
+		got := [][2]string{}
+		for _, interval := range intervals {
+			got = append(got, [2]string{interval.StartTime.Format("15:04"), interval.EndTime.Format("15:04")})
+		}
+		return got
+	}
+	steps := []struct {
+		name    string
+		seconds int
+		want    [][2]string
+	}{
+		{"manual time ends at the creation time", 1800, [][2]string{{"17:30", "18:00"}}},
+		{"added time follows the last interval", 5400, [][2]string{{"17:30", "18:00"}, {"18:00", "19:00"}}},
+		{"removed time is cut from the latest interval", 3600, [][2]string{{"17:30", "18:00"}, {"18:00", "18:30"}}},
+		{"a reset drops every interval", 0, [][2]string{}},
+	}
+	for _, step := range steps {
+		if err := uc.UpdateTotalSeconds(cw.ID, step.seconds); err != nil {
+			t.Fatalf("%s: UpdateTotalSeconds failed: %v", step.name, err)
+		}
+		if got := intervalsOf(); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("%s: intervals = %v, want %v", step.name, got, step.want)
+		}
+	}
+
+	early, _ := repo.CreateAt("Early", 0, 0, time.Date(2024, 5, 6, 0, 30, 0, 0, time.Local))
+	uc.UpdateTotalSeconds(early.ID, 3600)
+	intervals, _ := repo.FindIntervalsInRange(day[0], day[1])
+	if len(intervals) != 1 || intervals[0].StartTime.Format("15:04") != "00:00" || intervals[0].Seconds() != 3600 {
+		t.Errorf("expected the manual time to start at the start of the day, got %+v", intervals)
+	}
+}
+
+func TestChronoWorkUseCase_MoveToDateShiftsIntervals(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+	createdAt := time.Date(2024, 5, 6, 9, 0, 0, 0, time.Local)
+	cw, _ := repo.CreateAt("Review", 0, 0, createdAt)
+	repo.AddInterval(cw.ID, createdAt.Add(time.Hour), createdAt.Add(2*time.Hour))
+	cw.TotalSeconds = 3600
+
+	if err := uc.MoveToDate(cw.ID, createdAt.AddDate(0, 0, -2)); err != nil {
+		t.Fatalf("MoveToDate failed: %v", err)
+	}
+	intervals, _ := repo.FindIntervalsInRange(createdAt.AddDate(0, 0, -3), createdAt.AddDate(0, 0, 1))
+	want := createdAt.AddDate(0, 0, -2).Add(time.Hour)
+	if len(intervals) != 1 || !intervals[0].StartTime.Equal(want) || intervals[0].Seconds() != 3600 {
+		t.Errorf("expected the interval to move with the work to %s, got %+v", want, intervals)
+	}
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+)
+
+// DaySummary is the tracked time of the works created on a day.
+type DaySummary struct {
+	Date         time.Time
+	TotalSeconds int
+	Works        int
+	Confirmed    int
+}
+
+// AllConfirmed reports whether the day has works and all of them are confirmed.
+func (s DaySummary) AllConfirmed() bool {
+	return s.Works > 0 && s.Confirmed == s.Works
+}
+
+// DailySummaries returns a summary for every day from the day of startTime to
+// the day of endTime, including days without works.
+func (uc *ChronoWorkUseCase) DailySummaries(startTime, endTime time.Time) ([]DaySummary, error) {
+	startTime = timeutil.StartOfDay(startTime)
+	endTime = timeutil.EndOfDay(endTime)
+	chronoWorks, err := uc.repo.FindInRange(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []DaySummary
+	indexes := make(map[string]int)
+	for day := startTime; !day.After(endTime); day = day.AddDate(0, 0, 1) {
+		indexes[day.Format(time.DateOnly)] = len(summaries)
+		summaries = append(summaries, DaySummary{Date: day})
+	}
+	for _, cw := range chronoWorks {
+		i, ok := indexes[cw.CreatedAt.In(startTime.Location()).Format(time.DateOnly)]
+		if !ok {
+			continue
+		}
+		s := &summaries[i]
+		s.TotalSeconds += cw.TotalSeconds
+		s.Works++
+		if cw.Confirmed {
+			s.Confirmed++
+		}
+	}
+	return summaries, nil
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func TestChronoWorkUseCase_DailySummaries(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	first := time.Date(2024, 5, 1, 9, 0, 0, 0, time.Local)
+	standup, _ := repo.CreateAt("Standup", 0, 0, first)
+	review, _ := repo.CreateAt("Review", 0, 0, first.Add(3*time.Hour))
+	deploy, _ := repo.CreateAt("Deploy", 0, 0, first.AddDate(0, 0, 2))
+	repo.CreateAt("Outside", 0, 0, first.AddDate(0, 0, 5))
+	repo.UpdateTotalSeconds(standup.ID, 900)
+	repo.UpdateTotalSeconds(review.ID, 3600)
+	repo.UpdateTotalSeconds(deploy.ID, 1800)
+	repo.UpdateConfirmed(standup.ID, true)
+	repo.UpdateConfirmed(deploy.ID, true)
+
+	summaries, err := uc.DailySummaries(first, first.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("DailySummaries failed: %v", err)
+	}
+	if len(summaries) != 3 {
+		t.Fatalf("expected 3 days, got %d", len(summaries))
+	}
+
+	tests := []struct {
+		day          int
+		totalSeconds int
+		works        int
+		allConfirmed bool
+	}{
+		{1, 4500, 2, false},
+		{2, 0, 0, false},
+		{3, 1800, 1, true},
+	}
+	for i, tt := range tests {
+		s := summaries[i]
+		if s.Date.Day() != tt.day || s.TotalSeconds != tt.totalSeconds || s.Works != tt.works || s.AllConfirmed() != tt.allConfirmed {
+			t.Errorf("day %d: expected %d seconds in %d works (confirmed %v), got %+v", tt.day, tt.totalSeconds, tt.works, tt.allConfirmed, s)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+)
+
+// DistributionEntry is the tracked time of a project or tag. Name is empty
+// for the works without a project or tag.
+type DistributionEntry struct {
+	Name    string
+	Seconds int
+}
+
+// TimeDistribution is the tracked time of the works in a range by project,
+// tag and day of the week.
+type TimeDistribution struct {
+	TotalSeconds int
+	Projects     []DistributionEntry
+	Tags         []DistributionEntry
+	// Weekdays is indexed by time.Weekday.
+	Weekdays [7]int
+}
+
+// Percent returns the share of seconds in the total tracked time.
+func (d TimeDistribution) Percent(seconds int) float64 {
+	if d.TotalSeconds == 0 {
+		return 0
+	}
+	return float64(seconds) * 100 / float64(d.TotalSeconds)
+}
+
+// Distribution returns the tracked time of the works created in the range by
+// project, tag and day of the week. Projects and tags with the most time come
+// first.
+func (uc *ChronoWorkUseCase) Distribution(startTime, endTime time.Time) (TimeDistribution, error) {
+	var d TimeDistribution
+	chronoWorks, err := uc.FindInRange(startTime, endTime)
+	if err != nil {
+		return d, err
+	}
+
+	projects := make(map[string]int)
+	tags := make(map[string]int)
+	for _, cw := range chronoWorks {
+		if cw.TotalSeconds <= 0 {
+			continue
+		}
+		var projectName, tagName string
+		if cw.ProjectType != nil {
+			projectName = cw.ProjectType.Name
+		}
+		if cw.Tag != nil {
+			tagName = cw.Tag.Name
+		}
+		d.TotalSeconds += cw.TotalSeconds
+		projects[projectName] += cw.TotalSeconds
+		tags[tagName] += cw.TotalSeconds
+		d.Weekdays[cw.CreatedAt.Local().Weekday()] += cw.TotalSeconds
+	}
+	d.Projects = distributionEntries(projects)
+	d.Tags = distributionEntries(tags)
+	return d, nil
+}
+
+// distributionEntries sorts the seconds by name in descending order of time.
+// Ties are ordered by name, with the empty name last.
+func distributionEntries(seconds map[string]int) []DistributionEntry {
+	entries := make([]DistributionEntry, 0, len(seconds))
+	for name, s := range seconds {
+		entries = append(entries, DistributionEntry{Name: name, Seconds: s})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Seconds != entries[j].Seconds {
+			return entries[i].Seconds > entries[j].Seconds
+		}
+		if entries[i].Name == "" || entries[j].Name == "" {
+			return entries[j].Name == ""
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func TestChronoWorkUseCase_Distribution(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	web := &domain.ProjectType{ID: 1, Name: "web"}
+	review := &domain.Tag{ID: 1, Name: "review"}
+	monday := time.Date(2024, 4, 29, 9, 0, 0, 0, time.Local)
+	works := []struct {
+		project *domain.ProjectType
+		tag     *domain.Tag
+		day     int
+		seconds int
+	}{
+		{web, review, 0, 3600},
+		{web, nil, 0, 1800},
+		{nil, nil, 2, 1800},
+		{web, review, 7, 7200}, // outside the range
+		{web, nil, 1, 0},
+	}
+	for _, w := range works {
+		cw, _ := repo.CreateAt("work", 0, 0, monday.AddDate(0, 0, w.day))
+		cw.ProjectType = w.project
+		cw.Tag = w.tag
+		repo.UpdateTotalSeconds(cw.ID, w.seconds)
+	}
+
+	d, err := uc.Distribution(monday.AddDate(0, 0, -1), monday.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("Distribution failed: %v", err)
+	}
+	if d.TotalSeconds != 7200 {
+		t.Errorf("expected 7200 seconds, got %d", d.TotalSeconds)
+	}
+	wantProjects := []DistributionEntry{{"web", 5400}, {"", 1800}}
+	wantTags := []DistributionEntry{{"review", 3600}, {"", 3600}}
+	for name, tt := range map[string]struct{ got, want []DistributionEntry }{
+		"projects": {d.Projects, wantProjects},
+		"tags":     {d.Tags, wantTags},
+	} {
+		if len(tt.got) != len(tt.want) {
+			t.Fatalf("%s: expected %v, got %v", name, tt.want, tt.got)
+		}
+		for i := range tt.want {
+			if tt.got[i] != tt.want[i] {
+				t.Errorf("%s: expected %v, got %v", name, tt.want, tt.got)
+			}
+		}
+	}
+	if d.Weekdays[time.Monday] != 5400 || d.Weekdays[time.Wednesday] != 1800 || d.Weekdays[time.Tuesday] != 0 {
+		t.Errorf("unexpected weekdays %v", d.Weekdays)
+	}
+	if got := d.Percent(5400); got != 75 {
+		t.Errorf("expected 75%%, got %v", got)
+	}
+}
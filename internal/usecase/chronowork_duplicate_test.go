@@ -1,14 +1,16 @@
 package usecase
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/niiharamegumu/chronowork/internal/repository/mock"
 )
 
 func TestChronoWorkUseCase_Create_DuplicateTitle(t *testing.T) {
 	repo := mock.NewChronoWorkRepository()
-	uc := NewChronoWorkUseCase(repo)
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
 
 	// 1回目の作成（成功）
 	_, err := uc.Create("Test Work", 1, 2)
@@ -28,7 +30,7 @@ func TestChronoWorkUseCase_Create_DuplicateTitle(t *testing.T) {
 
 func TestChronoWorkUseCase_Create_DifferentTitle(t *testing.T) {
 	repo := mock.NewChronoWorkRepository()
-	uc := NewChronoWorkUseCase(repo)
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
 
 	// 異なるタイトルなら複数作成可能
 	_, err := uc.Create("Work 1", 1, 2)
@@ -47,3 +49,25 @@ func TestChronoWorkUseCase_Create_DifferentTitle(t *testing.T) {
 		t.Errorf("Expected 2 works, got %d", len(works))
 	}
 }
+
+func TestChronoWorkUseCase_CreateOnDate_DuplicateTitle(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	// 今日作成済みでも、過去日には同じタイトルで作成可能
+	if _, err := uc.Create("Test Work", 1, 2); err != nil {
+		t.Fatalf("Create today failed: %v", err)
+	}
+	if _, err := uc.CreateOnDate("Test Work", 1, 2, yesterday); err != nil {
+		t.Fatalf("CreateOnDate yesterday failed: %v", err)
+	}
+
+	// 同じ日に同じタイトルは失敗
+	_, err := uc.CreateOnDate("Test Work", 1, 2, yesterday)
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Code != ErrCodeDuplicateDate {
+		t.Errorf("Expected duplicate date error, got: %v", err)
+	}
+}
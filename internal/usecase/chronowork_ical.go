@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/util/ical"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+)
+
+// IntervalEvents returns the finished tracking intervals overlapping the range
+// as calendar events. The summary is the title of the work, the categories are
+// its project and tag, and the description has the durations of the interval
+// and of the work.
+func (uc *ChronoWorkUseCase) IntervalEvents(startTime, endTime time.Time) ([]ical.Event, error) {
+	intervals, err := uc.repo.FindIntervalsInRange(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ical.Event
+	for _, interval := range intervals {
+		if interval.IsOpen() {
+			continue
+		}
+		cw, err := uc.repo.FindByID(interval.ChronoWorkID)
+		if err != nil {
+			// the intervals of deleted works are not exported
+			continue
+		}
+		event := ical.Event{
+			UID:     fmt.Sprintf("chronowork-interval-%d@chronowork", interval.ID),
+			Summary: cw.Title,
+			Description: fmt.Sprintf("Duration: %s\nWork total: %s",
+				timeutil.FormatTime(int(interval.EndTime.Sub(interval.StartTime).Seconds())),
+				timeutil.FormatTime(cw.TotalSeconds)),
+			Start: interval.StartTime,
+			End:   interval.EndTime,
+		}
+		if cw.ProjectType != nil {
+			event.Categories = append(event.Categories, cw.ProjectType.Name)
+		}
+		if cw.Tag != nil {
+			event.Categories = append(event.Categories, cw.Tag.Name)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// ExportICS writes the finished tracking intervals overlapping the range to an
+// iCalendar file in the download path. It returns the path of the file and the
+// number of events, and writes nothing when there are no events.
+func (uc *ChronoWorkUseCase) ExportICS(startTime, endTime, now time.Time) (string, int, error) {
+	events, err := uc.IntervalEvents(startTime, endTime)
+	if err != nil || len(events) == 0 {
+		return "", 0, err
+	}
+	setting, err := uc.settingRepo.Get()
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := os.Stat(setting.DownloadPath); err != nil {
+		return "", 0, err
+	}
+
+	path := filepath.Join(setting.DownloadPath, fmt.Sprintf("chrono_works_%s.ics", now.Format("20060102150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := ical.Write(f, events, now); err != nil {
+		f.Close()
+		return "", 0, err
+	}
+	if err := f.Close(); err != nil {
+		return "", 0, err
+	}
+	return path, len(events), nil
+}
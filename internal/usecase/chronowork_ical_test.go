@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func TestChronoWorkUseCase_ExportICS(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	settingRepo := mock.NewSettingRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), settingRepo)
+
+	dir := t.TempDir()
+	setting, _ := settingRepo.Get()
+	setting.DownloadPath = dir
+	settingRepo.Update(setting)
+
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.Local)
+	review, _ := repo.CreateAt("Review", 1, 2, start)
+	review.ProjectType = &domain.ProjectType{ID: 1, Name: "web"}
+	review.Tag = &domain.Tag{ID: 2, Name: "review"}
+	review.TotalSeconds = 5400
+	repo.AddInterval(review.ID, start, start.Add(30*time.Minute))
+	repo.AddInterval(review.ID, start.Add(2*time.Hour), start.Add(3*time.Hour))
+	repo.AddInterval(review.ID, start.Add(4*time.Hour), time.Time{})
+	repo.AddInterval(review.ID, start.AddDate(0, 0, 2), start.AddDate(0, 0, 2).Add(time.Hour))
+
+	path, count, err := uc.ExportICS(start.Add(-time.Hour), start.Add(12*time.Hour), start)
+	if err != nil {
+		t.Fatalf("ExportICS failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 finished intervals in the range, got %d", count)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	for _, want := range []string{"SUMMARY:Review", "CATEGORIES:web,review", `Duration: 00:30:00\nWork total: 01:30:00`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q in\n%s", want, data)
+		}
+	}
+
+	path, count, err = uc.ExportICS(start.AddDate(0, 1, 0), start.AddDate(0, 1, 1), start)
+	if err != nil || count != 0 || path != "" {
+		t.Errorf("expected nothing exported for an empty range, got %q, %d, %v", path, count, err)
+	}
+}
+
+func TestChronoWorkUseCase_UntrackedWorks(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+
+	legacy, _ := repo.CreateAt("Legacy", 0, 0, day.Add(9*time.Hour))
+	legacy.TotalSeconds = 3600
+	partly, _ := repo.CreateAt("Partly", 0, 0, day.Add(10*time.Hour))
+	repo.AddInterval(partly.ID, day.Add(10*time.Hour), day.Add(10*time.Hour+30*time.Minute))
+	partly.TotalSeconds = 2700
+	typed, _ := repo.CreateAt("Typed", 0, 0, day.Add(11*time.Hour))
+	uc.UpdateTotalSeconds(typed.ID, 1800)
+
+	untracked, err := uc.UntrackedWorks(day, day.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("UntrackedWorks failed: %v", err)
+	}
+	if len(untracked) != 2 || untracked[0].Work.ID != legacy.ID || untracked[0].Seconds != 3600 ||
+		untracked[1].Work.ID != partly.ID || untracked[1].Seconds != 900 {
+		t.Errorf("expected the time without intervals of Legacy and Partly, got %+v", untracked)
+	}
+
+	// the time typed in is exported
+	events, _ := uc.IntervalEvents(day, day.Add(24*time.Hour))
+	if len(events) != 2 || events[1].Summary != "Typed" {
+		t.Errorf("expected the typed time to be exported, got %+v", events)
+	}
+}
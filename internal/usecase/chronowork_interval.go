@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+)
+
+// UntrackedWork is a work whose total time is more than its finished tracking
+// intervals, such as a work created before the intervals were recorded.
+type UntrackedWork struct {
+	Work domain.ChronoWork
+	// Seconds is the time of the work that no interval covers.
+	Seconds int
+}
+
+// UntrackedWorks returns the works created in the range whose time is not
+// covered by their finished tracking intervals, oldest first. That time is
+// missing from the exports of the intervals.
+func (uc *ChronoWorkUseCase) UntrackedWorks(startTime, endTime time.Time) ([]UntrackedWork, error) {
+	chronoWorks, err := uc.repo.FindInRange(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	// the intervals of a work may run into the days around it
+	intervals, err := uc.repo.FindIntervalsInRange(startTime.AddDate(0, 0, -1), endTime.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	tracked := map[uint]int{}
+	for _, interval := range intervals {
+		if !interval.IsOpen() {
+			tracked[interval.ChronoWorkID] += interval.Seconds()
+		}
+	}
+
+	var result []UntrackedWork
+	for _, cw := range chronoWorks {
+		if missing := cw.TotalSeconds - tracked[cw.ID]; missing > 0 {
+			result = append(result, UntrackedWork{Work: cw, Seconds: missing})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Work.CreatedAt.Before(result[j].Work.CreatedAt)
+	})
+	return result, nil
+}
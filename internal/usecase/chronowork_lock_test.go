@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func assertPermissionError(t *testing.T, name string, err error) {
+	t.Helper()
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Code != ErrCodePermission {
+		t.Errorf("%s: expected permission error, got %v", name, err)
+	}
+}
+
+func TestChronoWorkUseCase_ConfirmedWorkIsLocked(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	created, _ := uc.Create("Locked", 0, 0)
+	if err := uc.UpdateConfirmed(created.ID, true); err != nil {
+		t.Fatalf("UpdateConfirmed failed: %v", err)
+	}
+
+	assertPermissionError(t, "Update", uc.Update(created.ID, "Changed", 1, 1))
+	assertPermissionError(t, "UpdateTotalSeconds", uc.UpdateTotalSeconds(created.ID, 60))
+	assertPermissionError(t, "StartTracking", uc.StartTracking(created.ID))
+	assertPermissionError(t, "Delete", uc.Delete(created.ID))
+	assertPermissionError(t, "UpdateConfirmed(false)", uc.UpdateConfirmed(created.ID, false))
+
+	found, _ := uc.FindByID(created.ID)
+	if found.Title != "Locked" || found.TotalSeconds != 0 || found.IsTracking {
+		t.Errorf("expected locked work to be unchanged, got %+v", found)
+	}
+
+	// Reopen unlocks the work
+	if err := uc.Reopen(created.ID); err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	if err := uc.Update(created.ID, "Changed", 1, 1); err != nil {
+		t.Errorf("Update after Reopen failed: %v", err)
+	}
+}
+
+func TestChronoWorkUseCase_ConfirmStopsTracking(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	created, _ := uc.Create("Tracking", 0, 0)
+	_ = uc.StartTracking(created.ID)
+	if err := uc.UpdateConfirmed(created.ID, true); err != nil {
+		t.Fatalf("UpdateConfirmed failed: %v", err)
+	}
+
+	found, _ := uc.FindByID(created.ID)
+	if found.IsTracking {
+		t.Error("expected tracking to be stopped on confirm")
+	}
+	if !found.Confirmed {
+		t.Error("expected work to be confirmed")
+	}
+}
+
+func TestChronoWorkUseCase_ClosePeriod(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	settingRepo := mock.NewSettingRepository()
+	auditRepo := mock.NewAuditLogRepository()
+	uc := NewChronoWorkUseCase(repo, auditRepo, settingRepo)
+
+	first, _ := uc.Create("First", 0, 0)
+	second, _ := uc.Create("Second", 0, 0)
+	_ = uc.UpdateConfirmed(second.ID, true)
+
+	count, err := uc.ClosePeriod(time.Now())
+	if err != nil {
+		t.Fatalf("ClosePeriod failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 newly confirmed work, got %d", count)
+	}
+
+	found, _ := uc.FindByID(first.ID)
+	if !found.Confirmed {
+		t.Error("expected work to be confirmed by ClosePeriod")
+	}
+
+	setting, _ := settingRepo.Get()
+	if setting.ClosedUntil.IsZero() {
+		t.Error("expected ClosedUntil to be set")
+	}
+	logs, _ := auditRepo.FindByWorkID(0)
+	if len(logs) != 1 || logs[0].Action != domain.AuditActionCloseUntil || logs[0].OldValue != "" || logs[0].NewValue != time.Now().Format("2006/01/02") {
+		t.Errorf("expected the closed period to be recorded, got %+v", logs)
+	}
+	if _, err := uc.ClosePeriod(time.Now().AddDate(0, 0, -1)); err != nil {
+		t.Fatalf("ClosePeriod failed: %v", err)
+	}
+	if logs, _ := auditRepo.FindByWorkID(0); len(logs) != 1 {
+		t.Errorf("expected an earlier day not to move the closed period, got %+v", logs)
+	}
+
+	// No new works in a closed period
+	_, err = uc.Create("Third", 0, 0)
+	assertPermissionError(t, "Create", err)
+}
+
+func TestChronoWorkUseCase_ClosePeriodRollback(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	settingRepo := mock.NewSettingRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), settingRepo)
+
+	first, _ := uc.Create("First", 0, 0)
+	second, _ := uc.Create("Second", 0, 0)
+	settingRepo.SetUpdateClosedUntilError(errors.New("disk full"))
+
+	if count, err := uc.ClosePeriod(time.Now()); err == nil || count != 0 {
+		t.Fatalf("expected ClosePeriod to fail, got %d, %v", count, err)
+	}
+	for _, id := range []uint{first.ID, second.ID} {
+		if found, _ := uc.FindByID(id); found.Confirmed {
+			t.Errorf("expected work %d to stay unconfirmed without a closed period", id)
+		}
+	}
+}
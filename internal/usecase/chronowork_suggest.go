@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+)
+
+// TitleSuggestion is a title of past works with the project and tag it was last used with.
+type TitleSuggestion struct {
+	Title         string
+	ProjectTypeID uint
+	TagID         uint
+	Count         int
+	LastUsed      time.Time
+}
+
+// TitleSuggestions returns the distinct titles of the latest works, scanning at
+// most limit works. The most frequent titles come first and ties are broken by
+// the most recent use.
+func (uc *ChronoWorkUseCase) TitleSuggestions(limit int) ([]TitleSuggestion, error) {
+	chronoWorks, err := uc.repo.GetAll("created_at desc", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string]int)
+	var suggestions []TitleSuggestion
+	for _, cw := range chronoWorks {
+		i, ok := indexes[cw.Title]
+		if !ok {
+			indexes[cw.Title] = len(suggestions)
+			suggestions = append(suggestions, TitleSuggestion{Title: cw.Title})
+			i = len(suggestions) - 1
+		}
+		s := &suggestions[i]
+		s.Count++
+		if s.Count == 1 || cw.CreatedAt.After(s.LastUsed) {
+			s.ProjectTypeID = cw.ProjectTypeID
+			s.TagID = cw.TagID
+			s.LastUsed = cw.CreatedAt
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Count != suggestions[j].Count {
+			return suggestions[i].Count > suggestions[j].Count
+		}
+		return suggestions[i].LastUsed.After(suggestions[j].LastUsed)
+	})
+	return suggestions, nil
+}
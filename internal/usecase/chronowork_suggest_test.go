@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func TestChronoWorkUseCase_TitleSuggestions(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.Local)
+	repo.CreateAt("Standup", 1, 1, base)
+	repo.CreateAt("Review", 2, 0, base.AddDate(0, 0, 1))
+	repo.CreateAt("Standup", 3, 4, base.AddDate(0, 0, 2))
+	repo.CreateAt("Deploy", 1, 0, base.AddDate(0, 0, 3))
+
+	suggestions, err := uc.TitleSuggestions(0)
+	if err != nil {
+		t.Fatalf("TitleSuggestions failed: %v", err)
+	}
+
+	var titles []string
+	for _, s := range suggestions {
+		titles = append(titles, s.Title)
+	}
+	want := []string{"Standup", "Deploy", "Review"}
+	if len(titles) != len(want) {
+		t.Fatalf("expected %v, got %v", want, titles)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, titles)
+		}
+	}
+
+	standup := suggestions[0]
+	if standup.Count != 2 {
+		t.Errorf("expected count 2, got %d", standup.Count)
+	}
+	if standup.ProjectTypeID != 3 || standup.TagID != 4 {
+		t.Errorf("expected last used project 3 and tag 4, got %d and %d", standup.ProjectTypeID, standup.TagID)
+	}
+}
@@ -1,15 +1,17 @@
 package usecase
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/niiharamegumu/chronowork/internal/domain"
 	"github.com/niiharamegumu/chronowork/internal/repository/mock"
 )
 
 func TestChronoWorkUseCase_Create(t *testing.T) {
 	repo := mock.NewChronoWorkRepository()
-	uc := NewChronoWorkUseCase(repo)
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
 
 	// Test creation
 	cw, err := uc.Create("Test Work", 1, 2)
@@ -33,7 +35,7 @@ func TestChronoWorkUseCase_Create(t *testing.T) {
 
 func TestChronoWorkUseCase_FindByID(t *testing.T) {
 	repo := mock.NewChronoWorkRepository()
-	uc := NewChronoWorkUseCase(repo)
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
 
 	// Create a work entry
 	created, _ := uc.Create("Test Work", 0, 0)
@@ -56,7 +58,7 @@ func TestChronoWorkUseCase_FindByID(t *testing.T) {
 
 func TestChronoWorkUseCase_Update(t *testing.T) {
 	repo := mock.NewChronoWorkRepository()
-	uc := NewChronoWorkUseCase(repo)
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
 
 	// Create
 	created, _ := uc.Create("Original", 0, 0)
@@ -79,7 +81,7 @@ func TestChronoWorkUseCase_Update(t *testing.T) {
 
 func TestChronoWorkUseCase_Delete(t *testing.T) {
 	repo := mock.NewChronoWorkRepository()
-	uc := NewChronoWorkUseCase(repo)
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
 
 	// Create
 	created, _ := uc.Create("To Delete", 0, 0)
@@ -99,7 +101,7 @@ func TestChronoWorkUseCase_Delete(t *testing.T) {
 
 func TestChronoWorkUseCase_Tracking(t *testing.T) {
 	repo := mock.NewChronoWorkRepository()
-	uc := NewChronoWorkUseCase(repo)
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
 
 	// Create
 	created, _ := uc.Create("Track Test", 0, 0)
@@ -144,7 +146,7 @@ func TestChronoWorkUseCase_Tracking(t *testing.T) {
 
 func TestChronoWorkUseCase_UpdateConfirmed(t *testing.T) {
 	repo := mock.NewChronoWorkRepository()
-	uc := NewChronoWorkUseCase(repo)
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
 
 	// Create
 	created, _ := uc.Create("Confirm Test", 0, 0)
@@ -170,7 +172,7 @@ func TestChronoWorkUseCase_UpdateConfirmed(t *testing.T) {
 
 func TestChronoWorkUseCase_UpdateTotalSeconds(t *testing.T) {
 	repo := mock.NewChronoWorkRepository()
-	uc := NewChronoWorkUseCase(repo)
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
 
 	// Create
 	created, _ := uc.Create("Timer Test", 0, 0)
@@ -187,3 +189,61 @@ func TestChronoWorkUseCase_UpdateTotalSeconds(t *testing.T) {
 		t.Errorf("expected TotalSeconds 3600, got %d", found.TotalSeconds)
 	}
 }
+
+func TestChronoWorkUseCase_CreateOnDate(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	cw, err := uc.CreateOnDate("Back-filled", 1, 2, yesterday)
+	if err != nil {
+		t.Fatalf("CreateOnDate failed: %v", err)
+	}
+	if cw.CreatedAt.Year() != yesterday.Year() || cw.CreatedAt.YearDay() != yesterday.YearDay() {
+		t.Errorf("expected CreatedAt on %s, got %s", yesterday.Format("2006/01/02"), cw.CreatedAt.Format("2006/01/02"))
+	}
+
+	// Future dates are rejected
+	if _, err := uc.CreateOnDate("Future", 0, 0, time.Now().AddDate(0, 0, 1)); err == nil {
+		t.Error("expected error for future date")
+	}
+}
+
+func TestChronoWorkUseCase_CreateWithIntervals(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	auditRepo := mock.NewAuditLogRepository()
+	uc := NewChronoWorkUseCase(repo, auditRepo, mock.NewSettingRepository())
+	date := time.Now().AddDate(0, 0, -1)
+	start := time.Date(date.Year(), date.Month(), date.Day(), 9, 0, 0, 0, time.Local)
+	intervals := []domain.WorkInterval{
+		{StartTime: start, EndTime: start.Add(30 * time.Minute)},
+		{StartTime: start.Add(time.Hour), EndTime: start.Add(2 * time.Hour)},
+	}
+
+	if _, err := uc.CreateWithIntervals("Review", 0, 0, date, []domain.WorkInterval{{StartTime: start, EndTime: start}}); err == nil {
+		t.Error("expected an error for an empty interval")
+	}
+	cw, err := uc.CreateWithIntervals("Review", 0, 0, date, intervals)
+	if err != nil {
+		t.Fatalf("CreateWithIntervals failed: %v", err)
+	}
+	if cw.TotalSeconds != 5400 {
+		t.Errorf("expected the total time of the intervals, got %d", cw.TotalSeconds)
+	}
+	recorded, _ := repo.FindIntervalsInRange(start, start.Add(3*time.Hour))
+	if len(recorded) != 2 || !recorded[1].StartTime.Equal(intervals[1].StartTime) || !recorded[1].EndTime.Equal(intervals[1].EndTime) {
+		t.Errorf("expected the intervals to be recorded as given, got %+v", recorded)
+	}
+
+	// a failed write leaves neither the work nor its intervals behind
+	auditRepo.SetCreateError(errors.New("disk full"))
+	if _, err := uc.CreateWithIntervals("Spec", 0, 0, date, intervals); err == nil {
+		t.Fatal("expected the audit failure to be returned")
+	}
+	if found, _ := repo.FindByTitleOnDate("Spec", date); found != nil {
+		t.Errorf("expected the work to be rolled back, got %+v", found)
+	}
+	if recorded, _ := repo.FindIntervalsInRange(start, start.Add(3*time.Hour)); len(recorded) != 2 {
+		t.Errorf("expected the intervals to be rolled back, got %+v", recorded)
+	}
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+)
+
+// TimelineRow is a work with its tracking intervals within a day.
+type TimelineRow struct {
+	Work      domain.ChronoWork
+	Intervals []domain.WorkInterval
+}
+
+// Timeline returns the works tracked on the day of date with their tracking
+// intervals clipped to the day, ordered by their first interval. Intervals
+// still being tracked end at now.
+func (uc *ChronoWorkUseCase) Timeline(date, now time.Time) ([]TimelineRow, error) {
+	startTime := timeutil.StartOfDay(date)
+	endTime := timeutil.EndOfDay(date)
+	intervals, err := uc.repo.FindIntervalsInRange(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []TimelineRow
+	indexes := make(map[uint]int)
+	for _, interval := range intervals {
+		if interval.IsOpen() {
+			interval.EndTime = now
+		}
+		if interval.StartTime.Before(startTime) {
+			interval.StartTime = startTime
+		}
+		if interval.EndTime.After(endTime) {
+			interval.EndTime = endTime
+		}
+		if !interval.EndTime.After(interval.StartTime) {
+			continue
+		}
+
+		i, ok := indexes[interval.ChronoWorkID]
+		if !ok {
+			cw, err := uc.repo.FindByID(interval.ChronoWorkID)
+			if err != nil {
+				// the intervals of deleted works are not shown
+				continue
+			}
+			indexes[interval.ChronoWorkID] = len(rows)
+			rows = append(rows, TimelineRow{Work: *cw})
+			i = len(rows) - 1
+		}
+		rows[i].Intervals = append(rows[i].Intervals, interval)
+	}
+	return rows, nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func TestChronoWorkUseCase_Timeline(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	review, _ := repo.CreateAt("Review", 1, 0, at(9, 0))
+	standup, _ := repo.CreateAt("Standup", 2, 0, at(9, 0))
+	deleted, _ := repo.CreateAt("Deleted", 0, 0, at(9, 0))
+	repo.AddInterval(review.ID, at(-1, 0), at(0, 30))
+	repo.AddInterval(standup.ID, at(9, 0), at(9, 15))
+	repo.AddInterval(review.ID, at(13, 0), at(14, 0))
+	repo.AddInterval(review.ID, at(16, 0), time.Time{})
+	repo.AddInterval(deleted.ID, at(10, 0), at(11, 0))
+	repo.AddInterval(standup.ID, at(24, 10), at(24, 20))
+	repo.Delete(deleted.ID)
+
+	rows, err := uc.Timeline(day, at(17, 30))
+	if err != nil {
+		t.Fatalf("Timeline failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Work.Title != "Review" || rows[1].Work.Title != "Standup" {
+		t.Fatalf("expected Review and Standup rows, got %+v", rows)
+	}
+
+	want := [][2]time.Time{{at(0, 0), at(0, 30)}, {at(13, 0), at(14, 0)}, {at(16, 0), at(17, 30)}}
+	if len(rows[0].Intervals) != len(want) {
+		t.Fatalf("expected %d intervals, got %+v", len(want), rows[0].Intervals)
+	}
+	for i, interval := range rows[0].Intervals {
+		if !interval.StartTime.Equal(want[i][0]) || !interval.EndTime.Equal(want[i][1]) {
+			t.Errorf("interval %d: expected %v-%v, got %v-%v", i, want[i][0], want[i][1], interval.StartTime, interval.EndTime)
+		}
+	}
+	if len(rows[1].Intervals) != 1 {
+		t.Errorf("expected 1 Standup interval within the day, got %+v", rows[1].Intervals)
+	}
+}
+
+func TestChronoWorkUseCase_TrackingRecordsIntervals(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	cw, _ := uc.Create("Review", 0, 0)
+	for i := 0; i < 2; i++ {
+		if err := uc.StartTracking(cw.ID); err != nil {
+			t.Fatalf("StartTracking failed: %v", err)
+		}
+		if err := uc.StopTracking(cw.ID); err != nil {
+			t.Fatalf("StopTracking failed: %v", err)
+		}
+	}
+
+	intervals, _ := repo.FindIntervalsInRange(time.Now().Add(-time.Hour), time.Now())
+	if len(intervals) != 2 {
+		t.Fatalf("expected 2 intervals, got %d", len(intervals))
+	}
+	for _, interval := range intervals {
+		if interval.IsOpen() {
+			t.Errorf("expected closed interval, got %+v", interval)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package usecase
 
+import "fmt"
+
 // ErrorCode represents the type of error that occurred.
 type ErrorCode string
 
@@ -8,6 +10,8 @@ const (
 	ErrCodeNotFound ErrorCode = "NOT_FOUND"
 	// ErrCodeDuplicateToday indicates a duplicate entry for today.
 	ErrCodeDuplicateToday ErrorCode = "DUPLICATE_TODAY"
+	// ErrCodeDuplicateDate indicates a duplicate entry for a specific date.
+	ErrCodeDuplicateDate ErrorCode = "DUPLICATE_DATE"
 	// ErrCodeValidation indicates a validation error.
 	ErrCodeValidation ErrorCode = "VALIDATION"
 	// ErrCodePermission indicates a permission error.
@@ -15,14 +19,20 @@ const (
 )
 
 // UseCaseError represents an error from the use case layer.
+// Message is written in English and may be a format for Args, so that it can
+// be used as the key of its translation.
 type UseCaseError struct {
 	Code    ErrorCode
 	Message string
+	Args    []any
 	Err     error
 }
 
 // Error implements the error interface.
 func (e *UseCaseError) Error() string {
+	if len(e.Args) > 0 {
+		return fmt.Sprintf(e.Message, e.Args...)
+	}
 	return e.Message
 }
 
@@ -47,6 +57,14 @@ func NewDuplicateError(message string) error {
 	}
 }
 
+// NewDuplicateDateError creates a new duplicate error for a specific date.
+func NewDuplicateDateError(message string) error {
+	return &UseCaseError{
+		Code:    ErrCodeDuplicateDate,
+		Message: message,
+	}
+}
+
 // NewValidationError creates a new validation error.
 func NewValidationError(message string) error {
 	return &UseCaseError{
@@ -55,6 +73,15 @@ func NewValidationError(message string) error {
 	}
 }
 
+// NewValidationErrorf creates a new validation error with a formatted message.
+func NewValidationErrorf(format string, args ...any) error {
+	return &UseCaseError{
+		Code:    ErrCodeValidation,
+		Message: format,
+		Args:    args,
+	}
+}
+
 // NewPermissionError creates a new permission error.
 func NewPermissionError(message string) error {
 	return &UseCaseError{
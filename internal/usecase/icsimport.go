@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/util/ical"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+)
+
+// ICSImportRule sets the project and tag of the imported events whose summary
+// or categories contain Keyword, ignoring case. Tag is optional.
+type ICSImportRule struct {
+	Keyword string `json:"keyword"`
+	Project string `json:"project"`
+	Tag     string `json:"tag"`
+}
+
+// ICSImportResult is the outcome of an iCalendar import.
+type ICSImportResult struct {
+	// Created is the number of works created.
+	Created int
+	// Skipped has a reason for each event or work that was not imported.
+	Skipped []string
+}
+
+// ICSImportUseCase creates works from the events of iCalendar files.
+type ICSImportUseCase struct {
+	chronoWorkUC  *ChronoWorkUseCase
+	projectTypeUC *ProjectTypeUseCase
+}
+
+// NewICSImportUseCase creates a new ICSImportUseCase.
+func NewICSImportUseCase(chronoWorkUC *ChronoWorkUseCase, projectTypeUC *ProjectTypeUseCase) *ICSImportUseCase {
+	return &ICSImportUseCase{
+		chronoWorkUC:  chronoWorkUC,
+		projectTypeUC: projectTypeUC,
+	}
+}
+
+// icsRule is a rule with its project and tag resolved.
+type icsRule struct {
+	keyword       string
+	projectTypeID uint
+	tagID         uint
+}
+
+// Import creates a work with its time filled in for each title and day of the
+// events of the calendar starting in the range. The title is the summary of the
+// event and the time is the sum of the durations of its occurrences on the day.
+// The project and tag are set by the first matching rule. Cancelled, all-day
+// and untitled events, and the works that already exist, are skipped.
+func (uc *ICSImportUseCase) Import(r io.Reader, startTime, endTime time.Time, rules []ICSImportRule) (*ICSImportResult, error) {
+	resolved, err := uc.resolveRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	events, err := ical.Parse(r)
+	if err != nil {
+		return nil, NewValidationErrorf("the calendar cannot be read: %v", err)
+	}
+
+	result := &ICSImportResult{}
+	occurrences, unsupported := ical.Occurrences(events, startTime, endTime)
+	for _, event := range unsupported {
+		result.Skipped = append(result.Skipped, fmt.Sprintf("%s: unsupported recurrence rule %s", event.Summary, event.RRule))
+	}
+
+	works := map[string]*importedWork{}
+	for _, event := range occurrences {
+		title := strings.TrimSpace(event.Summary)
+		date := timeutil.StartOfDay(event.Start)
+		skip := func(reason string) {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s %s: %s", date.Format("2006/01/02"), title, reason))
+		}
+		switch {
+		case title == "":
+			skip("no title")
+			continue
+		case event.Status == "CANCELLED":
+			skip("cancelled")
+			continue
+		case event.AllDay:
+			skip("all-day event")
+			continue
+		case !event.End.After(event.Start):
+			skip("no duration")
+			continue
+		}
+
+		key := date.Format("20060102") + "\x00" + title
+		work, ok := works[key]
+		if !ok {
+			work = &importedWork{title: title, date: date}
+			if rule, ok := matchRule(resolved, event); ok {
+				work.projectTypeID = rule.projectTypeID
+				work.tagID = rule.tagID
+			}
+			works[key] = work
+		}
+		work.add(event.Start, event.End)
+	}
+
+	sorted := make([]*importedWork, 0, len(works))
+	for _, work := range works {
+		sorted = append(sorted, work)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].date.Equal(sorted[j].date) {
+			return sorted[i].date.Before(sorted[j].date)
+		}
+		return sorted[i].title < sorted[j].title
+	})
+
+	for _, work := range sorted {
+		skip, err := createImportedWork(uc.chronoWorkUC, work)
+		if err != nil {
+			return result, err
+		}
+		if skip != nil {
+			result.Skipped = append(result.Skipped, work.reason(skip))
+			continue
+		}
+		result.Created++
+	}
+	return result, nil
+}
+
+// resolveRules finds the projects and tags of the rules.
+func (uc *ICSImportUseCase) resolveRules(rules []ICSImportRule) ([]icsRule, error) {
+	resolved := make([]icsRule, 0, len(rules))
+	for _, rule := range rules {
+		keyword := strings.ToLower(strings.TrimSpace(rule.Keyword))
+		if keyword == "" {
+			return nil, NewValidationError("import rule has no keyword")
+		}
+		projectType, err := uc.projectTypeUC.FindByName(rule.Project)
+		if err != nil || projectType == nil || projectType.ID == 0 {
+			return nil, NewValidationErrorf("import rule %q: unknown project %q", rule.Keyword, rule.Project)
+		}
+		r := icsRule{keyword: keyword, projectTypeID: projectType.ID}
+		if rule.Tag != "" {
+			tagID, ok := findTagID(projectType, rule.Tag)
+			if !ok {
+				return nil, NewValidationErrorf("import rule %q: tag %q is not allowed on project %q", rule.Keyword, rule.Tag, rule.Project)
+			}
+			r.tagID = tagID
+		}
+		resolved = append(resolved, r)
+	}
+	return resolved, nil
+}
+
+// matchRule returns the first rule whose keyword is in the summary or categories of the event.
+func matchRule(rules []icsRule, event ical.Event) (icsRule, bool) {
+	texts := append([]string{event.Summary}, event.Categories...)
+	for _, rule := range rules {
+		for _, text := range texts {
+			if strings.Contains(strings.ToLower(text), rule.keyword) {
+				return rule, true
+			}
+		}
+	}
+	return icsRule{}, false
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+)
+
+func newICSImportUseCase(t *testing.T) (*ICSImportUseCase, *ChronoWorkUseCase) {
+	t.Helper()
+	tagRepo := mock.NewTagRepository()
+	tagUC := NewTagUseCase(tagRepo)
+	meeting, _ := tagUC.Create("meeting")
+
+	settingRepo := mock.NewSettingRepository()
+	projectTypeUC := NewProjectTypeUseCase(mock.NewProjectTypeRepository(tagRepo), tagRepo, settingRepo)
+	projectTypeUC.Create("web", []uint{meeting.ID})
+
+	chronoWorkUC := NewChronoWorkUseCase(mock.NewChronoWorkRepository(), mock.NewAuditLogRepository(), settingRepo)
+	return NewICSImportUseCase(chronoWorkUC, projectTypeUC), chronoWorkUC
+}
+
+// icsEvent returns a VEVENT with floating times starting at hour on day.
+func icsEvent(summary string, day time.Time, hour int, minutes int, extra ...string) string {
+	start := day.Add(time.Duration(hour) * time.Hour)
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + summary + start.Format("20060102T1504") + "@example.com",
+		"DTSTART:" + start.Format("20060102T150405"),
+		"DTEND:" + start.Add(time.Duration(minutes)*time.Minute).Format("20060102T150405"),
+		"SUMMARY:" + summary,
+	}
+	lines = append(lines, extra...)
+	return strings.Join(append(lines, "END:VEVENT"), "\r\n") + "\r\n"
+}
+
+func icsCalendar(events ...string) *strings.Reader {
+	return strings.NewReader("BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + strings.Join(events, "") + "END:VCALENDAR\r\n")
+}
+
+func TestICSImportUseCase_Import(t *testing.T) {
+	uc, chronoWorkUC := newICSImportUseCase(t)
+	yesterday := timeutil.StartOfDay(time.Now()).AddDate(0, 0, -1)
+	before := yesterday.AddDate(0, 0, -1)
+
+	calendar := icsCalendar(
+		icsEvent("Sprint planning", yesterday, 10, 60, "CATEGORIES:Web"),
+		icsEvent("Sprint planning", yesterday, 15, 30, "CATEGORIES:Web"),
+		icsEvent("1on1", yesterday, 11, 30),
+		icsEvent("Retro", yesterday, 16, 60, "STATUS:CANCELLED"),
+		icsEvent("Offsite", yesterday, 0, 0),
+		icsEvent("Old meeting", before.AddDate(0, 0, -7), 9, 60),
+	)
+	rules := []ICSImportRule{
+		{Keyword: "planning", Project: "web", Tag: "meeting"},
+		{Keyword: "web", Project: "web"},
+	}
+
+	result, err := uc.Import(calendar, before, timeutil.EndOfDay(yesterday), rules)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Created != 2 {
+		t.Errorf("expected 2 works, got %d", result.Created)
+	}
+	if len(result.Skipped) != 2 {
+		t.Errorf("expected the cancelled and zero-duration events to be skipped, got %q", result.Skipped)
+	}
+
+	works, _ := chronoWorkUC.FindInRange(yesterday, timeutil.EndOfDay(yesterday))
+	found := map[string]int{}
+	for _, work := range works {
+		found[work.Title] = work.TotalSeconds
+		// a work is placed at the start of its first event
+		if work.Title == "Sprint planning" && !work.CreatedAt.Equal(yesterday.Add(10*time.Hour)) {
+			t.Errorf("expected the work to be created at its first event, got %v", work.CreatedAt)
+		}
+		if work.Title == "Sprint planning" && (work.ProjectTypeID != 1 || work.TagID != 1) {
+			t.Errorf("expected the first rule to set the project and tag, got %d and %d", work.ProjectTypeID, work.TagID)
+		}
+		if work.Title == "1on1" && work.ProjectTypeID != 0 {
+			t.Errorf("expected no project without a matching rule, got %d", work.ProjectTypeID)
+		}
+	}
+	if found["Sprint planning"] != 90*60 || found["1on1"] != 30*60 {
+		t.Errorf("unexpected times %v", found)
+	}
+
+	// each event is recorded as a tracking interval
+	events, _ := chronoWorkUC.IntervalEvents(yesterday, timeutil.EndOfDay(yesterday))
+	starts := []string{}
+	for _, event := range events {
+		starts = append(starts, event.Summary+" "+event.Start.Format("15:04")+"-"+event.End.Format("15:04"))
+	}
+	sort.Strings(starts)
+	if want := []string{"1on1 11:00-11:30", "Sprint planning 10:00-11:00", "Sprint planning 15:00-15:30"}; !reflect.DeepEqual(starts, want) {
+		t.Errorf("intervals = %q, want %q", starts, want)
+	}
+}
+
+func TestICSImportUseCase_ImportSkipsExisting(t *testing.T) {
+	uc, _ := newICSImportUseCase(t)
+	yesterday := timeutil.StartOfDay(time.Now()).AddDate(0, 0, -1)
+
+	for i, want := range []int{1, 0} {
+		result, err := uc.Import(icsCalendar(icsEvent("Standup", yesterday, 9, 15)), yesterday, timeutil.EndOfDay(yesterday), nil)
+		if err != nil {
+			t.Fatalf("Import %d failed: %v", i, err)
+		}
+		if result.Created != want {
+			t.Errorf("import %d: expected %d works, got %d", i, want, result.Created)
+		}
+	}
+}
+
+func TestICSImportUseCase_ImportInvalidRules(t *testing.T) {
+	uc, _ := newICSImportUseCase(t)
+	for _, rules := range [][]ICSImportRule{
+		{{Keyword: "", Project: "web"}},
+		{{Keyword: "sync", Project: "unknown"}},
+		{{Keyword: "sync", Project: "web", Tag: "bug"}},
+	} {
+		if _, err := uc.Import(icsCalendar(), time.Now(), time.Now(), rules); err == nil {
+			t.Errorf("expected an error for %+v", rules)
+		}
+	}
+}
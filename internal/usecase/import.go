@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+)
+
+// importedWork is a work to be created by an import with the tracked
+// intervals of its title, project and tag on a day.
+type importedWork struct {
+	title         string
+	date          time.Time
+	projectTypeID uint
+	tagID         uint
+	intervals     []domain.WorkInterval
+}
+
+// add adds a tracked interval to the work.
+func (w *importedWork) add(start, end time.Time) {
+	w.intervals = append(w.intervals, domain.WorkInterval{StartTime: start, EndTime: end})
+}
+
+// seconds returns the total time of the intervals of the work.
+func (w *importedWork) seconds() int {
+	seconds := 0
+	for _, interval := range w.intervals {
+		seconds += interval.Seconds()
+	}
+	return seconds
+}
+
+// createdAt returns the start of the first interval of the work, which places
+// the work at its time within the day, or the day if it has no intervals.
+func (w *importedWork) createdAt() time.Time {
+	createdAt := w.date
+	for i, interval := range w.intervals {
+		if i == 0 || interval.StartTime.Before(createdAt) {
+			createdAt = interval.StartTime
+		}
+	}
+	return createdAt
+}
+
+// reason describes why the work was not imported.
+func (w *importedWork) reason(err error) string {
+	return fmt.Sprintf("%s %s: %s", w.date.Format("2006/01/02"), w.title, err.Error())
+}
+
+// createImportedWork creates the work at the start of its first interval with
+// its intervals and time in one transaction. Duplicates, future dates and closed periods concern the work
+// only and are returned as skip so that the import goes on; other errors stop
+// the import.
+func createImportedWork(chronoWorkUC *ChronoWorkUseCase, work *importedWork) (skip *UseCaseError, err error) {
+	_, err = chronoWorkUC.CreateWithIntervals(work.title, work.projectTypeID, work.tagID, work.createdAt(), work.intervals)
+	var ucErr *UseCaseError
+	if errors.As(err, &ucErr) && ucErr.Code != ErrCodeNotFound {
+		return ucErr, nil
+	}
+	return nil, err
+}
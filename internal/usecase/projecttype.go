@@ -1,18 +1,26 @@
 package usecase
 
 import (
+	"strings"
+
 	"github.com/niiharamegumu/chronowork/internal/domain"
 	"github.com/niiharamegumu/chronowork/internal/repository"
 )
 
 // ProjectTypeUseCase handles business logic for ProjectType operations.
 type ProjectTypeUseCase struct {
-	repo repository.ProjectTypeRepository
+	repo        repository.ProjectTypeRepository
+	tagRepo     repository.TagRepository
+	settingRepo repository.SettingRepository
 }
 
 // NewProjectTypeUseCase creates a new ProjectTypeUseCase.
-func NewProjectTypeUseCase(repo repository.ProjectTypeRepository) *ProjectTypeUseCase {
-	return &ProjectTypeUseCase{repo: repo}
+func NewProjectTypeUseCase(repo repository.ProjectTypeRepository, tagRepo repository.TagRepository, settingRepo repository.SettingRepository) *ProjectTypeUseCase {
+	return &ProjectTypeUseCase{
+		repo:        repo,
+		tagRepo:     tagRepo,
+		settingRepo: settingRepo,
+	}
 }
 
 // Create creates a new ProjectType with optional tags.
@@ -45,6 +53,50 @@ func (uc *ProjectTypeUseCase) Update(id uint, name string, tagIDs []uint) error
 	return uc.repo.Update(id, name, tagIDs)
 }
 
+// AddTag allows the tag named tagName on a ProjectType, creating the tag if it
+// does not exist yet. Extending a project requires AllowTagCreation in the setting.
+func (uc *ProjectTypeUseCase) AddTag(projectTypeID uint, tagName string) (*domain.Tag, error) {
+	tagName = strings.TrimSpace(tagName)
+	if tagName == "" {
+		return nil, NewValidationError("tag name is empty")
+	}
+	setting, err := uc.settingRepo.Get()
+	if err != nil {
+		return nil, err
+	}
+	if !setting.AllowTagCreation {
+		return nil, NewPermissionError("extending projects with new tags is not allowed")
+	}
+	projectType, err := uc.repo.FindByID(projectTypeID)
+	if err != nil {
+		return nil, NewNotFoundError("project type not found")
+	}
+
+	tagIDs := make([]uint, 0, len(projectType.Tags)+1)
+	for _, tag := range projectType.Tags {
+		if tag.Name == tagName {
+			return &tag, nil
+		}
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	var tag *domain.Tag
+	tags, err := uc.tagRepo.FindByNames([]string{tagName})
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) > 0 {
+		tag = &tags[0]
+	} else if tag, err = uc.tagRepo.Create(tagName); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(projectType.ID, projectType.Name, append(tagIDs, tag.ID)); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
 // Delete permanently deletes a ProjectType.
 func (uc *ProjectTypeUseCase) Delete(id uint) error {
 	return uc.repo.Delete(id)
@@ -9,7 +9,7 @@ import (
 func TestProjectTypeUseCase_Create(t *testing.T) {
 	tagRepo := mock.NewTagRepository()
 	repo := mock.NewProjectTypeRepository(tagRepo)
-	uc := NewProjectTypeUseCase(repo)
+	uc := NewProjectTypeUseCase(repo, tagRepo, mock.NewSettingRepository())
 
 	pt, err := uc.Create("Test Project", nil)
 	if err != nil {
@@ -31,7 +31,7 @@ func TestProjectTypeUseCase_CreateWithTags(t *testing.T) {
 	tag2, _ := tagUC.Create("Tag2")
 
 	repo := mock.NewProjectTypeRepository(tagRepo)
-	uc := NewProjectTypeUseCase(repo)
+	uc := NewProjectTypeUseCase(repo, tagRepo, mock.NewSettingRepository())
 
 	pt, err := uc.Create("Project With Tags", []uint{tag1.ID, tag2.ID})
 	if err != nil {
@@ -46,7 +46,7 @@ func TestProjectTypeUseCase_CreateWithTags(t *testing.T) {
 func TestProjectTypeUseCase_FindByID(t *testing.T) {
 	tagRepo := mock.NewTagRepository()
 	repo := mock.NewProjectTypeRepository(tagRepo)
-	uc := NewProjectTypeUseCase(repo)
+	uc := NewProjectTypeUseCase(repo, tagRepo, mock.NewSettingRepository())
 
 	created, _ := uc.Create("Test Project", nil)
 
@@ -62,7 +62,7 @@ func TestProjectTypeUseCase_FindByID(t *testing.T) {
 func TestProjectTypeUseCase_FindByName(t *testing.T) {
 	tagRepo := mock.NewTagRepository()
 	repo := mock.NewProjectTypeRepository(tagRepo)
-	uc := NewProjectTypeUseCase(repo)
+	uc := NewProjectTypeUseCase(repo, tagRepo, mock.NewSettingRepository())
 
 	uc.Create("Find Me", nil)
 
@@ -78,7 +78,7 @@ func TestProjectTypeUseCase_FindByName(t *testing.T) {
 func TestProjectTypeUseCase_GetAllNames(t *testing.T) {
 	tagRepo := mock.NewTagRepository()
 	repo := mock.NewProjectTypeRepository(tagRepo)
-	uc := NewProjectTypeUseCase(repo)
+	uc := NewProjectTypeUseCase(repo, tagRepo, mock.NewSettingRepository())
 
 	uc.Create("Project A", nil)
 	uc.Create("Project B", nil)
@@ -92,7 +92,7 @@ func TestProjectTypeUseCase_GetAllNames(t *testing.T) {
 func TestProjectTypeUseCase_Update(t *testing.T) {
 	tagRepo := mock.NewTagRepository()
 	repo := mock.NewProjectTypeRepository(tagRepo)
-	uc := NewProjectTypeUseCase(repo)
+	uc := NewProjectTypeUseCase(repo, tagRepo, mock.NewSettingRepository())
 
 	created, _ := uc.Create("Original", nil)
 
@@ -110,7 +110,7 @@ func TestProjectTypeUseCase_Update(t *testing.T) {
 func TestProjectTypeUseCase_Delete(t *testing.T) {
 	tagRepo := mock.NewTagRepository()
 	repo := mock.NewProjectTypeRepository(tagRepo)
-	uc := NewProjectTypeUseCase(repo)
+	uc := NewProjectTypeUseCase(repo, tagRepo, mock.NewSettingRepository())
 
 	created, _ := uc.Create("To Delete", nil)
 
@@ -124,3 +124,48 @@ func TestProjectTypeUseCase_Delete(t *testing.T) {
 		t.Error("expected error finding deleted project type")
 	}
 }
+
+func TestProjectTypeUseCase_AddTag(t *testing.T) {
+	tagRepo := mock.NewTagRepository()
+	tagUC := NewTagUseCase(tagRepo)
+	existing, _ := tagUC.Create("Existing")
+
+	repo := mock.NewProjectTypeRepository(tagRepo)
+	settingRepo := mock.NewSettingRepository()
+	uc := NewProjectTypeUseCase(repo, tagRepo, settingRepo)
+	created, _ := uc.Create("Project", []uint{existing.ID})
+
+	_, err := uc.AddTag(created.ID, "New")
+	assertPermissionError(t, "AddTag", err)
+
+	setting, _ := settingRepo.Get()
+	setting.AllowTagCreation = true
+	settingRepo.Update(setting)
+
+	tag, err := uc.AddTag(created.ID, " New ")
+	if err != nil {
+		t.Fatalf("AddTag failed: %v", err)
+	}
+	if tag.Name != "New" {
+		t.Errorf("expected tag 'New', got '%s'", tag.Name)
+	}
+	again, err := uc.AddTag(created.ID, "New")
+	if err != nil {
+		t.Fatalf("AddTag failed: %v", err)
+	}
+	if again.ID != tag.ID {
+		t.Errorf("expected the same tag, got ID %d and %d", tag.ID, again.ID)
+	}
+
+	updated, _ := uc.FindByID(created.ID)
+	if names := updated.GetTagNames(); len(names) != 2 || names[0] != "Existing" || names[1] != "New" {
+		t.Errorf("expected tags [Existing New], got %v", names)
+	}
+	if len(tagRepo.GetAllNames()) != 2 {
+		t.Errorf("expected 2 tags, got %v", tagRepo.GetAllNames())
+	}
+
+	if _, err := uc.AddTag(created.ID, ""); err == nil {
+		t.Error("expected error for empty tag name")
+	}
+}
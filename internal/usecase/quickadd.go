@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+)
+
+// QuickAdd is a work parsed from a quick-add line.
+type QuickAdd struct {
+	Title         string
+	ProjectTypeID uint
+	TagID         uint
+	TotalSeconds  int
+}
+
+// QuickAddUseCase creates works from single-line quick-add input.
+type QuickAddUseCase struct {
+	chronoWorkUC  *ChronoWorkUseCase
+	projectTypeUC *ProjectTypeUseCase
+}
+
+// NewQuickAddUseCase creates a new QuickAddUseCase.
+func NewQuickAddUseCase(chronoWorkUC *ChronoWorkUseCase, projectTypeUC *ProjectTypeUseCase) *QuickAddUseCase {
+	return &QuickAddUseCase{
+		chronoWorkUC:  chronoWorkUC,
+		projectTypeUC: projectTypeUC,
+	}
+}
+
+// Parse parses a quick-add line such as "Fix login bug @web #bug ~30m".
+// Plain words form the title, "@" names the project, "#" names one of the
+// project's tags and "~" gives the time already spent, e.g. "~30m", "~1h30m"
+// or "~45" (minutes). Names with spaces are quoted, e.g. @"Client Work".
+func (uc *QuickAddUseCase) Parse(line string) (*QuickAdd, error) {
+	words, err := splitQuickAdd(line)
+	if err != nil {
+		return nil, err
+	}
+	var projectName, tagName, spent string
+	var titleWords []string
+	for _, word := range words {
+		var target *string
+		switch {
+		case strings.HasPrefix(word, "@") && len(word) > 1:
+			target = &projectName
+		case strings.HasPrefix(word, "#") && len(word) > 1:
+			target = &tagName
+		case strings.HasPrefix(word, "~") && len(word) > 1:
+			target = &spent
+		default:
+			titleWords = append(titleWords, word)
+			continue
+		}
+		if *target != "" {
+			return nil, NewValidationErrorf("only one %s is allowed", word[:1])
+		}
+		*target = word[1:]
+	}
+
+	quickAdd := &QuickAdd{Title: strings.Join(titleWords, " ")}
+	if quickAdd.Title == "" {
+		return nil, NewValidationError("title is empty")
+	}
+
+	if tagName != "" && projectName == "" {
+		return nil, NewValidationErrorf("tag #%s requires a project", tagName)
+	}
+	if projectName != "" {
+		projectType, err := uc.projectTypeUC.FindByName(projectName)
+		if err != nil || projectType == nil || projectType.ID == 0 {
+			return nil, NewValidationErrorf("unknown project @%s", projectName)
+		}
+		quickAdd.ProjectTypeID = projectType.ID
+		if tagName != "" {
+			tagID, ok := findTagID(projectType, tagName)
+			if !ok {
+				return nil, NewValidationErrorf("tag #%s is not allowed on project @%s", tagName, projectName)
+			}
+			quickAdd.TagID = tagID
+		}
+	}
+
+	if spent != "" {
+		seconds, err := parseSpent(spent)
+		if err != nil {
+			return nil, err
+		}
+		quickAdd.TotalSeconds = seconds
+	}
+	return quickAdd, nil
+}
+
+// Create parses line and creates the work today. The time already spent is
+// recorded as an interval ending now.
+func (uc *QuickAddUseCase) Create(line string) (*domain.ChronoWork, error) {
+	quickAdd, err := uc.Parse(line)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var intervals []domain.WorkInterval
+	if quickAdd.TotalSeconds > 0 {
+		intervals = append(intervals, domain.WorkInterval{
+			StartTime: now.Add(-time.Duration(quickAdd.TotalSeconds) * time.Second),
+			EndTime:   now,
+		})
+	}
+	return uc.chronoWorkUC.CreateWithIntervals(quickAdd.Title, quickAdd.ProjectTypeID, quickAdd.TagID, now, intervals)
+}
+
+// splitQuickAdd splits a quick-add line into words at whitespace. A project
+// or tag name may be quoted, as in @"Client Work", to keep its spaces; the
+// quotes are removed and the name must be followed by whitespace or the end.
+func splitQuickAdd(line string) ([]string, error) {
+	var words []string
+	rest := strings.TrimSpace(line)
+	for rest != "" {
+		if (strings.HasPrefix(rest, `@"`) || strings.HasPrefix(rest, `#"`)) && len(rest) > 2 {
+			end := strings.IndexByte(rest[2:], '"')
+			if end < 0 {
+				return nil, NewValidationErrorf("unterminated quote in %s", rest)
+			}
+			name := rest[2 : 2+end]
+			after := rest[2+end+1:]
+			if strings.TrimSpace(name) == "" {
+				return nil, NewValidationErrorf("empty name in %s", rest[:2+end+1])
+			}
+			if after != "" && after == strings.TrimLeftFunc(after, unicode.IsSpace) {
+				return nil, NewValidationErrorf("a space is required after %s", rest[:2+end+1])
+			}
+			words = append(words, rest[:1]+name)
+			rest = strings.TrimLeftFunc(after, unicode.IsSpace)
+			continue
+		}
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			end = len(rest)
+		}
+		words = append(words, rest[:end])
+		rest = strings.TrimLeftFunc(rest[end:], unicode.IsSpace)
+	}
+	return words, nil
+}
+
+// findTagID returns the ID of the tag named name among the project's tags.
+func findTagID(projectType *domain.ProjectType, name string) (uint, bool) {
+	for _, tag := range projectType.Tags {
+		if tag.Name == name {
+			return tag.ID, true
+		}
+	}
+	return 0, false
+}
+
+// parseSpent parses a spent time such as "30m", "1h30m", "1.5h" or "45" (minutes).
+func parseSpent(spent string) (int, error) {
+	var duration time.Duration
+	if minutes, err := strconv.Atoi(spent); err == nil {
+		duration = time.Duration(minutes) * time.Minute
+	} else if duration, err = time.ParseDuration(spent); err != nil {
+		return 0, NewValidationErrorf("invalid spent time ~%s", spent)
+	}
+	if duration <= 0 {
+		return 0, NewValidationError("spent time must be positive")
+	}
+	return int(duration.Seconds()), nil
+}
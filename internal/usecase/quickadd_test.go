@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func newQuickAddUseCase(t *testing.T) (*QuickAddUseCase, *ChronoWorkUseCase) {
+	t.Helper()
+	tagRepo := mock.NewTagRepository()
+	tagUC := NewTagUseCase(tagRepo)
+	bug, _ := tagUC.Create("bug")
+	tagUC.Create("meeting")
+
+	settingRepo := mock.NewSettingRepository()
+	projectTypeUC := NewProjectTypeUseCase(mock.NewProjectTypeRepository(tagRepo), tagRepo, settingRepo)
+	projectTypeUC.Create("web", []uint{bug.ID})
+	client, _ := tagUC.Create("code review")
+	projectTypeUC.Create("Client Work", []uint{client.ID})
+
+	chronoWorkUC := NewChronoWorkUseCase(mock.NewChronoWorkRepository(), mock.NewAuditLogRepository(), settingRepo)
+	return NewQuickAddUseCase(chronoWorkUC, projectTypeUC), chronoWorkUC
+}
+
+func TestQuickAddUseCase_Parse(t *testing.T) {
+	uc, _ := newQuickAddUseCase(t)
+
+	tests := []struct {
+		line     string
+		title    string
+		project  uint
+		tag      uint
+		seconds  int
+		hasError bool
+	}{
+		{line: "Fix login bug @web #bug ~30m", title: "Fix login bug", project: 1, tag: 1, seconds: 1800},
+		{line: "  Review   docs  ", title: "Review docs"},
+		{line: "@web Deploy ~1h30m", title: "Deploy", project: 1, seconds: 5400},
+		{line: "Standup ~15", title: "Standup", seconds: 900},
+		{line: "Spec ~1.5h", title: "Spec", seconds: 5400},
+		{line: `Review PR @"Client Work" #"code review" ~20m`, title: "Review PR", project: 2, tag: 3, seconds: 1200},
+		{line: `Reply to "urgent" mail`, title: `Reply to "urgent" mail`},
+		{line: `Review @"Client Work`, hasError: true},
+		{line: `Review @"Client Work"#bug`, hasError: true},
+		{line: `Review @""`, hasError: true},
+		{line: "@web #bug", hasError: true},
+		{line: "Fix @unknown", hasError: true},
+		{line: "Fix #bug", hasError: true},
+		{line: "Fix @web #meeting", hasError: true},
+		{line: "Fix ~soon", hasError: true},
+		{line: "Fix ~-5m", hasError: true},
+		{line: "Fix ~5m ~10m", hasError: true},
+	}
+
+	for _, tc := range tests {
+		got, err := uc.Parse(tc.line)
+		if tc.hasError {
+			var ucErr *UseCaseError
+			if !errors.As(err, &ucErr) || ucErr.Code != ErrCodeValidation {
+				t.Errorf("Parse(%q): expected validation error, got %v", tc.line, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tc.line, err)
+			continue
+		}
+		if got.Title != tc.title || got.ProjectTypeID != tc.project || got.TagID != tc.tag || got.TotalSeconds != tc.seconds {
+			t.Errorf("Parse(%q) = %+v, want title %q project %d tag %d seconds %d",
+				tc.line, *got, tc.title, tc.project, tc.tag, tc.seconds)
+		}
+	}
+}
+
+func TestQuickAddUseCase_Create(t *testing.T) {
+	uc, chronoWorkUC := newQuickAddUseCase(t)
+
+	created, err := uc.Create("Fix login bug @web #bug ~30m")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	found, err := chronoWorkUC.FindByID(created.ID)
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if found.Title != "Fix login bug" || found.ProjectTypeID != 1 || found.TagID != 1 {
+		t.Errorf("unexpected work: %+v", *found)
+	}
+	if found.TotalSeconds != 1800 {
+		t.Errorf("expected 1800 seconds, got %d", found.TotalSeconds)
+	}
+
+	if _, err := uc.Create("Fix login bug"); err == nil {
+		t.Error("expected duplicate error for the same title today")
+	}
+}
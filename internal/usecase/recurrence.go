@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// weekdayNames are the short names of the days of the week accepted in recurrence rules.
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Recurrence is a parsed recurrence rule of a work template.
+// It matches either days of the week or days of the month.
+type Recurrence struct {
+	weekdays  [7]bool
+	monthDays []int
+}
+
+// ParseRecurrence parses a recurrence rule such as "daily", "weekdays",
+// "mon,thu" (days of the week) or "monthly:1,15" (days of the month).
+// Days of the month past the end of a month match its last day.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	var r Recurrence
+	rule = strings.ToLower(strings.TrimSpace(rule))
+	switch {
+	case rule == "":
+		return r, NewValidationError("recurrence is empty")
+	case rule == "daily":
+		for i := range r.weekdays {
+			r.weekdays[i] = true
+		}
+	case rule == "weekdays":
+		for i := time.Monday; i <= time.Friday; i++ {
+			r.weekdays[i] = true
+		}
+	case strings.HasPrefix(rule, "monthly:"):
+		for _, field := range strings.Split(strings.TrimPrefix(rule, "monthly:"), ",") {
+			day, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil || day < 1 || day > 31 {
+				return r, NewValidationErrorf("invalid day of month in recurrence: %s", field)
+			}
+			r.monthDays = append(r.monthDays, day)
+		}
+		sort.Ints(r.monthDays)
+	default:
+		for _, field := range strings.Split(rule, ",") {
+			weekday, ok := parseWeekday(strings.TrimSpace(field))
+			if !ok {
+				return r, NewValidationErrorf("invalid recurrence: %s", rule)
+			}
+			r.weekdays[weekday] = true
+		}
+	}
+	return r, nil
+}
+
+// parseWeekday parses a day of the week by its short or full English name.
+func parseWeekday(name string) (time.Weekday, bool) {
+	for i, short := range weekdayNames {
+		if name == short || name == strings.ToLower(time.Weekday(i).String()) {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+// Matches reports whether the recurrence includes the day of t.
+func (r Recurrence) Matches(t time.Time) bool {
+	if len(r.monthDays) == 0 {
+		return r.weekdays[t.Weekday()]
+	}
+	lastDay := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	for _, day := range r.monthDays {
+		if day == t.Day() || day > lastDay && t.Day() == lastDay {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the canonical form of the rule.
+func (r Recurrence) String() string {
+	if len(r.monthDays) > 0 {
+		days := make([]string, len(r.monthDays))
+		for i, day := range r.monthDays {
+			days[i] = strconv.Itoa(day)
+		}
+		return "monthly:" + strings.Join(days, ",")
+	}
+
+	var names []string
+	count := 0
+	for i := 1; i <= 7; i++ {
+		// list the days from Monday
+		if weekday := i % 7; r.weekdays[weekday] {
+			names = append(names, weekdayNames[weekday])
+			count++
+		}
+	}
+	switch {
+	case count == 7:
+		return "daily"
+	case count == 5 && !r.weekdays[time.Saturday] && !r.weekdays[time.Sunday]:
+		return "weekdays"
+	}
+	return strings.Join(names, ",")
+}
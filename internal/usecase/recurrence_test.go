@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule      string
+		canonical string
+		hasError  bool
+	}{
+		{rule: "daily", canonical: "daily"},
+		{rule: " Weekdays ", canonical: "weekdays"},
+		{rule: "mon,tue,wed,thu,fri", canonical: "weekdays"},
+		{rule: "sun,sat,mon,tue,wed,thu,fri", canonical: "daily"},
+		{rule: "thu, Monday", canonical: "mon,thu"},
+		{rule: "sun", canonical: "sun"},
+		{rule: "monthly:15,1", canonical: "monthly:1,15"},
+		{rule: "", hasError: true},
+		{rule: "someday", hasError: true},
+		{rule: "monthly:0", hasError: true},
+		{rule: "monthly:32", hasError: true},
+		{rule: "monthly:", hasError: true},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseRecurrence(tc.rule)
+		if tc.hasError {
+			if err == nil {
+				t.Errorf("ParseRecurrence(%q): expected error", tc.rule)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseRecurrence(%q) failed: %v", tc.rule, err)
+			continue
+		}
+		if got := r.String(); got != tc.canonical {
+			t.Errorf("ParseRecurrence(%q).String() = %q, want %q", tc.rule, got, tc.canonical)
+		}
+	}
+}
+
+func TestRecurrence_Matches(t *testing.T) {
+	// 2024-04-01 is a Monday
+	monday := time.Date(2024, 4, 1, 9, 0, 0, 0, time.Local)
+	saturday := monday.AddDate(0, 0, 5)
+	aprilLast := time.Date(2024, 4, 30, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		rule     string
+		date     time.Time
+		expected bool
+	}{
+		{"daily", saturday, true},
+		{"weekdays", monday, true},
+		{"weekdays", saturday, false},
+		{"mon", monday, true},
+		{"mon", monday.AddDate(0, 0, 1), false},
+		{"monthly:1", monday, true},
+		{"monthly:1", saturday, false},
+		{"monthly:31", aprilLast, true},
+		{"monthly:31", aprilLast.AddDate(0, 0, -1), false},
+	}
+
+	for _, tc := range tests {
+		r, err := ParseRecurrence(tc.rule)
+		if err != nil {
+			t.Fatalf("ParseRecurrence(%q) failed: %v", tc.rule, err)
+		}
+		if got := r.Matches(tc.date); got != tc.expected {
+			t.Errorf("%q.Matches(%s) = %v, want %v", tc.rule, tc.date.Format("2006-01-02"), got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/util/timereport"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+)
+
+// ReportMapping renames the clients, projects and tags of a time tracker
+// report to the projects and tags of ChronoWork. Names without a mapping are
+// used as they are.
+type ReportMapping struct {
+	Projects map[string]string `json:"projects"`
+	// Clients sets the project of the entries whose project has no mapping.
+	Clients map[string]string `json:"clients"`
+	Tags    map[string]string `json:"tags"`
+}
+
+// ReportImportResult is the outcome of a report import.
+type ReportImportResult struct {
+	// Created is the number of works created.
+	Created int
+	// Unmapped has the projects and tags without a counterpart and their number of entries.
+	Unmapped []string
+	// Duplicates has the repeated entries and the works that already exist.
+	Duplicates []string
+	// Conflicts has the entries whose project or tag differs from the earlier
+	// entries of their work; their time is added to the work all the same.
+	Conflicts []string
+	// Skipped has the other entries and works that were not imported.
+	Skipped []string
+}
+
+// ReportImportUseCase creates works from the detailed reports of other time trackers.
+type ReportImportUseCase struct {
+	chronoWorkUC  *ChronoWorkUseCase
+	projectTypeUC *ProjectTypeUseCase
+}
+
+// NewReportImportUseCase creates a new ReportImportUseCase.
+func NewReportImportUseCase(chronoWorkUC *ChronoWorkUseCase, projectTypeUC *ProjectTypeUseCase) *ReportImportUseCase {
+	return &ReportImportUseCase{
+		chronoWorkUC:  chronoWorkUC,
+		projectTypeUC: projectTypeUC,
+	}
+}
+
+// Import creates a work with its time filled in for each description of a day
+// of the entries of the report starting in the range, since a day has one work
+// of a title. A zero start or end leaves that side of the range open. An entry
+// belongs to the day it starts, and its tag is the first of its tags allowed on
+// its project. The work takes the project and tag of its first entry, and the
+// other entries with another project or tag are reported as conflicts.
+// Entries without a description are titled after their project.
+func (uc *ReportImportUseCase) Import(r io.Reader, format timereport.Format, startTime, endTime time.Time, mapping ReportMapping) (*ReportImportResult, error) {
+	entries, err := timereport.Parse(r, format)
+	if err != nil {
+		return nil, NewValidationErrorf("the report cannot be read: %v", err)
+	}
+
+	result := &ReportImportResult{}
+	projects := map[string]*domain.ProjectType{}
+	unmapped := map[string]int{}
+	seen := map[string]int{}
+	works := map[string]*importedWork{}
+	labels := map[*importedWork]string{}
+	for _, entry := range entries {
+		if (!startTime.IsZero() && entry.Start.Before(startTime)) || (!endTime.IsZero() && entry.Start.After(endTime)) {
+			continue
+		}
+		if entry.Duration() <= 0 {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("line %d: running or empty entry", entry.Line))
+			continue
+		}
+		key := fmt.Sprintf("%d\x00%d\x00%s\x00%s\x00%s\x00%s", entry.Start.Unix(), entry.End.Unix(), entry.Client, entry.Project, entry.Description, strings.Join(entry.Tags, ","))
+		if line, ok := seen[key]; ok {
+			result.Duplicates = append(result.Duplicates, fmt.Sprintf("line %d: same entry as line %d", entry.Line, line))
+			continue
+		}
+		seen[key] = entry.Line
+
+		work := &importedWork{
+			title: entry.Description,
+			date:  timeutil.StartOfDay(entry.Start),
+		}
+		projectName := mappedName(mapping.Projects, entry.Project)
+		if _, ok := mapping.Projects[entry.Project]; !ok && entry.Client != "" {
+			if name, ok := mapping.Clients[entry.Client]; ok {
+				projectName = name
+			}
+		}
+		if work.title == "" {
+			work.title = entry.Project
+		}
+		if work.title == "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("line %d: no description or project", entry.Line))
+			continue
+		}
+
+		label := "no project"
+		projectType := findProject(uc.projectTypeUC, projects, projectName)
+		if projectName != "" && projectType == nil {
+			unmapped[fmt.Sprintf("project %q", projectName)]++
+		}
+		if projectType != nil {
+			work.projectTypeID = projectType.ID
+			label = fmt.Sprintf("project %q", projectType.Name)
+			for _, tag := range entry.Tags {
+				tagName := mappedName(mapping.Tags, tag)
+				tagID, ok := findTagID(projectType, tagName)
+				if !ok {
+					unmapped[fmt.Sprintf("tag %q on project %q", tagName, projectType.Name)]++
+					continue
+				}
+				if work.tagID == 0 {
+					work.tagID = tagID
+					label += fmt.Sprintf(" tag %q", tagName)
+				}
+			}
+		}
+
+		workKey := fmt.Sprintf("%s\x00%s", work.date.Format("20060102"), work.title)
+		if existing, ok := works[workKey]; ok {
+			if existing.projectTypeID != work.projectTypeID || existing.tagID != work.tagID {
+				result.Conflicts = append(result.Conflicts, fmt.Sprintf("line %d: %s on %s is added to the work on %s", entry.Line, label, work.date.Format("2006/01/02"), labels[existing]))
+			}
+			work = existing
+		} else {
+			works[workKey] = work
+			labels[work] = label
+		}
+		work.add(entry.Start, entry.End)
+	}
+
+	for item, count := range unmapped {
+		result.Unmapped = append(result.Unmapped, fmt.Sprintf("%s: %d entries", item, count))
+	}
+	sort.Strings(result.Unmapped)
+
+	sorted := make([]*importedWork, 0, len(works))
+	for _, work := range works {
+		sorted = append(sorted, work)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].date.Equal(sorted[j].date) {
+			return sorted[i].date.Before(sorted[j].date)
+		}
+		return sorted[i].title < sorted[j].title
+	})
+
+	for _, work := range sorted {
+		skip, err := createImportedWork(uc.chronoWorkUC, work)
+		if err != nil {
+			return result, err
+		}
+		if skip != nil {
+			if skip.Code == ErrCodeDuplicateToday || skip.Code == ErrCodeDuplicateDate {
+				result.Duplicates = append(result.Duplicates, work.reason(skip))
+			} else {
+				result.Skipped = append(result.Skipped, work.reason(skip))
+			}
+			continue
+		}
+		result.Created++
+	}
+	return result, nil
+}
+
+// findProject returns the project named name, or nil if there is none,
+// caching the lookups of an import.
+func findProject(projectTypeUC *ProjectTypeUseCase, cache map[string]*domain.ProjectType, name string) *domain.ProjectType {
+	if name == "" {
+		return nil
+	}
+	if projectType, ok := cache[name]; ok {
+		return projectType
+	}
+	projectType, err := projectTypeUC.FindByName(name)
+	if err != nil || projectType == nil || projectType.ID == 0 {
+		// the repositories report missing projects differently
+		projectType = nil
+	}
+	cache[name] = projectType
+	return projectType
+}
+
+// mappedName returns the mapping of name, or name itself.
+func mappedName(mapping map[string]string, name string) string {
+	if mapped, ok := mapping[name]; ok {
+		return mapped
+	}
+	return name
+}
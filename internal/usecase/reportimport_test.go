@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+	"github.com/niiharamegumu/chronowork/util/timereport"
+)
+
+func newReportImportUseCase(t *testing.T) (*ReportImportUseCase, *ChronoWorkUseCase) {
+	t.Helper()
+	tagRepo := mock.NewTagRepository()
+	tagUC := NewTagUseCase(tagRepo)
+	bug, _ := tagUC.Create("bug")
+	meeting, _ := tagUC.Create("meeting")
+
+	settingRepo := mock.NewSettingRepository()
+	projectTypeUC := NewProjectTypeUseCase(mock.NewProjectTypeRepository(tagRepo), tagRepo, settingRepo)
+	projectTypeUC.Create("web", []uint{bug.ID, meeting.ID})
+
+	chronoWorkUC := NewChronoWorkUseCase(mock.NewChronoWorkRepository(), mock.NewAuditLogRepository(), settingRepo)
+	return NewReportImportUseCase(chronoWorkUC, projectTypeUC), chronoWorkUC
+}
+
+func importFixture(t *testing.T, uc *ReportImportUseCase, mapping ReportMapping) *ReportImportResult {
+	t.Helper()
+	f, err := os.Open("../../util/timereport/testdata/toggl_detailed.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	result, err := uc.Import(f, timereport.Toggl, time.Time{}, time.Time{}, mapping)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	return result
+}
+
+func TestReportImportUseCase_Import(t *testing.T) {
+	uc, chronoWorkUC := newReportImportUseCase(t)
+	mapping := ReportMapping{
+		Projects: map[string]string{"Website": "web"},
+		Clients:  map[string]string{"Globex": "web"},
+	}
+
+	result := importFixture(t, uc, mapping)
+	if result.Created != 3 {
+		t.Errorf("expected 3 works, got %d", result.Created)
+	}
+	expectedUnmapped := []string{`project "Internal": 1 entries`, `tag "frontend" on project "web": 1 entries`}
+	if !reflect.DeepEqual(result.Unmapped, expectedUnmapped) {
+		t.Errorf("Unmapped = %q, want %q", result.Unmapped, expectedUnmapped)
+	}
+	if !reflect.DeepEqual(result.Duplicates, []string{"line 5: same entry as line 4"}) {
+		t.Errorf("unexpected duplicates %q", result.Duplicates)
+	}
+
+	works, _ := chronoWorkUC.GetAll("id", 0)
+	got := map[string][3]int{}
+	for _, work := range works {
+		got[work.CreatedAt.Format("01/02")+" "+work.Title] = [3]int{int(work.ProjectTypeID), int(work.TagID), work.TotalSeconds}
+	}
+	expected := map[string][3]int{
+		"05/06 Fix login bug":       {1, 1, 8100},
+		"05/06 Weekly sync":         {0, 0, 1800},
+		"05/07 Release 1.2, part 1": {1, 0, 2700},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("works = %v, want %v", got, expected)
+	}
+}
+
+func TestReportImportUseCase_ImportTwice(t *testing.T) {
+	uc, _ := newReportImportUseCase(t)
+	importFixture(t, uc, ReportMapping{})
+
+	result := importFixture(t, uc, ReportMapping{})
+	if result.Created != 0 || len(result.Duplicates) != 4 {
+		t.Errorf("expected every work to be a duplicate, got %d created and %q", result.Created, result.Duplicates)
+	}
+}
+
+func TestReportImportUseCase_ImportConflicts(t *testing.T) {
+	uc, chronoWorkUC := newReportImportUseCase(t)
+	report := "Project,Description,Start date,Start time,End date,End time,Tags\n" +
+		"web,Review,2024-05-06,09:00:00,2024-05-06,10:00:00,bug\n" +
+		"web,Review,2024-05-06,11:00:00,2024-05-06,11:30:00,meeting\n" +
+		",Review,2024-05-06,13:00:00,2024-05-06,13:15:00,\n"
+
+	result, err := uc.Import(strings.NewReader(report), timereport.Toggl, time.Time{}, time.Time{}, ReportMapping{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	expected := []string{
+		`line 3: project "web" tag "meeting" on 2024/05/06 is added to the work on project "web" tag "bug"`,
+		`line 4: no project on 2024/05/06 is added to the work on project "web" tag "bug"`,
+	}
+	if result.Created != 1 || len(result.Duplicates) != 0 || !reflect.DeepEqual(result.Conflicts, expected) {
+		t.Errorf("expected one work and the conflicts reported, got %+v", result)
+	}
+	works, _ := chronoWorkUC.GetAll("id", 0)
+	if len(works) != 1 || works[0].TagID != 1 || works[0].TotalSeconds != 6300 {
+		t.Errorf("expected the time of every entry on the work of the first, got %+v", works)
+	}
+}
+
+func TestReportImportUseCase_ImportRange(t *testing.T) {
+	uc, _ := newReportImportUseCase(t)
+	report := "Project,Description,Start date,Start time,End date,End time\n" +
+		"web,Before,2024-05-05,09:00:00,2024-05-05,10:00:00\n" +
+		"web,Inside,2024-05-06,09:00:00,2024-05-06,10:00:00\n" +
+		"web,Running,2024-05-06,11:00:00,,\n"
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.Local)
+
+	result, err := uc.Import(strings.NewReader(report), timereport.Toggl, start, time.Time{}, ReportMapping{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Created != 1 || !reflect.DeepEqual(result.Skipped, []string{"line 4: running or empty entry"}) {
+		t.Errorf("expected one work and the running entry to be skipped, got %d and %q", result.Created, result.Skipped)
+	}
+}
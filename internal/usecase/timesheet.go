@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+)
+
+// TimesheetRow is the tracked time of a title, project and tag on each day of
+// a week, from Monday.
+type TimesheetRow struct {
+	Title         string
+	ProjectTypeID uint
+	TagID         uint
+	ProjectName   string
+	TagName       string
+	Seconds       [7]int
+	// WorkIDs are the works of each day. Titles are unique within a day, so a
+	// day has at most one work unless the data was imported.
+	WorkIDs [7][]uint
+}
+
+// Total returns the tracked time of the row in the week.
+func (r TimesheetRow) Total() int {
+	total := 0
+	for _, seconds := range r.Seconds {
+		total += seconds
+	}
+	return total
+}
+
+// Timesheet is the tracked time of a week by title, project and tag.
+type Timesheet struct {
+	// Start is the Monday of the week.
+	Start time.Time
+	Rows  []TimesheetRow
+}
+
+// DayTotal returns the tracked time of a day of the week, 0 being Monday.
+func (t Timesheet) DayTotal(day int) int {
+	total := 0
+	for _, row := range t.Rows {
+		total += row.Seconds[day]
+	}
+	return total
+}
+
+// Total returns the tracked time of the week.
+func (t Timesheet) Total() int {
+	total := 0
+	for _, row := range t.Rows {
+		total += row.Total()
+	}
+	return total
+}
+
+// Date returns the date of a day of the week, 0 being Monday.
+func (t Timesheet) Date(day int) time.Time {
+	return t.Start.AddDate(0, 0, day)
+}
+
+// Timesheet returns the timesheet of the week of date, with a row per title,
+// project and tag ordered by project, tag and title.
+func (uc *ChronoWorkUseCase) Timesheet(date time.Time) (Timesheet, error) {
+	sheet := Timesheet{Start: timeutil.StartOfWeek(date)}
+	chronoWorks, err := uc.repo.FindInRange(sheet.Start, timeutil.EndOfDay(sheet.Date(6)))
+	if err != nil {
+		return sheet, err
+	}
+
+	type rowKey struct {
+		title                string
+		projectTypeID, tagID uint
+	}
+	indexes := make(map[rowKey]int)
+	for _, cw := range chronoWorks {
+		key := rowKey{cw.Title, cw.ProjectTypeID, cw.TagID}
+		i, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(sheet.Rows)
+			sheet.Rows = append(sheet.Rows, TimesheetRow{Title: cw.Title, ProjectTypeID: cw.ProjectTypeID, TagID: cw.TagID})
+			i = len(sheet.Rows) - 1
+		}
+		if cw.ProjectType != nil {
+			sheet.Rows[i].ProjectName = cw.ProjectType.Name
+		}
+		if cw.Tag != nil {
+			sheet.Rows[i].TagName = cw.Tag.Name
+		}
+		day := (int(cw.CreatedAt.Local().Weekday()) + 6) % 7
+		sheet.Rows[i].Seconds[day] += cw.TotalSeconds
+		sheet.Rows[i].WorkIDs[day] = append(sheet.Rows[i].WorkIDs[day], cw.ID)
+	}
+
+	sort.SliceStable(sheet.Rows, func(i, j int) bool {
+		a, b := sheet.Rows[i], sheet.Rows[j]
+		if a.ProjectName != b.ProjectName {
+			return a.ProjectName < b.ProjectName
+		}
+		if a.TagName != b.TagName {
+			return a.TagName < b.TagName
+		}
+		return a.Title < b.Title
+	})
+	return sheet, nil
+}
+
+// SetTimesheetTime sets the tracked time of a row on a day of the week of the
+// timesheet, 0 being Monday. A work is created on the day if it has none.
+func (uc *ChronoWorkUseCase) SetTimesheetTime(sheet Timesheet, row TimesheetRow, day int, seconds int) error {
+	if day < 0 || day > 6 {
+		return NewValidationError("invalid day of the week")
+	}
+	if seconds < 0 {
+		return NewValidationError("time must not be negative")
+	}
+
+	switch ids := row.WorkIDs[day]; len(ids) {
+	case 0:
+		if seconds == 0 {
+			return nil
+		}
+		return uc.transaction(func(tx *ChronoWorkUseCase) error {
+			chronoWork, err := tx.CreateOnDate(row.Title, row.ProjectTypeID, row.TagID, sheet.Date(day))
+			if err != nil {
+				return err
+			}
+			return tx.UpdateTotalSeconds(chronoWork.ID, seconds)
+		})
+	case 1:
+		chronoWork, err := uc.repo.FindByID(ids[0])
+		if err != nil {
+			return err
+		}
+		if chronoWork.IsTracking {
+			return NewValidationError("stop tracking the work before changing its time")
+		}
+		return uc.UpdateTotalSeconds(chronoWork.ID, seconds)
+	default:
+		return NewValidationError("the day has several works with this title; change them in the work table")
+	}
+}
+
+// ParseTimesheetTime parses a time of a timesheet cell such as "1:30", "1h30m",
+// "1.5h" or "90" (minutes). An empty text is no time.
+func ParseTimesheetTime(text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	if hours, minutes, ok := strings.Cut(text, ":"); ok {
+		h, err1 := strconv.Atoi(hours)
+		m, err2 := strconv.Atoi(minutes)
+		if err1 != nil || err2 != nil || h < 0 || m < 0 || m > 59 {
+			return 0, NewValidationErrorf("invalid time: %s", text)
+		}
+		return h*3600 + m*60, nil
+	}
+	var duration time.Duration
+	if minutes, err := strconv.Atoi(text); err == nil {
+		duration = time.Duration(minutes) * time.Minute
+	} else if duration, err = time.ParseDuration(text); err != nil {
+		return 0, NewValidationErrorf("invalid time: %s", text)
+	}
+	if duration < 0 {
+		return 0, NewValidationError("time must not be negative")
+	}
+	return int(duration.Seconds()), nil
+}
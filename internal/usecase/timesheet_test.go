@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func TestChronoWorkUseCase_Timesheet(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), mock.NewSettingRepository())
+
+	monday := time.Date(2024, 4, 29, 9, 0, 0, 0, time.Local)
+	web := &domain.ProjectType{ID: 1, Name: "web"}
+	create := func(title string, project *domain.ProjectType, day, seconds int) {
+		var projectTypeID uint
+		if project != nil {
+			projectTypeID = project.ID
+		}
+		cw, _ := repo.CreateAt(title, projectTypeID, 0, monday.AddDate(0, 0, day))
+		cw.ProjectType = project
+		repo.UpdateTotalSeconds(cw.ID, seconds)
+	}
+	create("Review", web, 0, 3600)
+	create("Review", web, 2, 1800)
+	create("Review", nil, 1, 600)
+	create("Standup", web, 0, 900)
+	create("Next week", web, 7, 900)
+
+	sheet, err := uc.Timesheet(monday.AddDate(0, 0, 3))
+	if err != nil {
+		t.Fatalf("Timesheet failed: %v", err)
+	}
+	if !sheet.Start.Equal(monday.Add(-9 * time.Hour)) {
+		t.Errorf("expected the week to start on %v, got %v", monday, sheet.Start)
+	}
+	if len(sheet.Rows) != 3 {
+		t.Fatalf("expected 3 rows, got %+v", sheet.Rows)
+	}
+	if row := sheet.Rows[0]; row.ProjectName != "" || row.Seconds[1] != 600 {
+		t.Errorf("expected the row without project first, got %+v", row)
+	}
+	if row := sheet.Rows[1]; row.Title != "Review" || row.Seconds[0] != 3600 || row.Seconds[2] != 1800 || row.Total() != 5400 {
+		t.Errorf("unexpected Review row %+v", row)
+	}
+	if sheet.DayTotal(0) != 4500 || sheet.Total() != 6900 {
+		t.Errorf("expected totals 4500 and 6900, got %d and %d", sheet.DayTotal(0), sheet.Total())
+	}
+
+	// change an existing day and fill an empty one
+	review := sheet.Rows[1]
+	if err := uc.SetTimesheetTime(sheet, review, 0, 7200); err != nil {
+		t.Fatalf("SetTimesheetTime failed: %v", err)
+	}
+	if err := uc.SetTimesheetTime(sheet, review, 4, 1200); err != nil {
+		t.Fatalf("SetTimesheetTime failed: %v", err)
+	}
+	sheet, _ = uc.Timesheet(monday)
+	if len(sheet.Rows) != 3 {
+		t.Fatalf("expected the new work in the Review row, got %+v", sheet.Rows)
+	}
+	if row := sheet.Rows[1]; row.Seconds[0] != 7200 || row.Seconds[4] != 1200 || len(row.WorkIDs[4]) != 1 {
+		t.Errorf("expected updated Review row, got %+v", row)
+	}
+
+	if err := uc.SetTimesheetTime(sheet, review, 0, -1); err == nil {
+		t.Error("expected error for negative time")
+	}
+	repo.StartTracking(sheet.Rows[1].WorkIDs[0][0])
+	if err := uc.SetTimesheetTime(sheet, sheet.Rows[1], 0, 60); err == nil {
+		t.Error("expected error for a tracking work")
+	}
+}
+
+func TestParseTimesheetTime(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"1:30", 5400, false},
+		{"0:05", 300, false},
+		{"90", 5400, false},
+		{"1h30m", 5400, false},
+		{"1.5h", 5400, false},
+		{"1:75", 0, true},
+		{"-10m", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimesheetTime(tt.text)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseTimesheetTime(%q) = %d, %v; want %d, error %v", tt.text, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+	"github.com/niiharamegumu/chronowork/util/timew"
+)
+
+// TimewIntervals returns the finished tracking intervals overlapping the range
+// as Timewarrior intervals. The tags are the project and tag of the work and
+// the annotation is its title.
+func (uc *ChronoWorkUseCase) TimewIntervals(startTime, endTime time.Time) ([]timew.Interval, error) {
+	intervals, err := uc.repo.FindIntervalsInRange(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []timew.Interval
+	for _, interval := range intervals {
+		if interval.IsOpen() {
+			continue
+		}
+		cw, err := uc.repo.FindByID(interval.ChronoWorkID)
+		if err != nil {
+			// the intervals of deleted works are not exported
+			continue
+		}
+		ti := timew.Interval{
+			Start:      interval.StartTime,
+			End:        interval.EndTime,
+			Annotation: cw.Title,
+		}
+		if cw.ProjectType != nil {
+			ti.Tags = append(ti.Tags, cw.ProjectType.Name)
+		}
+		if cw.Tag != nil {
+			ti.Tags = append(ti.Tags, cw.Tag.Name)
+		}
+		result = append(result, ti)
+	}
+	return result, nil
+}
+
+// ExportTimew writes the finished tracking intervals overlapping the range as
+// Timewarrior data files to a new directory in the download path. It returns
+// the path of the directory and the number of intervals, and writes nothing
+// when there are no intervals.
+func (uc *ChronoWorkUseCase) ExportTimew(startTime, endTime, now time.Time) (string, int, error) {
+	intervals, err := uc.TimewIntervals(startTime, endTime)
+	if err != nil || len(intervals) == 0 {
+		return "", 0, err
+	}
+	setting, err := uc.settingRepo.Get()
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := os.Stat(setting.DownloadPath); err != nil {
+		return "", 0, err
+	}
+
+	dir := filepath.Join(setting.DownloadPath, fmt.Sprintf("timewarrior_%s", now.Format("20060102150405")))
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return "", 0, err
+	}
+	if _, err := timew.WriteDataFiles(dir, intervals); err != nil {
+		return "", 0, err
+	}
+	return dir, len(intervals), nil
+}
+
+// TimewImportResult is the outcome of a Timewarrior import.
+type TimewImportResult struct {
+	// Created is the number of works created.
+	Created int
+	// Skipped has a reason for each interval or work that was not imported.
+	Skipped []string
+}
+
+// TimewImportUseCase creates works from Timewarrior intervals.
+type TimewImportUseCase struct {
+	chronoWorkUC  *ChronoWorkUseCase
+	projectTypeUC *ProjectTypeUseCase
+}
+
+// NewTimewImportUseCase creates a new TimewImportUseCase.
+func NewTimewImportUseCase(chronoWorkUC *ChronoWorkUseCase, projectTypeUC *ProjectTypeUseCase) *TimewImportUseCase {
+	return &TimewImportUseCase{
+		chronoWorkUC:  chronoWorkUC,
+		projectTypeUC: projectTypeUC,
+	}
+}
+
+// Import creates a work with its time and tracking intervals for each title,
+// project and tag of a day of the intervals starting in the range. A zero start
+// or end leaves that side of the range open. The project is the first tag that
+// names a project and the tag the first other tag allowed on it. The title is
+// the annotation, or else the other tags, or else the project. Intervals still
+// being tracked and the works that already exist are skipped.
+func (uc *TimewImportUseCase) Import(intervals []timew.Interval, startTime, endTime time.Time) (*TimewImportResult, error) {
+	result := &TimewImportResult{}
+	projects := map[string]*domain.ProjectType{}
+	works := map[string]*importedWork{}
+	for _, interval := range intervals {
+		if (!startTime.IsZero() && interval.Start.Before(startTime)) || (!endTime.IsZero() && interval.Start.After(endTime)) {
+			continue
+		}
+		skip := func(reason string) {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %s", interval.Start.Format("2006/01/02 15:04"), reason))
+		}
+		if interval.IsOpen() {
+			skip("still being tracked")
+			continue
+		}
+		if !interval.End.After(interval.Start) {
+			skip("no duration")
+			continue
+		}
+
+		work := &importedWork{date: timeutil.StartOfDay(interval.Start)}
+		var projectType *domain.ProjectType
+		var rest []string
+		for _, tag := range interval.Tags {
+			if projectType == nil {
+				if projectType = findProject(uc.projectTypeUC, projects, tag); projectType != nil {
+					work.projectTypeID = projectType.ID
+					continue
+				}
+			}
+			rest = append(rest, tag)
+		}
+		if projectType != nil {
+			for i, tag := range rest {
+				if tagID, ok := findTagID(projectType, tag); ok {
+					work.tagID = tagID
+					rest = append(rest[:i:i], rest[i+1:]...)
+					break
+				}
+			}
+		}
+		switch {
+		case strings.TrimSpace(interval.Annotation) != "":
+			work.title = strings.TrimSpace(interval.Annotation)
+		case len(rest) > 0:
+			work.title = strings.Join(rest, " ")
+		case projectType != nil:
+			work.title = projectType.Name
+		default:
+			skip("no annotation or tags")
+			continue
+		}
+
+		key := fmt.Sprintf("%s\x00%s\x00%d\x00%d", work.date.Format("20060102"), work.title, work.projectTypeID, work.tagID)
+		if existing, ok := works[key]; ok {
+			work = existing
+		} else {
+			works[key] = work
+		}
+		work.add(interval.Start, interval.End)
+	}
+
+	sorted := make([]*importedWork, 0, len(works))
+	for _, work := range works {
+		sorted = append(sorted, work)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].intervals[0].StartTime.Before(sorted[j].intervals[0].StartTime)
+	})
+
+	for _, work := range sorted {
+		skip, err := createImportedWork(uc.chronoWorkUC, work)
+		if err != nil {
+			return result, err
+		}
+		if skip != nil {
+			result.Skipped = append(result.Skipped, work.reason(skip))
+			continue
+		}
+		result.Created++
+	}
+	return result, nil
+}
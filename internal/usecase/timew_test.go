@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+	"github.com/niiharamegumu/chronowork/util/timew"
+)
+
+func TestChronoWorkUseCase_ExportTimew(t *testing.T) {
+	repo := mock.NewChronoWorkRepository()
+	settingRepo := mock.NewSettingRepository()
+	uc := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), settingRepo)
+
+	setting, _ := settingRepo.Get()
+	setting.DownloadPath = t.TempDir()
+	settingRepo.Update(setting)
+
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	review, _ := repo.CreateAt("Review", 1, 2, start)
+	review.ProjectType = &domain.ProjectType{ID: 1, Name: "web"}
+	review.Tag = &domain.Tag{ID: 2, Name: "review"}
+	repo.AddInterval(review.ID, start, start.Add(30*time.Minute))
+	repo.AddInterval(review.ID, start.Add(4*time.Hour), time.Time{})
+
+	dir, count, err := uc.ExportTimew(start.Add(-time.Hour), start.Add(12*time.Hour), start)
+	if err != nil {
+		t.Fatalf("ExportTimew failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected the finished interval to be exported, got %d", count)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "2024-05.data"))
+	if err != nil {
+		t.Fatalf("failed to read the data file: %v", err)
+	}
+	expected := `inc 20240501T090000Z - 20240501T093000Z # web review # "Review"` + "\n"
+	if string(data) != expected {
+		t.Errorf("data file = %q, want %q", data, expected)
+	}
+
+	dir, count, err = uc.ExportTimew(start.AddDate(0, 1, 0), start.AddDate(0, 1, 1), start)
+	if err != nil || count != 0 || dir != "" {
+		t.Errorf("expected nothing exported for an empty range, got %q, %d, %v", dir, count, err)
+	}
+}
+
+func newTimewImportUseCase(t *testing.T) (*TimewImportUseCase, *mock.ChronoWorkRepository) {
+	t.Helper()
+	tagRepo := mock.NewTagRepository()
+	tagUC := NewTagUseCase(tagRepo)
+	bug, _ := tagUC.Create("bug")
+
+	settingRepo := mock.NewSettingRepository()
+	projectTypeUC := NewProjectTypeUseCase(mock.NewProjectTypeRepository(tagRepo), tagRepo, settingRepo)
+	projectTypeUC.Create("web", []uint{bug.ID})
+
+	repo := mock.NewChronoWorkRepository()
+	chronoWorkUC := NewChronoWorkUseCase(repo, mock.NewAuditLogRepository(), settingRepo)
+	return NewTimewImportUseCase(chronoWorkUC, projectTypeUC), repo
+}
+
+func TestTimewImportUseCase_Import(t *testing.T) {
+	uc, repo := newTimewImportUseCase(t)
+	intervals, err := timew.ReadPath("../../util/timew/testdata/2024-05.data")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := uc.Import(intervals, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Created != 3 {
+		t.Errorf("expected 3 works, got %d", result.Created)
+	}
+	if len(result.Skipped) != 1 || !strings.HasSuffix(result.Skipped[0], "still being tracked") {
+		t.Errorf("expected the open interval to be skipped, got %q", result.Skipped)
+	}
+
+	works, _ := repo.GetAll("id", 0)
+	got := map[string][3]int{}
+	for _, work := range works {
+		got[work.Title] = [3]int{int(work.ProjectTypeID), int(work.TagID), work.TotalSeconds}
+	}
+	expected := map[string][3]int{
+		"Fix login bug":          {1, 1, 5400},
+		"team sync meeting":      {0, 0, 1800},
+		`Reply to "urgent" mail`: {0, 0, 3600},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("works = %v, want %v", got, expected)
+	}
+
+	recorded, _ := repo.FindIntervalsInRange(intervals[0].Start, intervals[2].End)
+	if len(recorded) != 3 || !recorded[0].StartTime.Equal(intervals[0].Start) || !recorded[0].EndTime.Equal(intervals[0].End) {
+		t.Errorf("expected the intervals to be recorded, got %+v", recorded)
+	}
+
+	again, err := uc.Import(intervals, time.Time{}, time.Time{})
+	if err != nil || again.Created != 0 || len(again.Skipped) != 4 {
+		t.Errorf("expected the existing works to be skipped, got %+v, %v", again, err)
+	}
+}
+
+func TestTimewImportUseCase_ImportTitleFromTags(t *testing.T) {
+	uc, repo := newTimewImportUseCase(t)
+	start := time.Date(2024, 5, 6, 9, 0, 0, 0, time.Local)
+	intervals := []timew.Interval{
+		{Start: start, End: start.Add(time.Hour), Tags: []string{"bug", "web"}},
+		{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour), Tags: []string{"web", "deploy", "staging"}},
+		{Start: start.Add(4 * time.Hour), End: start.Add(5 * time.Hour)},
+	}
+
+	result, err := uc.Import(intervals, start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Created != 2 || len(result.Skipped) != 1 {
+		t.Errorf("expected 2 works and the untagged interval to be skipped, got %+v", result)
+	}
+	works, _ := repo.GetAll("id", 0)
+	titles := []string{}
+	for _, work := range works {
+		titles = append(titles, work.Title)
+	}
+	sort.Strings(titles)
+	// "bug" is a tag of the project web, so that work is titled after the project
+	if !reflect.DeepEqual(titles, []string{"deploy staging", "web"}) {
+		t.Errorf("titles = %q", titles)
+	}
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+)
+
+// WorkFilter narrows a list of works by title, project, tag and confirmed state.
+// Text conditions are case-insensitive substring matches; empty conditions match everything.
+type WorkFilter struct {
+	Title     string
+	Project   string
+	Tag       string
+	Confirmed *bool
+}
+
+// ParseWorkFilter parses a search query such as "login @web #bug is:unconfirmed".
+// Plain words match the title, "@" prefixes the project, "#" prefixes the tag,
+// and "is:confirmed" / "is:unconfirmed" select the confirmed state.
+func ParseWorkFilter(query string) WorkFilter {
+	var f WorkFilter
+	var titleWords []string
+	for _, word := range strings.Fields(query) {
+		switch {
+		case strings.HasPrefix(word, "@") && len(word) > 1:
+			f.Project = word[1:]
+		case strings.HasPrefix(word, "#") && len(word) > 1:
+			f.Tag = word[1:]
+		case word == "is:confirmed":
+			confirmed := true
+			f.Confirmed = &confirmed
+		case word == "is:unconfirmed":
+			confirmed := false
+			f.Confirmed = &confirmed
+		default:
+			titleWords = append(titleWords, word)
+		}
+	}
+	f.Title = strings.Join(titleWords, " ")
+	return f
+}
+
+// IsEmpty reports whether the filter has no conditions.
+func (f WorkFilter) IsEmpty() bool {
+	return f.Title == "" && f.Project == "" && f.Tag == "" && f.Confirmed == nil
+}
+
+// Match reports whether the work satisfies every condition of the filter.
+func (f WorkFilter) Match(cw domain.ChronoWork) bool {
+	if !containsFold(cw.Title, f.Title) {
+		return false
+	}
+	if f.Project != "" {
+		if cw.ProjectType == nil || !containsFold(cw.ProjectType.Name, f.Project) {
+			return false
+		}
+	}
+	if f.Tag != "" {
+		if cw.Tag == nil || !containsFold(cw.Tag.Name, f.Tag) {
+			return false
+		}
+	}
+	if f.Confirmed != nil && cw.Confirmed != *f.Confirmed {
+		return false
+	}
+	return true
+}
+
+// Apply returns the works that match the filter, keeping their order.
+func (f WorkFilter) Apply(chronoWorks []domain.ChronoWork) []domain.ChronoWork {
+	if f.IsEmpty() {
+		return chronoWorks
+	}
+	var result []domain.ChronoWork
+	for _, cw := range chronoWorks {
+		if f.Match(cw) {
+			result = append(result, cw)
+		}
+	}
+	return result
+}
+
+// containsFold reports whether substr is within s, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+)
+
+func TestParseWorkFilter(t *testing.T) {
+	f := ParseWorkFilter("fix login @Web #bug is:unconfirmed")
+
+	if f.Title != "fix login" {
+		t.Errorf("expected title 'fix login', got '%s'", f.Title)
+	}
+	if f.Project != "Web" {
+		t.Errorf("expected project 'Web', got '%s'", f.Project)
+	}
+	if f.Tag != "bug" {
+		t.Errorf("expected tag 'bug', got '%s'", f.Tag)
+	}
+	if f.Confirmed == nil || *f.Confirmed {
+		t.Error("expected unconfirmed condition")
+	}
+
+	if !ParseWorkFilter("   ").IsEmpty() {
+		t.Error("expected blank query to be empty")
+	}
+}
+
+func TestWorkFilter_Apply(t *testing.T) {
+	web := &domain.ProjectType{ID: 1, Name: "Website"}
+	bug := &domain.Tag{ID: 1, Name: "Bug"}
+	works := []domain.ChronoWork{
+		{ID: 1, Title: "Fix login", ProjectType: web, Tag: bug},
+		{ID: 2, Title: "Fix logout", ProjectType: web, Confirmed: true},
+		{ID: 3, Title: "Write docs"},
+	}
+
+	tests := []struct {
+		query    string
+		expected []uint
+	}{
+		{"", []uint{1, 2, 3}},
+		{"fix", []uint{1, 2}},
+		{"LOGIN", []uint{1}},
+		{"@web", []uint{1, 2}},
+		{"#bug", []uint{1}},
+		{"is:confirmed", []uint{2}},
+		{"fix is:unconfirmed", []uint{1}},
+		{"@nothing", nil},
+	}
+
+	for _, tc := range tests {
+		result := ParseWorkFilter(tc.query).Apply(works)
+		if len(result) != len(tc.expected) {
+			t.Errorf("query %q: expected %d works, got %d", tc.query, len(tc.expected), len(result))
+			continue
+		}
+		for i, id := range tc.expected {
+			if result[i].ID != id {
+				t.Errorf("query %q: expected ID %d at %d, got %d", tc.query, id, i, result[i].ID)
+			}
+		}
+	}
+}
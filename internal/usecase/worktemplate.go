@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+)
+
+// WorkTemplateUseCase handles business logic for recurring work templates.
+type WorkTemplateUseCase struct {
+	repo          repository.WorkTemplateRepository
+	chronoWorkUC  *ChronoWorkUseCase
+	projectTypeUC *ProjectTypeUseCase
+}
+
+// NewWorkTemplateUseCase creates a new WorkTemplateUseCase.
+func NewWorkTemplateUseCase(repo repository.WorkTemplateRepository, chronoWorkUC *ChronoWorkUseCase, projectTypeUC *ProjectTypeUseCase) *WorkTemplateUseCase {
+	return &WorkTemplateUseCase{
+		repo:          repo,
+		chronoWorkUC:  chronoWorkUC,
+		projectTypeUC: projectTypeUC,
+	}
+}
+
+// Create validates and creates a new WorkTemplate.
+func (uc *WorkTemplateUseCase) Create(template *domain.WorkTemplate) error {
+	if err := uc.validate(template); err != nil {
+		return err
+	}
+	return uc.repo.Create(template)
+}
+
+// FindByID finds a WorkTemplate by its ID.
+func (uc *WorkTemplateUseCase) FindByID(id uint) (*domain.WorkTemplate, error) {
+	return uc.repo.FindByID(id)
+}
+
+// FindAll finds all WorkTemplates.
+func (uc *WorkTemplateUseCase) FindAll() ([]domain.WorkTemplate, error) {
+	return uc.repo.FindAll()
+}
+
+// Update validates and updates a WorkTemplate.
+func (uc *WorkTemplateUseCase) Update(template *domain.WorkTemplate) error {
+	if err := uc.validate(template); err != nil {
+		return err
+	}
+	return uc.repo.Update(template)
+}
+
+// Delete permanently deletes a WorkTemplate.
+func (uc *WorkTemplateUseCase) Delete(id uint) error {
+	return uc.repo.Delete(id)
+}
+
+// Materialize creates the works of the templates whose recurrence matches today.
+// A template creates at most one work per day, and is skipped when a work with
+// its title already exists today. It returns the number of created works.
+func (uc *WorkTemplateUseCase) Materialize(now time.Time) (int, error) {
+	templates, err := uc.repo.FindAll()
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, template := range templates {
+		recurrence, err := ParseRecurrence(template.Recurrence)
+		if err != nil || !recurrence.Matches(now) || timeutil.IsSameDay(template.LastCreatedOn, now) {
+			continue
+		}
+		_, err = uc.chronoWorkUC.Create(template.Title, template.ProjectTypeID, template.TagID)
+		var ucErr *UseCaseError
+		if err != nil && !(errors.As(err, &ucErr) && ucErr.Code == ErrCodeDuplicateToday) {
+			return created, err
+		}
+		if err == nil {
+			created++
+		}
+		if err := uc.repo.UpdateLastCreatedOn(template.ID, now); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// validate normalizes the title and recurrence, and checks that the tag is
+// allowed on the project.
+func (uc *WorkTemplateUseCase) validate(template *domain.WorkTemplate) error {
+	template.Title = strings.TrimSpace(template.Title)
+	if template.Title == "" {
+		return NewValidationError("title is empty")
+	}
+	if template.EstimateSeconds < 0 {
+		return NewValidationError("estimate must not be negative")
+	}
+	recurrence, err := ParseRecurrence(template.Recurrence)
+	if err != nil {
+		return err
+	}
+	template.Recurrence = recurrence.String()
+
+	if template.ProjectTypeID == 0 {
+		if template.TagID != 0 {
+			return NewValidationError("tag requires a project")
+		}
+		return nil
+	}
+	projectType, err := uc.projectTypeUC.FindByID(template.ProjectTypeID)
+	if err != nil {
+		return NewNotFoundError("project type not found")
+	}
+	if template.TagID != 0 {
+		for _, tag := range projectType.Tags {
+			if tag.ID == template.TagID {
+				return nil
+			}
+		}
+		return NewValidationErrorf("tag is not allowed on project %s", projectType.Name)
+	}
+	return nil
+}
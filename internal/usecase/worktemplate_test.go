@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/repository/mock"
+)
+
+func newWorkTemplateUseCase(t *testing.T) (*WorkTemplateUseCase, *ChronoWorkUseCase, *domain.ProjectType) {
+	t.Helper()
+	tagRepo := mock.NewTagRepository()
+	tagUC := NewTagUseCase(tagRepo)
+	review, _ := tagUC.Create("review")
+	tagUC.Create("ops")
+
+	settingRepo := mock.NewSettingRepository()
+	projectTypeUC := NewProjectTypeUseCase(mock.NewProjectTypeRepository(tagRepo), tagRepo, settingRepo)
+	project, _ := projectTypeUC.Create("web", []uint{review.ID})
+
+	chronoWorkUC := NewChronoWorkUseCase(mock.NewChronoWorkRepository(), mock.NewAuditLogRepository(), settingRepo)
+	return NewWorkTemplateUseCase(mock.NewWorkTemplateRepository(), chronoWorkUC, projectTypeUC), chronoWorkUC, project
+}
+
+func TestWorkTemplateUseCase_CreateValidates(t *testing.T) {
+	uc, _, project := newWorkTemplateUseCase(t)
+
+	template := &domain.WorkTemplate{Title: " Code review ", ProjectTypeID: project.ID, TagID: project.Tags[0].ID, Recurrence: "Mon,Tue,Wed,Thu,Fri"}
+	if err := uc.Create(template); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if template.Title != "Code review" || template.Recurrence != "weekdays" {
+		t.Errorf("expected normalized template, got %+v", *template)
+	}
+
+	invalid := []*domain.WorkTemplate{
+		{Title: "", Recurrence: "daily"},
+		{Title: "No rule"},
+		{Title: "Bad rule", Recurrence: "sometimes"},
+		{Title: "Tag only", TagID: project.Tags[0].ID, Recurrence: "daily"},
+		{Title: "Unknown project", ProjectTypeID: 99, Recurrence: "daily"},
+		{Title: "Foreign tag", ProjectTypeID: project.ID, TagID: 2, Recurrence: "daily"},
+		{Title: "Negative", Recurrence: "daily", EstimateSeconds: -1},
+	}
+	for _, template := range invalid {
+		if err := uc.Create(template); err == nil {
+			t.Errorf("expected error for %+v", *template)
+		}
+	}
+}
+
+func TestWorkTemplateUseCase_Materialize(t *testing.T) {
+	uc, chronoWorkUC, project := newWorkTemplateUseCase(t)
+
+	now := time.Now()
+	uc.Create(&domain.WorkTemplate{Title: "Standup", ProjectTypeID: project.ID, Recurrence: "daily"})
+	uc.Create(&domain.WorkTemplate{Title: "Code review", ProjectTypeID: project.ID, TagID: project.Tags[0].ID, Recurrence: "daily"})
+	uc.Create(&domain.WorkTemplate{Title: "Not today", Recurrence: weekdayNames[now.AddDate(0, 0, 1).Weekday()]})
+	chronoWorkUC.Create("Code review", 0, 0)
+
+	created, err := uc.Materialize(now)
+	if err != nil {
+		t.Fatalf("Materialize failed: %v", err)
+	}
+	if created != 1 {
+		t.Errorf("expected 1 created work, got %d", created)
+	}
+	if existing, _ := chronoWorkUC.repo.FindByTitleToday("Standup"); existing == nil || existing.ProjectTypeID != project.ID {
+		t.Errorf("expected Standup to be created for project %d, got %+v", project.ID, existing)
+	}
+	if existing, _ := chronoWorkUC.repo.FindByTitleToday("Not today"); existing != nil {
+		t.Error("expected no work for a template not recurring today")
+	}
+
+	// running again on the same day creates nothing, even after deleting the work
+	standup, _ := chronoWorkUC.repo.FindByTitleToday("Standup")
+	chronoWorkUC.Delete(standup.ID)
+	created, err = uc.Materialize(now)
+	if err != nil {
+		t.Fatalf("Materialize failed: %v", err)
+	}
+	if created != 0 {
+		t.Errorf("expected no work on the second run, got %d", created)
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// AuditLog is append-only: it has no UpdatedAt/DeletedAt and is never modified.
+type AuditLog struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	WorkID    uint      `gorm:"index; not null" json:"work_id"`
+	Action    string    `gorm:"size:64; not null" json:"action"`
+	Actor     string    `gorm:"size:255" json:"actor"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,15 +1,21 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type Setting struct {
 	gorm.Model
-	RelativeDate       uint   `gorm:"default:0" json:"relative_date"`
-	PersonDay          uint   `gorm:"default:8" json:"person_day"`
-	DisplayAsPersonDay bool   `gorm:"default:1" json:"display_as_person_day"`
-	DownloadPath       string `gorm:"default:./" json:"download_path"`
+	RelativeDate       uint      `gorm:"default:0" json:"relative_date"`
+	PersonDay          uint      `gorm:"default:8" json:"person_day"`
+	DisplayAsPersonDay bool      `gorm:"default:1" json:"display_as_person_day"`
+	DownloadPath       string    `gorm:"default:./" json:"download_path"`
+	Theme              string    `gorm:"default:default" json:"theme"`
+	Language           string    `gorm:"default:auto" json:"language"`
+	AllowTagCreation   bool      `gorm:"default:0" json:"allow_tag_creation"`
+	ClosedUntil        time.Time `json:"closed_until"`
 }
 
 func (s *Setting) GetSetting(db *gorm.DB) error {
@@ -25,12 +31,18 @@ func (s *Setting) UpdateSetting(db *gorm.DB, setting Setting) error {
 		"person_day":            setting.PersonDay,
 		"display_as_person_day": setting.DisplayAsPersonDay,
 		"download_path":         setting.DownloadPath,
+		"theme":                 setting.Theme,
+		"language":              setting.Language,
+		"allow_tag_creation":    setting.AllowTagCreation,
 	}
 	if result := db.Model(s).Select(
 		"relative_date",
 		"person_day",
 		"display_as_person_day",
-		"download_path").
+		"download_path",
+		"theme",
+		"language",
+		"allow_tag_creation").
 		Updates(dataMap); result.Error != nil {
 		return result.Error
 	}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WorkInterval is a period during which a ChronoWork was tracked.
+// EndTime is zero while the interval is still being tracked.
+type WorkInterval struct {
+	gorm.Model
+	ChronoWorkID uint      `gorm:"index; not null" json:"chrono_work_id"`
+	StartTime    time.Time `gorm:"index" json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+}
+
+// BackfillWorkIntervals creates an interval from the last tracked start and end
+// of every ChronoWork. Earlier intervals were not recorded and cannot be restored.
+func BackfillWorkIntervals(db *gorm.DB) error {
+	var chronoWorks []ChronoWork
+	if err := db.Where("start_time > ?", time.Time{}).Find(&chronoWorks).Error; err != nil {
+		return err
+	}
+	var intervals []WorkInterval
+	for _, chronoWork := range chronoWorks {
+		if !chronoWork.IsTracking && !chronoWork.EndTime.After(chronoWork.StartTime) {
+			continue
+		}
+		interval := WorkInterval{ChronoWorkID: chronoWork.ID, StartTime: chronoWork.StartTime}
+		if !chronoWork.IsTracking {
+			interval.EndTime = chronoWork.EndTime
+		}
+		intervals = append(intervals, interval)
+	}
+	if len(intervals) == 0 {
+		return nil
+	}
+	return db.Create(&intervals).Error
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WorkTemplate struct {
+	gorm.Model
+	Title           string    `gorm:"size:255; required" json:"title"`
+	ProjectTypeID   uint      `json:"project_type_id"`
+	TagID           uint      `json:"tag_id"`
+	EstimateSeconds int       `json:"estimate_seconds"`
+	Recurrence      string    `gorm:"size:64; not null" json:"recurrence"`
+	LastCreatedOn   time.Time `json:"last_created_on"`
+
+	ProjectType ProjectType `gorm:"foreignkey:ProjectTypeID"`
+	Tag         Tag         `gorm:"foreignkey:TagID"`
+}
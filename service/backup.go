@@ -0,0 +1,59 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// defaultBackupKeep is the number of backups kept without a backup config file.
+const defaultBackupKeep = 10
+
+// BackupConfig is where backups are written and how many of them are kept.
+type BackupConfig struct {
+	Dir string `json:"dir"`
+	// Keep is the number of the latest backups kept, or 0 to keep every backup.
+	Keep int `json:"keep"`
+}
+
+// BackupConfigPath returns the path of the backup config file.
+func BackupConfigPath() string {
+	if path := os.Getenv("CHRONOWORK_BACKUP_CONFIG"); path != "" {
+		return path
+	}
+	if rootPath := os.Getenv("CHRONOWORK_ROOT_PATH"); rootPath != "" {
+		return fmt.Sprintf("%s/%s", rootPath, "backup.json")
+	}
+	return fmt.Sprintf("%s/%s", ".", "backup.json")
+}
+
+// LoadBackupConfig returns the backup config of the file at path, or the
+// default config if it does not exist. The default directory is "backups" next
+// to the config file and 10 backups are kept. A relative directory is relative
+// to the config file:
+//
+//	{"dir": "/mnt/backup/chronowork", "keep": 30}
+func LoadBackupConfig(path string) (BackupConfig, error) {
+	config := BackupConfig{Dir: "backups", Keep: defaultBackupKeep}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return config, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return config, fmt.Errorf("backup config %s: %w", path, err)
+		}
+	}
+	if config.Dir == "" {
+		return config, fmt.Errorf("backup config %s: dir must not be empty", path)
+	}
+	if config.Keep < 0 {
+		return config, fmt.Errorf("backup config %s: keep must not be negative, got %d", path, config.Keep)
+	}
+	if !filepath.IsAbs(config.Dir) {
+		config.Dir = filepath.Join(filepath.Dir(path), config.Dir)
+	}
+	return config, nil
+}
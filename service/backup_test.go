@@ -0,0 +1,43 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadBackupConfig(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "backup.json")
+	config, err := LoadBackupConfig(path)
+	if err != nil || config != (BackupConfig{Dir: filepath.Join(root, "backups"), Keep: 10}) {
+		t.Fatalf("expected the default config without a file, got %v, %v", config, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"dir": "/mnt/backup/chronowork", "keep": 0}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err = LoadBackupConfig(path)
+	if err != nil {
+		t.Fatalf("LoadBackupConfig failed: %v", err)
+	}
+	if config != (BackupConfig{Dir: "/mnt/backup/chronowork", Keep: 0}) {
+		t.Errorf("LoadBackupConfig() = %v", config)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"dir": "archive"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if config, _ := LoadBackupConfig(path); config != (BackupConfig{Dir: filepath.Join(root, "archive"), Keep: 10}) {
+		t.Errorf("expected a relative dir and the default keep, got %v", config)
+	}
+
+	for _, invalid := range []string{`{"keep": -1}`, `{"dir": ""}`, `[]`} {
+		if err := os.WriteFile(path, []byte(invalid), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadBackupConfig(path); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"errors"
 
 	"github.com/niiharamegumu/chronowork/internal/usecase"
-	"github.com/rivo/tview"
+	"github.com/niiharamegumu/chronowork/util/i18n"
 )
 
 // ErrorHandler handles error display in TUI.
@@ -17,18 +17,17 @@ func NewErrorHandler(tui *TUI) *ErrorHandler {
 	return &ErrorHandler{tui: tui}
 }
 
-// ShowError displays an error message in a modal dialog.
-// focusTarget is the widget name to focus after closing the modal.
+// ShowError displays an error message in the status bar without blocking the application.
+// focusTarget is the widget name to focus, as the error may come from a closed dialog.
 func (h *ErrorHandler) ShowError(message string, focusTarget string) {
-	modal := tview.NewModal().
-		SetText(message).
-		AddButtons([]string{"OK"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			h.tui.DeleteModal()
-			h.tui.SetFocus(focusTarget)
-		})
-	h.tui.SetModal(modal)
-	h.tui.SetFocus("modal")
+	h.tui.Status.Error("%s", message)
+	h.tui.SetFocus(focusTarget)
+}
+
+// ShowWarning displays a translated warning in the status bar and focuses focusTarget.
+func (h *ErrorHandler) ShowWarning(message string, focusTarget string) {
+	h.tui.Status.Warn("%s", i18n.T(message))
+	h.tui.SetFocus(focusTarget)
 }
 
 // ShowErrorWithErr displays a user-friendly error message based on the error.
@@ -40,30 +39,35 @@ func (h *ErrorHandler) ShowErrorWithErr(err error, focusTarget string) {
 // friendlyMessage converts errors to user-friendly messages.
 func (h *ErrorHandler) friendlyMessage(err error) string {
 	if err == nil {
-		return "不明なエラーが発生しました。"
+		return i18n.T("An unknown error occurred.")
 	}
 
 	// UseCaseErrorを型判定
 	var ucErr *usecase.UseCaseError
 	if errors.As(err, &ucErr) {
+		// 入力エラーは翻訳した原因を添えて表示する
+		if ucErr.Code == usecase.ErrCodeValidation && ucErr.Message != "" {
+			return i18n.T("The input is invalid: %s", i18n.T(ucErr.Message, ucErr.Args...))
+		}
 		return h.getMessageForCode(ucErr.Code)
 	}
 
 	// その他のエラー
-	return "エラーが発生しました: " + err.Error()
+	return i18n.T("An error occurred: %s", err.Error())
 }
 
 // getMessageForCode returns a user-friendly message for the given error code.
 func (h *ErrorHandler) getMessageForCode(code usecase.ErrorCode) string {
 	messages := map[usecase.ErrorCode]string{
-		usecase.ErrCodeNotFound:       "データが見つかりませんでした。",
-		usecase.ErrCodeDuplicateToday: "この作業は今日既に作成されています。",
-		usecase.ErrCodeValidation:     "入力内容に誤りがあります。",
-		usecase.ErrCodePermission:     "権限がありません。",
+		usecase.ErrCodeNotFound:       "The data was not found.",
+		usecase.ErrCodeDuplicateToday: "This work has already been created today.",
+		usecase.ErrCodeDuplicateDate:  "This work has already been created on that date.",
+		usecase.ErrCodeValidation:     "The input is invalid.",
+		usecase.ErrCodePermission:     "Permission denied (confirmed works and closed periods cannot be changed).",
 	}
 
 	if msg, ok := messages[code]; ok {
-		return msg
+		return i18n.T(msg)
 	}
-	return "エラーが発生しました。"
+	return i18n.T("An error occurred.")
 }
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+)
+
+func TestErrorHandler_FriendlyMessage(t *testing.T) {
+	defer i18n.SetLocale(i18n.CurrentLocale())
+	h := NewErrorHandler(NewTUI())
+	err := usecase.NewValidationErrorf("unknown project @%s", "web")
+
+	i18n.SetLocale(i18n.English)
+	if got := h.friendlyMessage(err); got != "The input is invalid: unknown project @web" {
+		t.Errorf("friendlyMessage in English = %q", got)
+	}
+
+	i18n.SetLocale(i18n.Japanese)
+	want := i18n.T("The input is invalid: %s", "プロジェクト @web が見つかりません")
+	if got := h.friendlyMessage(err); got != want {
+		t.Errorf("friendlyMessage in Japanese = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/rivo/tview"
+)
+
+// widgetContexts maps the names of focusable widgets to their key binding context.
+var widgetContexts = map[string]string{
+	"menu":              ContextMenu,
+	"mainWorkContent":   ContextWork,
+	"mainWorkForm":      ContextForm,
+	"projectTable":      ContextProject,
+	"projectForm":       ContextForm,
+	"tagTable":          ContextTag,
+	"tagForm":           ContextForm,
+	"templateTable":     ContextTemplate,
+	"templateForm":      ContextForm,
+	"calendarTable":     ContextCalendar,
+	"timelineView":      ContextTimeline,
+	"timesheetTable":    ContextTimesheet,
+	"timesheetForm":     ContextForm,
+	"auditForm":         ContextForm,
+	"chartForm":         "",
+	"exportForm":        "",
+	"importForm":        "",
+	"settingForm":       "",
+	"notificationTable": "",
+}
+
+// widgetTitles are the headings of the help overlay by widget name.
+var widgetTitles = map[string]string{
+	"menu":              "Menu",
+	"mainWorkContent":   "Works",
+	"mainWorkForm":      "Work form",
+	"projectTable":      "Projects",
+	"projectForm":       "Project form",
+	"tagTable":          "Tags",
+	"tagForm":           "Tag form",
+	"templateTable":     "Templates",
+	"templateForm":      "Template form",
+	"calendarTable":     "Calendar",
+	"timelineView":      "Timeline",
+	"timesheetTable":    "Timesheet",
+	"timesheetForm":     "Timesheet form",
+	"auditForm":         "Audit",
+	"chartForm":         "Charts",
+	"exportForm":        "Export",
+	"importForm":        "Import",
+	"settingForm":       "Setting",
+	"notificationTable": "Notifications",
+}
+
+// FocusedWidget returns the name of the registered widget that has focus or
+// contains the focused primitive, such as a form holding the focused input
+// field, or "" if there is none.
+func (t *TUI) FocusedWidget() string {
+	focused := t.App.GetFocus()
+	if focused == nil {
+		return ""
+	}
+	names := make([]string, 0, len(t.Widgets))
+	for name, widget := range t.Widgets {
+		if widget == focused {
+			return name
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if t.Widgets[name].HasFocus() {
+			return name
+		}
+	}
+	return ""
+}
+
+// HelpText returns the shortcuts valid for the widget followed by the global ones.
+func (t *TUI) HelpText(widget string) string {
+	var sections []string
+	if context := widgetContexts[widget]; context != "" {
+		sections = append(sections, fmt.Sprintf("%s\n%s", i18n.T(widgetTitles[widget]), t.Keymap.Help(context)))
+	}
+	sections = append(sections, fmt.Sprintf("%s\n%s", i18n.T("Global"), t.Keymap.Help(ContextGlobal)))
+	return strings.Join(sections, "\n\n")
+}
+
+// ShowHelp shows the shortcuts of the focused widget in a modal.
+// The modal is closed with Esc, Enter or a help key, and focus returns to the widget.
+func (t *TUI) ShowHelp() {
+	widget := t.FocusedWidget()
+	if widget == "" || widget == "modal" {
+		return
+	}
+	text := t.HelpText(widget)
+
+	view := tview.NewTextView().SetText(text)
+	view.SetBorder(true).SetTitle(i18n.T(" Help ")).SetTitleAlign(tview.AlignLeft)
+	view.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		action := t.Keymap.Action(ContextGlobal, event)
+		if event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyEnter || action == "help" || action == "help_input" {
+			t.DeleteModal()
+			t.SetFocus(widget)
+			return nil
+		}
+		return event
+	})
+
+	lines := strings.Split(text, "\n")
+	width := 0
+	for _, line := range lines {
+		if w := runewidth.StringWidth(line); w > width {
+			width = w
+		}
+	}
+	modal := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(view, len(lines)+2, 0, true).
+			AddItem(nil, 0, 1, false), width+4, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	t.SetModal(modal)
+	t.App.SetFocus(view)
+}
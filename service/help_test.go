@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestTUI_ShowHelpFromFormItem(t *testing.T) {
+	tui := NewTUI()
+	form := tview.NewForm().AddInputField("Title", "", 20, nil, nil)
+	tui.SetWidget("mainWorkForm", form)
+	tui.SetWidget("mainWorkContent", tview.NewTable())
+	tui.GlobalKeyActions()
+
+	// the form passes its focus on to the input field
+	tui.SetFocus("mainWorkForm")
+	if _, ok := tui.App.GetFocus().(*tview.InputField); !ok {
+		t.Fatalf("expected the input field to have focus, got %T", tui.App.GetFocus())
+	}
+	if name := tui.FocusedWidget(); name != "mainWorkForm" {
+		t.Errorf("FocusedWidget() = %q, want mainWorkForm", name)
+	}
+
+	capture := tui.App.GetInputCapture()
+	if event := capture(tcell.NewEventKey(tcell.KeyRune, '?', tcell.ModNone)); event == nil {
+		t.Error("expected a typed character to reach the input field")
+	}
+	if event := capture(tcell.NewEventKey(tcell.KeyF1, 0, tcell.ModNone)); event != nil {
+		t.Error("expected the help key to be handled")
+	}
+	if _, ok := tui.Widgets["modal"]; !ok {
+		t.Fatal("expected the help modal to open")
+	}
+
+	// closing the modal gives the focus back to the form
+	tui.App.GetFocus().InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), nil)
+	if _, ok := tui.Widgets["modal"]; ok {
+		t.Error("expected the help modal to be closed")
+	}
+	if name := tui.FocusedWidget(); name != "mainWorkForm" {
+		t.Errorf("expected the form to get the focus back, got %q", name)
+	}
+
+	// outside of text inputs the help key is "?"
+	tui.SetFocus("mainWorkContent")
+	if event := capture(tcell.NewEventKey(tcell.KeyRune, '?', tcell.ModNone)); event != nil {
+		t.Error("expected \"?\" to be handled outside of text inputs")
+	}
+	if _, ok := tui.Widgets["modal"]; !ok {
+		t.Error("expected the help modal to open")
+	}
+}
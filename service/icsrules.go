@@ -0,0 +1,40 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+)
+
+// ICSRulesPath returns the path of the iCalendar import rule file.
+func ICSRulesPath() string {
+	if path := os.Getenv("CHRONOWORK_ICAL_RULES"); path != "" {
+		return path
+	}
+	if rootPath := os.Getenv("CHRONOWORK_ROOT_PATH"); rootPath != "" {
+		return fmt.Sprintf("%s/%s", rootPath, "ical_rules.json")
+	}
+	return fmt.Sprintf("%s/%s", ".", "ical_rules.json")
+}
+
+// LoadICSRules returns the iCalendar import rules of the file at path, or no
+// rules if it does not exist. The rules are tried in order:
+//
+//	[{"keyword": "standup", "project": "web", "tag": "meeting"}, {"keyword": "1on1", "project": "team"}]
+func LoadICSRules(path string) ([]usecase.ICSImportRule, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rules []usecase.ICSImportRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("ical rules %s: %w", path, err)
+	}
+	return rules, nil
+}
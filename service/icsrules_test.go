@@ -0,0 +1,39 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+)
+
+func TestLoadICSRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ical_rules.json")
+	if rules, err := LoadICSRules(path); err != nil || rules != nil {
+		t.Fatalf("expected no rules without a file, got %v, %v", rules, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"keyword": "standup", "project": "web", "tag": "meeting"}, {"keyword": "1on1", "project": "team"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadICSRules(path)
+	if err != nil {
+		t.Fatalf("LoadICSRules failed: %v", err)
+	}
+	expected := []usecase.ICSImportRule{
+		{Keyword: "standup", Project: "web", Tag: "meeting"},
+		{Keyword: "1on1", Project: "team"},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("LoadICSRules() = %v, want %v", rules, expected)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"keyword": "standup"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadICSRules(path); err == nil {
+		t.Error("expected an error for an object instead of a list")
+	}
+}
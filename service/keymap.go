@@ -0,0 +1,308 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+)
+
+// Key binding contexts. Each widget handles the actions of one context,
+// and the global context is handled before any widget.
+const (
+	ContextGlobal    = "global"
+	ContextMenu      = "menu"
+	ContextWork      = "work"
+	ContextForm      = "form"
+	ContextProject   = "project"
+	ContextTag       = "tag"
+	ContextTemplate  = "template"
+	ContextCalendar  = "calendar"
+	ContextTimeline  = "timeline"
+	ContextTimesheet = "timesheet"
+)
+
+// Binding binds a key to a named action within a context.
+type Binding struct {
+	Context     string
+	Action      string
+	Key         string
+	Description string
+}
+
+// defaultBindings is the source of truth for every shortcut of the application.
+var defaultBindings = []Binding{
+	{ContextGlobal, "focus_menu", "Esc", "Back to menu"},
+	{ContextGlobal, "help", "?", "Show shortcuts"},
+	{ContextGlobal, "help_input", "F1", "Show shortcuts from a text input"},
+	{ContextGlobal, "palette", "Ctrl-P", "Command palette"},
+
+	{ContextMenu, "works", "w", "Works"},
+	{ContextMenu, "calendar", "c", "Calendar"},
+	{ContextMenu, "timeline", "l", "Timeline"},
+	{ContextMenu, "charts", "g", "Charts"},
+	{ContextMenu, "timesheet", "i", "Timesheet"},
+	{ContextMenu, "projects", "p", "Projects"},
+	{ContextMenu, "tags", "t", "Tags"},
+	{ContextMenu, "templates", "r", "Templates"},
+	{ContextMenu, "export", "e", "Export"},
+	{ContextMenu, "import", "o", "Import"},
+	{ContextMenu, "backup", "b", "Backup"},
+	{ContextMenu, "audit", "a", "Audit"},
+	{ContextMenu, "notifications", "n", "Notifications"},
+	{ContextMenu, "setting", "s", "Setting"},
+	{ContextMenu, "quit", "q", "Quit"},
+
+	{ContextWork, "toggle_tracking", "Enter", "Start/stop tracking (copy past work to today)"},
+	{ContextWork, "add", "a", "Add work"},
+	{ContextWork, "quick_add", "q", "Quick add work (title @project #tag ~30m)"},
+	{ContextWork, "update", "u", "Update work"},
+	{ContextWork, "reset_timer", "r", "Reset total time"},
+	{ContextWork, "delete", "d", "Delete work"},
+	{ContextWork, "confirm", "c", "Confirm work (reopen if confirmed)"},
+	{ContextWork, "close_period", "C", "Close period up to a date"},
+	{ContextWork, "copy_title", "t", "Copy title to clipboard"},
+	{ContextWork, "copy_time", "h", "Copy time to clipboard"},
+	{ContextWork, "audit", "l", "Show audit log"},
+	{ContextWork, "mark", "Space", "Mark/unmark work"},
+	{ContextWork, "visual", "v", "Start/end range selection"},
+	{ContextWork, "clear_marks", "V", "Clear marks"},
+	{ContextWork, "batch", "b", "Batch actions on marked works"},
+	{ContextWork, "prev_day", "[", "Previous day"},
+	{ContextWork, "next_day", "]", "Next day"},
+	{ContextWork, "prev_week", "{", "Previous week"},
+	{ContextWork, "next_week", "}", "Next week"},
+	{ContextWork, "today", "T", "Back to today"},
+	{ContextWork, "go_to_date", "g", "Go to date"},
+	{ContextWork, "search", "/", "Search works"},
+	{ContextWork, "top", "s", "Go to top"},
+	{ContextWork, "bottom", "e", "Go to bottom"},
+
+	{ContextForm, "back", "Ctrl-B", "Back to table"},
+
+	{ContextProject, "add", "a", "Add project"},
+	{ContextProject, "update", "u", "Update project"},
+	{ContextProject, "delete", "d", "Delete project"},
+
+	{ContextTag, "add", "a", "Add tag"},
+	{ContextTag, "update", "u", "Update tag"},
+
+	{ContextTemplate, "add", "a", "Add template"},
+	{ContextTemplate, "update", "u", "Update template"},
+	{ContextTemplate, "delete", "d", "Delete template"},
+	{ContextTemplate, "materialize", "r", "Create today's works from templates"},
+
+	{ContextCalendar, "open", "Enter", "Show works of the day"},
+	{ContextCalendar, "prev", "[", "Previous month/year"},
+	{ContextCalendar, "next", "]", "Next month/year"},
+	{ContextCalendar, "today", "T", "Back to today"},
+	{ContextCalendar, "toggle_view", "y", "Switch month grid/year heatmap"},
+
+	{ContextTimeline, "prev_day", "[", "Previous day"},
+	{ContextTimeline, "next_day", "]", "Next day"},
+	{ContextTimeline, "today", "T", "Back to today"},
+	{ContextTimeline, "toggle_axis", "h", "Switch working hours/24 hours"},
+
+	{ContextTimesheet, "edit", "Enter", "Change the time of the day"},
+	{ContextTimesheet, "prev_week", "[", "Previous week"},
+	{ContextTimesheet, "next_week", "]", "Next week"},
+	{ContextTimesheet, "this_week", "T", "Back to this week"},
+}
+
+// Keymap is a registry of key bindings by context.
+type Keymap struct {
+	bindings []Binding
+}
+
+// DefaultKeymap returns a Keymap with the default bindings.
+func DefaultKeymap() *Keymap {
+	bindings := make([]Binding, len(defaultBindings))
+	copy(bindings, defaultBindings)
+	return &Keymap{bindings: bindings}
+}
+
+// KeymapPath returns the path of the key binding config file.
+func KeymapPath() string {
+	if path := os.Getenv("CHRONOWORK_KEYMAP"); path != "" {
+		return path
+	}
+	if rootPath := os.Getenv("CHRONOWORK_ROOT_PATH"); rootPath != "" {
+		return fmt.Sprintf("%s/%s", rootPath, "keymap.json")
+	}
+	return fmt.Sprintf("%s/%s", ".", "keymap.json")
+}
+
+// LoadKeymap returns the default bindings overridden by the config file at path,
+// if it exists. The config maps contexts to actions and keys:
+//
+//	{"work": {"search": "f"}, "form": {"back": "Ctrl-G"}}
+//
+// An error is returned for unknown contexts, actions or keys and for conflicts.
+func LoadKeymap(path string) (*Keymap, error) {
+	k := DefaultKeymap()
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		var overrides map[string]map[string]string
+		if err := json.Unmarshal(data, &overrides); err != nil {
+			return nil, fmt.Errorf("keymap %s: %w", path, err)
+		}
+		for context, actions := range overrides {
+			for action, key := range actions {
+				if err := k.Bind(context, action, key); err != nil {
+					return nil, fmt.Errorf("keymap %s: %w", path, err)
+				}
+			}
+		}
+	}
+
+	if err := k.Validate(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Bind changes the key of an existing action.
+func (k *Keymap) Bind(context, action, key string) error {
+	name, ok := canonicalKeyName(key)
+	if !ok {
+		return fmt.Errorf("unknown key %q for %s.%s", key, context, action)
+	}
+	for i, b := range k.bindings {
+		if b.Context == context && b.Action == action {
+			k.bindings[i].Key = name
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown action %s.%s", context, action)
+}
+
+// Validate reports keys bound to several actions of the same context, or to an
+// action of a context and of the global context.
+func (k *Keymap) Validate() error {
+	var conflicts []string
+	seen := map[string]Binding{}
+	for _, b := range k.bindings {
+		if b.Context == ContextMenu && len([]rune(b.Key)) != 1 {
+			conflicts = append(conflicts, fmt.Sprintf("%s.%s: menu keys must be a single character, got %q", b.Context, b.Action, b.Key))
+		}
+		id := b.Context + "\x00" + b.Key
+		if other, ok := seen[id]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("%q is bound to both %s.%s and %s.%s", b.Key, other.Context, other.Action, b.Context, b.Action))
+			continue
+		}
+		seen[id] = b
+	}
+	for _, b := range k.bindings {
+		if b.Context == ContextGlobal {
+			continue
+		}
+		if global, ok := seen[ContextGlobal+"\x00"+b.Key]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("%q is bound to both %s.%s and %s.%s", b.Key, global.Context, global.Action, b.Context, b.Action))
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("key binding conflicts: %s", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+// Action returns the action bound to the key event in the context, or "" if none.
+func (k *Keymap) Action(context string, event *tcell.EventKey) string {
+	name := EventKeyName(event)
+	if name == "" {
+		return ""
+	}
+	for _, b := range k.bindings {
+		if b.Context == context && b.Key == name {
+			return b.Action
+		}
+	}
+	return ""
+}
+
+// Key returns the key bound to the action in the context, or "" if none.
+func (k *Keymap) Key(context, action string) string {
+	for _, b := range k.bindings {
+		if b.Context == context && b.Action == action {
+			return b.Key
+		}
+	}
+	return ""
+}
+
+// Rune returns the single-character key bound to the action, or 0 if none.
+func (k *Keymap) Rune(context, action string) rune {
+	key := []rune(k.Key(context, action))
+	if len(key) != 1 {
+		return 0
+	}
+	return key[0]
+}
+
+// Bindings returns the bindings of the context in registration order.
+func (k *Keymap) Bindings(context string) []Binding {
+	var result []Binding
+	for _, b := range k.bindings {
+		if b.Context == context {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// Help returns the shortcuts of the context, one "key  description" per line,
+// with the descriptions translated to the current locale.
+func (k *Keymap) Help(context string) string {
+	bindings := k.Bindings(context)
+	width := 0
+	for _, b := range bindings {
+		if len(b.Key) > width {
+			width = len(b.Key)
+		}
+	}
+	lines := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		lines = append(lines, fmt.Sprintf("%-*s  %s", width, b.Key, i18n.T(b.Description)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// EventKeyName returns the name of a key event as used in bindings:
+// the character for runes, "Space" for the space bar, and tcell's key names
+// such as "Enter", "Esc" or "Ctrl-B" otherwise.
+func EventKeyName(event *tcell.EventKey) string {
+	if event.Key() == tcell.KeyRune {
+		if event.Rune() == ' ' {
+			return "Space"
+		}
+		return string(event.Rune())
+	}
+	return tcell.KeyNames[event.Key()]
+}
+
+// canonicalKeyName normalizes a configured key name such as "ctrl+b" to "Ctrl-B".
+func canonicalKeyName(key string) (string, bool) {
+	if len([]rune(key)) == 1 {
+		return key, key != " "
+	}
+	normalized := strings.ToLower(strings.ReplaceAll(key, "+", "-"))
+	if normalized == "space" {
+		return "Space", true
+	}
+	for _, name := range tcell.KeyNames {
+		if strings.ToLower(name) == normalized {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDefaultKeymap_Validate(t *testing.T) {
+	if err := DefaultKeymap().Validate(); err != nil {
+		t.Errorf("default keymap has conflicts: %v", err)
+	}
+}
+
+func TestKeymap_Action(t *testing.T) {
+	k := DefaultKeymap()
+
+	tests := []struct {
+		context  string
+		event    *tcell.EventKey
+		expected string
+	}{
+		{ContextWork, tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone), "add"},
+		{ContextWork, tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), "mark"},
+		{ContextWork, tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), "toggle_tracking"},
+		{ContextForm, tcell.NewEventKey(tcell.KeyCtrlB, 0, tcell.ModCtrl), "back"},
+		{ContextGlobal, tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), "focus_menu"},
+		{ContextTag, tcell.NewEventKey(tcell.KeyRune, 'd', tcell.ModNone), ""},
+	}
+
+	for _, tc := range tests {
+		result := k.Action(tc.context, tc.event)
+		if result != tc.expected {
+			t.Errorf("Action(%s, %s) = %q, want %q", tc.context, tc.event.Name(), result, tc.expected)
+		}
+	}
+}
+
+func TestLoadKeymap_Overrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keymap.json")
+	config := `{"work": {"search": "f"}, "form": {"back": "ctrl+g"}}`
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := LoadKeymap(path)
+	if err != nil {
+		t.Fatalf("LoadKeymap failed: %v", err)
+	}
+	if key := k.Key(ContextWork, "search"); key != "f" {
+		t.Errorf("expected search on 'f', got %q", key)
+	}
+	if key := k.Key(ContextForm, "back"); key != "Ctrl-G" {
+		t.Errorf("expected back on 'Ctrl-G', got %q", key)
+	}
+	found := false
+	for _, line := range strings.Split(k.Help(ContextWork), "\n") {
+		if strings.HasPrefix(line, "f ") && strings.HasSuffix(line, "Search works") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected help to reflect override, got:\n%s", k.Help(ContextWork))
+	}
+}
+
+func TestLoadKeymap_MissingFile(t *testing.T) {
+	k, err := LoadKeymap(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadKeymap failed: %v", err)
+	}
+	if key := k.Key(ContextWork, "add"); key != "a" {
+		t.Errorf("expected default key 'a', got %q", key)
+	}
+}
+
+func TestLoadKeymap_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{"conflict in context", `{"work": {"search": "a"}}`},
+		{"conflict with global", `{"work": {"search": "Esc"}}`},
+		{"unknown action", `{"work": {"fly": "x"}}`},
+		{"unknown key", `{"work": {"search": "Hyper-X"}}`},
+		{"multi-key menu", `{"menu": {"quit": "Enter"}}`},
+	}
+
+	for _, tc := range tests {
+		path := filepath.Join(t.TempDir(), "keymap.json")
+		if err := os.WriteFile(path, []byte(tc.config), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadKeymap(path); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
+
+func TestTUI_HelpText(t *testing.T) {
+	tui := NewTUI()
+
+	work := tui.HelpText("mainWorkContent")
+	if !strings.HasPrefix(work, "Works\n") {
+		t.Errorf("expected work shortcuts first, got %q", work)
+	}
+	if !strings.Contains(work, "Search works") || !strings.Contains(work, "Show shortcuts") {
+		t.Errorf("expected work and global shortcuts, got %q", work)
+	}
+
+	export := tui.HelpText("exportForm")
+	if !strings.HasPrefix(export, "Global\n") {
+		t.Errorf("expected only global shortcuts for export, got %q", export)
+	}
+}
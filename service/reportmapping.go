@@ -0,0 +1,41 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+)
+
+// ReportMappingPath returns the path of the time tracker report mapping file.
+func ReportMappingPath() string {
+	if path := os.Getenv("CHRONOWORK_REPORT_MAPPING"); path != "" {
+		return path
+	}
+	if rootPath := os.Getenv("CHRONOWORK_ROOT_PATH"); rootPath != "" {
+		return fmt.Sprintf("%s/%s", rootPath, "report_mapping.json")
+	}
+	return fmt.Sprintf("%s/%s", ".", "report_mapping.json")
+}
+
+// LoadReportMapping returns the report mapping of the file at path, or an
+// empty mapping if it does not exist. The file renames projects, clients and
+// tags of the reports:
+//
+//	{"projects": {"Website": "web"}, "clients": {"Acme": "acme"}, "tags": {"mtg": "meeting"}}
+func LoadReportMapping(path string) (usecase.ReportMapping, error) {
+	var mapping usecase.ReportMapping
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return mapping, nil
+	}
+	if err != nil {
+		return mapping, err
+	}
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return mapping, fmt.Errorf("report mapping %s: %w", path, err)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+)
+
+func TestLoadReportMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report_mapping.json")
+	if mapping, err := LoadReportMapping(path); err != nil || !reflect.DeepEqual(mapping, usecase.ReportMapping{}) {
+		t.Fatalf("expected an empty mapping without a file, got %v, %v", mapping, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"projects": {"Website": "web"}, "tags": {"mtg": "meeting"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mapping, err := LoadReportMapping(path)
+	if err != nil {
+		t.Fatalf("LoadReportMapping failed: %v", err)
+	}
+	expected := usecase.ReportMapping{
+		Projects: map[string]string{"Website": "web"},
+		Tags:     map[string]string{"mtg": "meeting"},
+	}
+	if !reflect.DeepEqual(mapping, expected) {
+		t.Errorf("LoadReportMapping() = %v, want %v", mapping, expected)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"projects": ["web"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadReportMapping(path); err == nil {
+		t.Error("expected an error for a list of projects")
+	}
+}
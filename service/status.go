@@ -0,0 +1,137 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/rivo/tview"
+)
+
+// Level is the severity of a notification.
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelWarning
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelWarning:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return "INFO"
+	}
+}
+
+// statusTimeouts are the durations after which notifications are dismissed.
+var statusTimeouts = map[Level]time.Duration{
+	LevelInfo:    3 * time.Second,
+	LevelWarning: 5 * time.Second,
+	LevelError:   8 * time.Second,
+}
+
+// maxStatusHistory is the number of notifications kept in the history.
+const maxStatusHistory = 100
+
+// Notification is a message shown in the status bar.
+type Notification struct {
+	Level   Level
+	Message string
+	Time    time.Time
+}
+
+// StatusBar shows notifications at the bottom of the screen without blocking
+// the application, and keeps them in a history.
+type StatusBar struct {
+	View *tview.TextView
+
+	app     *tview.Application
+	mu      sync.Mutex
+	history []Notification
+	shown   int
+	colors  map[Level]tcell.Color
+}
+
+// NewStatusBar creates a new StatusBar.
+func NewStatusBar(app *tview.Application) *StatusBar {
+	return &StatusBar{
+		View: tview.NewTextView(),
+		app:  app,
+		colors: map[Level]tcell.Color{
+			LevelInfo:    tcell.ColorGreen,
+			LevelWarning: tcell.ColorYellow,
+			LevelError:   tcell.ColorRed,
+		},
+	}
+}
+
+// SetColors sets the colors of the levels.
+func (s *StatusBar) SetColors(info, warning, err tcell.Color) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.colors = map[Level]tcell.Color{
+		LevelInfo:    info,
+		LevelWarning: warning,
+		LevelError:   err,
+	}
+}
+
+// Info shows an informational message, such as the result of an action.
+// The format is translated to the current locale.
+func (s *StatusBar) Info(format string, args ...any) {
+	s.notify(LevelInfo, i18n.T(format, args...))
+}
+
+// Warn shows a translated warning.
+func (s *StatusBar) Warn(format string, args ...any) {
+	s.notify(LevelWarning, i18n.T(format, args...))
+}
+
+// Error shows a translated error message.
+func (s *StatusBar) Error(format string, args ...any) {
+	s.notify(LevelError, i18n.T(format, args...))
+}
+
+// History returns the notifications, newest first.
+func (s *StatusBar) History() []Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	history := make([]Notification, len(s.history))
+	for i, n := range s.history {
+		history[len(s.history)-1-i] = n
+	}
+	return history
+}
+
+// notify shows the message and dismisses it after the timeout of its level,
+// unless another message has been shown in the meantime.
+// It must be called from the event loop, like other widget updates.
+func (s *StatusBar) notify(level Level, message string) {
+	s.mu.Lock()
+	s.history = append(s.history, Notification{Level: level, Message: message, Time: time.Now()})
+	if len(s.history) > maxStatusHistory {
+		s.history = s.history[len(s.history)-maxStatusHistory:]
+	}
+	s.shown++
+	shown := s.shown
+	color := s.colors[level]
+	s.mu.Unlock()
+
+	s.View.SetTextColor(color).SetText(fmt.Sprintf("[%s] %s", level, message))
+	time.AfterFunc(statusTimeouts[level], func() {
+		s.app.QueueUpdateDraw(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.shown == shown {
+				s.View.SetText("")
+			}
+		})
+	})
+}
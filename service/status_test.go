@@ -0,0 +1,45 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestStatusBar_History(t *testing.T) {
+	s := NewStatusBar(tview.NewApplication())
+
+	s.Info("exported %d works", 3)
+	s.Error("failed")
+
+	history := s.History()
+	if len(history) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(history))
+	}
+	if history[0].Level != LevelError || history[0].Message != "failed" {
+		t.Errorf("expected newest notification first, got %+v", history[0])
+	}
+	if history[1].Level != LevelInfo || history[1].Message != "exported 3 works" {
+		t.Errorf("unexpected notification %+v", history[1])
+	}
+	if text := s.View.GetText(true); !strings.Contains(text, "failed") {
+		t.Errorf("expected the last message to be shown, got %q", text)
+	}
+}
+
+func TestStatusBar_HistoryLimit(t *testing.T) {
+	s := NewStatusBar(tview.NewApplication())
+
+	for i := 0; i < maxStatusHistory+10; i++ {
+		s.Warn("warning %d", i)
+	}
+
+	history := s.History()
+	if len(history) != maxStatusHistory {
+		t.Fatalf("expected %d notifications, got %d", maxStatusHistory, len(history))
+	}
+	if history[len(history)-1].Message != "warning 10" {
+		t.Errorf("expected oldest notifications to be dropped, got %q", history[len(history)-1].Message)
+	}
+}
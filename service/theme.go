@@ -0,0 +1,285 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// DefaultThemeName is the theme used when none is selected.
+const DefaultThemeName = "default"
+
+// Theme is a named palette of the colors used by the widgets.
+type Theme struct {
+	Name string
+	// Background, Text and Border are the defaults of every primitive.
+	Background tcell.Color
+	Text       tcell.Color
+	Border     tcell.Color
+	// Accent is used for labels, buttons, table headers and the timer.
+	Accent tcell.Color
+	// AccentText is the text color on Accent, Summary and DateRow backgrounds.
+	AccentText      tcell.Color
+	FieldText       tcell.Color
+	FieldBackground tcell.Color
+	// Summary and DateRow are the backgrounds of daily total and date rows.
+	Summary tcell.Color
+	DateRow tcell.Color
+	// Positive and Negative mark confirmed/tracking states and their opposite,
+	// and with Warning color the notifications of the status bar.
+	Positive tcell.Color
+	Negative tcell.Color
+	Warning  tcell.Color
+	Marked   tcell.Color
+	// Shortcut is the color of the menu shortcuts.
+	Shortcut tcell.Color
+	// MatchText and MatchBackground highlight search matches.
+	MatchText       tcell.Color
+	MatchBackground tcell.Color
+}
+
+var builtinThemes = map[string]Theme{
+	DefaultThemeName: {
+		Background:      tcell.ColorBlack,
+		Text:            tcell.ColorWhite,
+		Border:          tcell.ColorWhite,
+		Accent:          tcell.ColorPurple,
+		AccentText:      tcell.ColorWhite,
+		FieldText:       tcell.ColorGray,
+		FieldBackground: tcell.ColorWhite,
+		Summary:         tcell.ColorRebeccaPurple,
+		DateRow:         tcell.ColorMediumPurple.TrueColor(),
+		Positive:        tcell.ColorGreen,
+		Negative:        tcell.ColorRed,
+		Warning:         tcell.ColorYellow,
+		Marked:          tcell.ColorDarkSlateGray,
+		Shortcut:        tcell.ColorYellow,
+		MatchText:       tcell.ColorBlack,
+		MatchBackground: tcell.ColorYellow,
+	},
+	"high-contrast": {
+		Background:      tcell.ColorBlack,
+		Text:            tcell.ColorWhite,
+		Border:          tcell.ColorYellow,
+		Accent:          tcell.ColorYellow,
+		AccentText:      tcell.ColorBlack,
+		FieldText:       tcell.ColorBlack,
+		FieldBackground: tcell.ColorWhite,
+		Summary:         tcell.ColorWhite,
+		DateRow:         tcell.ColorAqua,
+		Positive:        tcell.ColorLime,
+		Negative:        tcell.ColorFuchsia,
+		Warning:         tcell.ColorYellow,
+		Marked:          tcell.ColorBlue,
+		Shortcut:        tcell.ColorYellow,
+		MatchText:       tcell.ColorBlack,
+		MatchBackground: tcell.ColorAqua,
+	},
+	"light": {
+		Background:      tcell.ColorWhite,
+		Text:            tcell.ColorBlack,
+		Border:          tcell.ColorGray,
+		Accent:          tcell.ColorPurple,
+		AccentText:      tcell.ColorWhite,
+		FieldText:       tcell.ColorBlack,
+		FieldBackground: tcell.ColorLightGray,
+		Summary:         tcell.ColorRebeccaPurple,
+		DateRow:         tcell.ColorMediumPurple.TrueColor(),
+		Positive:        tcell.ColorDarkGreen,
+		Negative:        tcell.ColorDarkRed,
+		Warning:         tcell.ColorDarkOrange,
+		Marked:          tcell.ColorLightSteelBlue,
+		Shortcut:        tcell.ColorPurple,
+		MatchText:       tcell.ColorBlack,
+		MatchBackground: tcell.ColorGold,
+	},
+}
+
+// Themes is a set of themes by name.
+type Themes map[string]Theme
+
+// DefaultThemes returns the built-in themes.
+func DefaultThemes() Themes {
+	themes := Themes{}
+	for name, theme := range builtinThemes {
+		theme.Name = name
+		themes[name] = theme
+	}
+	return themes
+}
+
+// ThemePath returns the path of the theme config file.
+func ThemePath() string {
+	if path := os.Getenv("CHRONOWORK_THEMES"); path != "" {
+		return path
+	}
+	if rootPath := os.Getenv("CHRONOWORK_ROOT_PATH"); rootPath != "" {
+		return fmt.Sprintf("%s/%s", rootPath, "themes.json")
+	}
+	return fmt.Sprintf("%s/%s", ".", "themes.json")
+}
+
+// LoadThemes returns the built-in themes and the themes of the config file at path,
+// if it exists. A theme is based on another theme and overrides some of its colors,
+// given as names or hex values:
+//
+//	{"solarized": {"base": "light", "accent": "#268bd2", "summary": "#073642"}}
+//
+// Without a base, the default theme is used. A theme of the file named like a
+// built-in one replaces it, and is based on it when its base is itself.
+func LoadThemes(path string) (Themes, error) {
+	themes := DefaultThemes()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return themes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var configs map[string]map[string]string
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("themes %s: %w", path, err)
+	}
+
+	defaults := DefaultThemes()
+	var resolve func(name string, seen map[string]bool) (Theme, error)
+	resolve = func(name string, seen map[string]bool) (Theme, error) {
+		config, ok := configs[name]
+		if !ok || seen[name] {
+			theme, ok := defaults[name]
+			if !ok {
+				return Theme{}, fmt.Errorf("unknown base theme %q", name)
+			}
+			return theme, nil
+		}
+		seen[name] = true
+		base, err := resolve(baseOf(config), seen)
+		if err != nil {
+			return Theme{}, err
+		}
+		theme, err := base.with(config)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme %s: %w", name, err)
+		}
+		theme.Name = name
+		return theme, nil
+	}
+	for name := range configs {
+		theme, err := resolve(name, map[string]bool{})
+		if err != nil {
+			return nil, fmt.Errorf("themes %s: %w", path, err)
+		}
+		themes[name] = theme
+	}
+	return themes, nil
+}
+
+// Names returns the theme names, the default theme first.
+func (t Themes) Names() []string {
+	names := []string{DefaultThemeName}
+	for name := range t {
+		if name != DefaultThemeName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// Get returns the named theme, or the default theme if there is none.
+func (t Themes) Get(name string) *Theme {
+	if theme, ok := t[name]; ok {
+		return &theme
+	}
+	theme := t[DefaultThemeName]
+	return &theme
+}
+
+// Apply sets the theme as the default style of the primitives created afterwards.
+func (t *Theme) Apply() {
+	tview.Styles.PrimitiveBackgroundColor = t.Background
+	tview.Styles.PrimaryTextColor = t.Text
+	tview.Styles.BorderColor = t.Border
+	tview.Styles.TitleColor = t.Text
+	tview.Styles.GraphicsColor = t.Border
+	tview.Styles.SecondaryTextColor = t.Shortcut
+}
+
+// MatchTag returns the tview color tag used to highlight search matches.
+func (t *Theme) MatchTag() string {
+	return fmt.Sprintf("[%s:%s]", colorName(t.MatchText), colorName(t.MatchBackground))
+}
+
+func baseOf(config map[string]string) string {
+	if base, ok := config["base"]; ok {
+		return base
+	}
+	return DefaultThemeName
+}
+
+// with returns a copy of the theme with the colors of the config.
+func (t Theme) with(config map[string]string) (Theme, error) {
+	fields := map[string]*tcell.Color{
+		"background":       &t.Background,
+		"text":             &t.Text,
+		"border":           &t.Border,
+		"accent":           &t.Accent,
+		"accent_text":      &t.AccentText,
+		"field_text":       &t.FieldText,
+		"field_background": &t.FieldBackground,
+		"summary":          &t.Summary,
+		"date_row":         &t.DateRow,
+		"positive":         &t.Positive,
+		"negative":         &t.Negative,
+		"warning":          &t.Warning,
+		"marked":           &t.Marked,
+		"shortcut":         &t.Shortcut,
+		"match_text":       &t.MatchText,
+		"match_background": &t.MatchBackground,
+	}
+	for key, value := range config {
+		if key == "base" {
+			continue
+		}
+		field, ok := fields[key]
+		if !ok {
+			return t, fmt.Errorf("unknown color %q", key)
+		}
+		color := tcell.GetColor(value)
+		if color == tcell.ColorDefault && value != "default" {
+			return t, fmt.Errorf("invalid color %q for %s", value, key)
+		}
+		*field = color
+	}
+	return t, nil
+}
+
+// colorName returns a name of the color usable in tview color tags.
+func colorName(color tcell.Color) string {
+	if color == tcell.ColorDefault {
+		return "-"
+	}
+	r, g, b := color.RGB()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// ColorTag returns the tview color tag setting the text color.
+func ColorTag(color tcell.Color) string {
+	return "[" + colorName(color) + "]"
+}
+
+// ApplyTheme applies the theme to the primitives created afterwards and to the
+// layout of the TUI, which exists before the theme is loaded.
+func (t *TUI) ApplyTheme(theme *Theme) {
+	theme.Apply()
+	t.Grid.SetBordersColor(theme.Border).SetBackgroundColor(theme.Background)
+	t.MainPage.SetBackgroundColor(theme.Background)
+	t.Status.View.SetBackgroundColor(theme.Background)
+	t.Status.SetColors(theme.Positive, theme.Warning, theme.Negative)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func writeThemes(t *testing.T, config string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "themes.json")
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadThemes_MissingFile(t *testing.T) {
+	themes, err := LoadThemes(filepath.Join(t.TempDir(), "themes.json"))
+	if err != nil {
+		t.Fatalf("LoadThemes failed: %v", err)
+	}
+	expected := []string{"default", "high-contrast", "light"}
+	if names := themes.Names(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Names() = %v, want %v", names, expected)
+	}
+}
+
+func TestLoadThemes_Base(t *testing.T) {
+	path := writeThemes(t, `{
+		"solarized": {"base": "light", "accent": "#268bd2"},
+		"solarized-dark": {"base": "solarized", "background": "black"}
+	}`)
+
+	themes, err := LoadThemes(path)
+	if err != nil {
+		t.Fatalf("LoadThemes failed: %v", err)
+	}
+	dark := themes.Get("solarized-dark")
+	if dark.Name != "solarized-dark" {
+		t.Errorf("expected name solarized-dark, got %s", dark.Name)
+	}
+	if dark.Accent != tcell.NewHexColor(0x268bd2) {
+		t.Errorf("expected accent inherited from solarized, got %v", dark.Accent)
+	}
+	if dark.Background != tcell.ColorBlack {
+		t.Errorf("expected black background, got %v", dark.Background)
+	}
+	if dark.Text != tcell.ColorBlack {
+		t.Errorf("expected text inherited from light, got %v", dark.Text)
+	}
+}
+
+func TestLoadThemes_Invalid(t *testing.T) {
+	tests := []string{
+		`{"mine": {"accent": "not-a-color"}}`,
+		`{"mine": {"unknown": "red"}}`,
+		`{"mine": {"base": "missing"}}`,
+		`{"a": {"base": "b"}, "b": {"base": "a"}}`,
+	}
+
+	for _, config := range tests {
+		if _, err := LoadThemes(writeThemes(t, config)); err == nil {
+			t.Errorf("expected error for %s", config)
+		}
+	}
+}
+
+func TestThemes_GetFallsBackToDefault(t *testing.T) {
+	theme := DefaultThemes().Get("missing")
+	if theme.Name != DefaultThemeName {
+		t.Errorf("expected default theme, got %s", theme.Name)
+	}
+	if tag := theme.MatchTag(); tag != "[#000000:#ffff00]" {
+		t.Errorf("MatchTag() = %s", tag)
+	}
+}
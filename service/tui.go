@@ -12,6 +12,10 @@ type TUI struct {
 	Grid     *tview.Grid
 	MainPage *tview.Pages
 	Widgets  map[string]tview.Primitive
+	Keymap   *Keymap
+	Status   *StatusBar
+
+	globalActions map[string]func()
 }
 
 func (t *TUI) SetHeader(header tview.Primitive, focus bool) {
@@ -25,7 +29,7 @@ func (t *TUI) SetMenu(menu tview.Primitive, focus bool) {
 }
 
 func (t *TUI) SetWork(mainTitle, mainForm, mainTimer, mainContent tview.Primitive, focus bool) {
-	main := tview.NewGrid().SetRows(1, 10, 0).SetColumns(0, 0).SetBorders(true)
+	main := tview.NewGrid().SetRows(1, 11, 0).SetColumns(0, 0).SetBorders(true)
 	main.AddItem(mainTitle, 0, 0, 1, 2, 0, 0, false)
 	main.AddItem(mainForm, 1, 0, 1, 1, 0, 0, false)
 	main.AddItem(mainTimer, 1, 1, 1, 1, 0, 0, false)
@@ -39,6 +43,10 @@ func (t *TUI) SetWork(mainTitle, mainForm, mainTimer, mainContent tview.Primitiv
 	t.Grid.AddItem(t.MainPage, 1, 1, 1, 2, 0, 100, focus)
 }
 
+func (t *TUI) SetStatusBar() {
+	t.Grid.AddItem(t.Status.View, 2, 0, 1, 3, 0, 0, false)
+}
+
 func (t *TUI) SetMainPage(name string, page tview.Primitive, focus bool) {
 	t.MainPage.AddPage(name, page, true, focus)
 }
@@ -62,27 +70,53 @@ func (t *TUI) DeleteModal() {
 }
 
 func NewTUI() *TUI {
+	app := tview.NewApplication()
 	return &TUI{
-		App: tview.NewApplication(),
+		App: app,
 		Grid: tview.NewGrid().
-			SetRows(1, 0).
+			SetRows(1, 0, 1).
 			SetColumns(15, 0).
 			SetBorders(true),
 		MainPage: tview.NewPages(),
 		Widgets:  make(map[string]tview.Primitive),
+		Keymap:   DefaultKeymap(),
+		Status:   NewStatusBar(app),
+
+		globalActions: make(map[string]func()),
 	}
 }
 
 func (t *TUI) GlobalKeyActions() {
 	t.App.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyEscape:
+		// modals and text inputs handle their own keys
+		if _, ok := t.Widgets["modal"]; ok {
+			return event
+		}
+		if _, ok := t.App.GetFocus().(*tview.InputField); ok && event.Key() == tcell.KeyRune {
+			return event
+		}
+		switch action := t.Keymap.Action(ContextGlobal, event); action {
+		case "focus_menu":
 			t.SetFocus("menu")
+		case "help", "help_input":
+			t.ShowHelp()
+			return nil
+		default:
+			if fn, ok := t.globalActions[action]; ok {
+				fn()
+				return nil
+			}
 		}
 		return event
 	})
 }
 
+// SetGlobalAction registers the handler of a global action that is
+// implemented outside of the TUI, such as the command palette.
+func (t *TUI) SetGlobalAction(action string, fn func()) {
+	t.globalActions[action] = fn
+}
+
 func (t *TUI) Quit() {
 	t.App.Stop()
 }
@@ -0,0 +1,135 @@
+// Package i18n translates the UI strings. Strings are written in English in
+// the code and used as keys of the catalogs of the other languages.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Locale is a language of the UI.
+type Locale string
+
+const (
+	English  Locale = "en"
+	Japanese Locale = "ja"
+)
+
+// Auto is the language setting that selects the locale from the environment.
+const Auto = "auto"
+
+var (
+	current = English
+
+	catalogs = map[Locale]map[string]string{
+		Japanese: japanese,
+	}
+
+	dateFormats = map[Locale]string{
+		English:  "2006/01/02",
+		Japanese: "2006年1月2日",
+	}
+
+	monthFormats = map[Locale]string{
+		English:  "January 2006",
+		Japanese: "2006年1月",
+	}
+
+	japaneseWeekdays      = []string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"}
+	japaneseShortWeekdays = []string{"日", "月", "火", "水", "木", "金", "土"}
+)
+
+// Settings returns the values of the language setting.
+func Settings() []string {
+	return []string{Auto, string(English), string(Japanese)}
+}
+
+// Resolve returns the locale of a language setting, detecting it from the
+// environment for Auto or unknown values.
+func Resolve(setting string) Locale {
+	switch Locale(setting) {
+	case English, Japanese:
+		return Locale(setting)
+	}
+	return Detect()
+}
+
+// Detect returns the locale of the LC_ALL, LC_MESSAGES or LANG environment
+// variables, in that order, and English if none is Japanese.
+func Detect() Locale {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(value), "ja") {
+			return Japanese
+		}
+		return English
+	}
+	return English
+}
+
+// SetLocale sets the locale of the UI. It must be called before widgets are created.
+func SetLocale(locale Locale) {
+	current = locale
+}
+
+// CurrentLocale returns the locale of the UI.
+func CurrentLocale() Locale {
+	return current
+}
+
+// T returns the translation of key in the current locale, formatted with args
+// if any. Keys without translation are returned as is.
+func T(key string, args ...any) string {
+	message := key
+	if translated, ok := catalogs[current][key]; ok {
+		message = translated
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// Weekday returns the name of the weekday in the current locale.
+func Weekday(weekday time.Weekday) string {
+	if current == Japanese {
+		return japaneseWeekdays[weekday]
+	}
+	return weekday.String()
+}
+
+// ShortWeekday returns the abbreviated name of the weekday in the current locale.
+func ShortWeekday(weekday time.Weekday) string {
+	if current == Japanese {
+		return japaneseShortWeekdays[weekday]
+	}
+	return weekday.String()[:3]
+}
+
+// ShortMonth returns the abbreviated name of the month in the current locale.
+func ShortMonth(month time.Month) string {
+	if current == Japanese {
+		return fmt.Sprintf("%d月", month)
+	}
+	return month.String()[:3]
+}
+
+// FormatDate formats the date for display in the current locale.
+func FormatDate(t time.Time) string {
+	return t.Format(dateFormats[current])
+}
+
+// FormatMonth formats the month of t for display in the current locale.
+func FormatMonth(t time.Time) string {
+	return t.Format(monthFormats[current])
+}
+
+// FormatDateTime formats the date and time of day for display in the current locale.
+func FormatDateTime(t time.Time) string {
+	return FormatDate(t) + " " + t.Format("15:04:05")
+}
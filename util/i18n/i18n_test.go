@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var verbPattern = regexp.MustCompile(`%[a-z]`)
+
+func TestJapaneseCatalog_Verbs(t *testing.T) {
+	for key, translated := range japanese {
+		keyVerbs := verbPattern.FindAllString(key, -1)
+		translatedVerbs := verbPattern.FindAllString(translated, -1)
+		if !reflect.DeepEqual(keyVerbs, translatedVerbs) {
+			t.Errorf("translation of %q has verbs %v, want %v", key, translatedVerbs, keyVerbs)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	defer SetLocale(CurrentLocale())
+
+	SetLocale(English)
+	if got := T("Marked: %d", 2); got != "Marked: 2" {
+		t.Errorf("T in English = %q", got)
+	}
+
+	SetLocale(Japanese)
+	if got := T("Marked: %d", 2); got != "マーク: 2件" {
+		t.Errorf("T in Japanese = %q", got)
+	}
+	if got := T("untranslated"); got != "untranslated" {
+		t.Errorf("expected untranslated key as is, got %q", got)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "ja_JP.UTF-8")
+
+	tests := []struct {
+		setting  string
+		expected Locale
+	}{
+		{"en", English},
+		{"ja", Japanese},
+		{Auto, Japanese},
+		{"", Japanese},
+	}
+
+	for _, tc := range tests {
+		if got := Resolve(tc.setting); got != tc.expected {
+			t.Errorf("Resolve(%q) = %s, want %s", tc.setting, got, tc.expected)
+		}
+	}
+
+	t.Setenv("LC_ALL", "C")
+	if got := Resolve(Auto); got != English {
+		t.Errorf("expected LC_ALL to take precedence over LANG, got %s", got)
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	defer SetLocale(CurrentLocale())
+	date := time.Date(2024, 3, 4, 9, 5, 6, 0, time.Local)
+
+	SetLocale(English)
+	if got := FormatDate(date) + " " + Weekday(date.Weekday()); got != "2024/03/04 Monday" {
+		t.Errorf("English date = %q", got)
+	}
+
+	SetLocale(Japanese)
+	if got := FormatDateTime(date) + " " + ShortWeekday(date.Weekday()); got != "2024年3月4日 09:05:06 月" {
+		t.Errorf("Japanese date = %q", got)
+	}
+	if got := FormatMonth(date) + " " + ShortMonth(date.Month()); got != "2024年3月 3月" {
+		t.Errorf("Japanese month = %q", got)
+	}
+}
@@ -0,0 +1,264 @@
+package i18n
+
+// japanese translates the English strings of the UI.
+var japanese = map[string]string{
+	" (%s, copy to today)":                  "（%s、今日にコピー）",
+	" Commands ":                            " コマンド ",
+	" Help ":                                " ヘルプ ",
+	"%d  Total %s":                          "%d年  合計 %s",
+	"%d works, %d confirmed":                "作業%d件、確定%d件",
+	"%s  Total %s":                          "%s  合計 %s",
+	"%s: applied to %d works.":              "%s: %d件に適用しました。",
+	"+ Create tag: %s":                      "+ タグを作成: %s",
+	"Action":                                "操作",
+	"Action(%d works)":                      "操作(%d件)",
+	"Actor":                                 "実行者",
+	"Add":                                   "追加",
+	"Add project":                           "プロジェクトを追加",
+	"Add tag":                               "タグを追加",
+	"Add template":                          "テンプレートを追加",
+	"Add work":                              "作業を追加",
+	"Add work: @%s":                         "作業を追加: @%s",
+	"Add work: @%s #%s":                     "作業を追加: @%s #%s",
+	"Added %s.":                             "%s を追加しました。",
+	"Allow Creating Tags From Work Form : ": "作業フォームでのタグ作成を許可 : ",
+	"An error occurred.":                    "エラーが発生しました。",
+	"An error occurred: %s":                 "エラーが発生しました: %s",
+	"An unknown error occurred.":            "不明なエラーが発生しました。",
+	"Apply":                                 "適用",
+	"Are you sure you want to delete %d works?":      "%d件の作業を削除してもよろしいですか？",
+	"Are you sure you want to delete this project?":  "このプロジェクトを削除してもよろしいですか？",
+	"Are you sure you want to delete this template?": "このテンプレートを削除してもよろしいですか？",
+	"Are you sure you want to delete this work?":     "この作業を削除してもよろしいですか？",
+	"Audit":                         "監査ログ",
+	"Back to menu":                  "メニューに戻る",
+	"Back to table":                 "一覧に戻る",
+	"Back to this week":             "今週に戻る",
+	"Back to today":                 "今日に戻る",
+	"Backed up to %s":               "バックアップしました: %s",
+	"Backup":                        "バックアップ",
+	"Batch actions on marked works": "マークした作業への一括操作",
+	"By project":                    "プロジェクト別",
+	"By tag":                        "タグ別",
+	"By weekday":                    "曜日別",
+	"Calendar":                      "カレンダー",
+	"Can't delete this project. Exist work that use this project.": "このプロジェクトを使用している作業があるため削除できません。",
+	"Cancel":                              "キャンセル",
+	"Change the time of the day":          "その日の作業時間を変更",
+	"Charts":                              "グラフ",
+	"Clear":                               "クリア",
+	"Clear marks":                         "マークを解除",
+	"Close":                               "締める",
+	"Close Until(YYYY/MM/DD)":             "締め日(YYYY/MM/DD)",
+	"Close period":                        "期間を締める",
+	"Close period up to a date":           "指定日までの期間を締める",
+	"Command palette":                     "コマンドパレット",
+	"Confirm":                             "確定",
+	"Confirm work (reopen if confirmed)":  "作業を確定（確定済みなら再オープン）",
+	"Conflicts:":                          "競合:",
+	"Copied time: %s":                     "作業時間をコピーしました: %s",
+	"Copied title: %s":                    "タイトルをコピーしました: %s",
+	"Copy":                                "コピー",
+	"Copy time to clipboard":              "作業時間をクリップボードにコピー",
+	"Copy title to clipboard":             "タイトルをクリップボードにコピー",
+	"Copy to Today":                       "今日にコピー",
+	"Create":                              "作成",
+	"Create today's works from templates": "テンプレートから今日の作業を作成",
+	"Created %d works from templates.":    "テンプレートから%d件の作業を作成しました。",
+	"Created Date : ":                     "作成日 : ",
+	"Custom":                              "期間を指定",
+	"Date":                                "日時",
+	"Date(YYYY/MM/DD)":                    "日付(YYYY/MM/DD)",
+	"Delete":                              "削除",
+	"Delete project":                      "プロジェクトを削除",
+	"Delete template":                     "テンプレートを削除",
+	"Delete work":                         "作業を削除",
+	"Display As Person Day : ":            "人日で表示 : ",
+	"Done":                                "完了",
+	"Download Path : ":                    "ダウンロード先 : ",
+	"Duplicates:":                         "重複:",
+	"ERROR":                               "エラー",
+	"Estimate":                            "見積もり",
+	"Estimate(Minutes)":                   "見積もり(分)",
+	"Export":                              "エクスポート",
+	"Export CSV":                          "CSVをエクスポート",
+	"Export Timewarrior":                  "Timewarriorエクスポート",
+	"Export iCalendar":                    "iCalendarエクスポート",
+	"Exported %d intervals to %s":         "%d件の区間を %s にエクスポートしました",
+	"Exported %d intervals to %s; %d works have %s without intervals, which is not exported": "%d件の区間を %s にエクスポートしました。%d件の作業の %s は区間がないためエクスポートされていません",
+	"Exported to %s":                  "エクスポートしました: %s",
+	"File":                            "ファイル",
+	"Format":                          "形式",
+	"From(YYYY/MM/DD)":                "開始日(YYYY/MM/DD)",
+	"Global":                          "全体",
+	"Go":                              "移動",
+	"Go to %s":                        "%sへ移動",
+	"Go to bottom":                    "末尾へ移動",
+	"Go to date":                      "日付へ移動",
+	"Go to top":                       "先頭へ移動",
+	"Hour(0-)":                        "時(0-)",
+	"ID":                              "ID",
+	"INFO":                            "情報",
+	"Import":                          "インポート",
+	"Imported %d works":               "%d件の作業をインポートしました",
+	"Language(Applied On Restart) : ": "言語(再起動後に反映) : ",
+	"Last 30 days":                    "過去30日",
+	"Last Created":                    "最終作成日",
+	"Last month":                      "先月",
+	"Last week":                       "先週",
+	"Less %s More  %s all confirmed":  "少 %s 多  %s すべて確定",
+	"Level":                           "レベル",
+	"Mark/unmark work":                "作業をマーク/マーク解除",
+	"Marked: %d":                      "マーク: %d件",
+	"Menu":                            "メニュー",
+	"Message":                         "メッセージ",
+	"Minute(0-59)":                    "分(0-59)",
+	"Move to Date":                    "日付を移動",
+	"Name":                            "名前",
+	"New":                             "変更後",
+	"Next day":                        "翌日",
+	"Next month/year":                 "次の月/年",
+	"Next week":                       "翌週",
+	"No":                              "いいえ",
+	"No project":                      "プロジェクトなし",
+	"No rules in %s; events get no project or tag.": "%s にルールがないため、予定にプロジェクトとタグは設定されません。",
+	"No tag":                              "タグなし",
+	"No tracked time in this range.":      "この期間の作業時間はありません。",
+	"No tracked time on this day.":        "この日の計測記録はありません。",
+	"Not Select":                          "未選択",
+	"Notifications":                       "通知履歴",
+	"Old":                                 "変更前",
+	"Period closed (%d works confirmed).": "期間を締めました（%d件を確定）。",
+	"Permission denied (confirmed works and closed periods cannot be changed).": "権限がありません（確定済みの作業や締めた期間は変更できません）。",
+	"Person Day : ":       "人日の時間数 : ",
+	"Previous day":        "前日",
+	"Previous month/year": "前の月/年",
+	"Previous week":       "前週",
+	"Project":             "プロジェクト",
+	"Project Name : ":     "プロジェクト名 : ",
+	"Project form":        "プロジェクトフォーム",
+	"Projects":            "プロジェクト",
+	"Quick Add":           "クイック追加",
+	"Quick add work":      "作業をクイック追加",
+	"Quick add work (title @project #tag ~30m)": "作業をクイック追加（タイトル @プロジェクト #タグ ~30m）",
+	"Quit":                 "終了",
+	"Range":                "期間",
+	"Reassign Project/Tag": "プロジェクト/タグを変更",
+	"Recurrence":           "繰り返し",
+	"Recurrence(daily/weekdays/mon,thu/monthly:1) : ": "繰り返し(daily/weekdays/mon,thu/monthly:1) : ",
+	"Reopen":                              "再オープン",
+	"Reset":                               "リセット",
+	"Reset total time":                    "作業時間をリセット",
+	"Rules of %s:":                        "%s のルール:",
+	"Save":                                "保存",
+	"Search":                              "検索",
+	"Search works":                        "作業を検索",
+	"Search: %s":                          "検索: %s",
+	"Second(0-59)":                        "秒(0-59)",
+	"Selected Tags":                       "選択中のタグ",
+	"Setting":                             "設定",
+	"Settings saved.":                     "設定を保存しました。",
+	"Show":                                "表示",
+	"Show Relative Date(0:Today Only) : ": "表示する過去日数(0:今日のみ) : ",
+	"Show audit log":                      "監査ログを表示",
+	"Show shortcuts":                      "ショートカットを表示",
+	"Show shortcuts from a text input":    "入力欄からショートカットを表示",
+	"Show today":                          "今日を表示",
+	"Show works of the day":               "その日の作業を表示",
+	"Skipped:":                            "スキップ:",
+	"Start Tracking":                      "追跡を開始",
+	"Start tracking: %s":                  "追跡開始: %s",
+	"Start/end range selection":           "範囲選択の開始/終了",
+	"Start/stop tracking (copy past work to today)": "追跡の開始/停止（過去の作業は今日にコピー）",
+	"Stop tracking: %s":                             "追跡停止: %s",
+	"Store":                                         "保存",
+	"Switch month grid/year heatmap":                "月表示/年ヒートマップを切り替え",
+	"Switch working hours/24 hours":                 "業務時間/24時間表示を切り替え",
+	"TRACKING":                                      "追跡中",
+	"Tag":                                           "タグ",
+	"Tag %s added to project %s.":                   "タグ %s をプロジェクト %s に追加しました。",
+	"Tag Name : ":                                   "タグ名 : ",
+	"Tag form":                                      "タグフォーム",
+	"Tags":                                          "タグ",
+	"Tags : ":                                       "タグ : ",
+	"Template form":                                 "テンプレートフォーム",
+	"Templates":                                     "テンプレート",
+	"The data was not found.":                       "データが見つかりませんでした。",
+	"The input is invalid.":                         "入力内容に誤りがあります。",
+	"The input is invalid: %s":                      "入力内容に誤りがあります: %s",
+	"Theme(Applied On Restart) : ":                  "テーマ(再起動後に反映) : ",
+	"There are no tracked intervals to export.":        "エクスポートする計測区間がありません。",
+	"There are no works to export.":                    "エクスポートする作業がありません。",
+	"This month":                                       "今月",
+	"This week":                                        "今週",
+	"This work has already been created on that date.": "この作業は指定日に既に作成されています。",
+	"This work has already been created today.":        "この作業は今日既に作成されています。",
+	"This work is confirmed and locked. Reopen it?":    "この作業は確定済みでロックされています。再オープンしますか？",
+	"Time":                                "日時",
+	"Time(H:MM, 1h30m or minutes)":        "時間(H:MM、1h30m または分)",
+	"Timeline":                            "タイムライン",
+	"Timer : ":                            "タイマー : ",
+	"Timesheet":                           "週次タイムシート",
+	"Timesheet form":                      "タイムシートフォーム",
+	"Title":                               "タイトル",
+	"Title : ":                            "タイトル : ",
+	"To(YYYY/MM/DD)":                      "終了日(YYYY/MM/DD)",
+	"Today is %s (%s)":                    "今日は %s（%s）",
+	"Total":                               "合計",
+	"TotalTime":                           "作業時間",
+	"Unmapped:":                           "対応なし:",
+	"Update":                              "更新",
+	"Update project":                      "プロジェクトを編集",
+	"Update tag":                          "タグを編集",
+	"Update template":                     "テンプレートを編集",
+	"Update work":                         "作業を編集",
+	"WARN":                                "警告",
+	"Work":                                "作業",
+	"Work ID":                             "作業ID",
+	"Work form":                           "作業フォーム",
+	"Works":                               "作業一覧",
+	"Yes":                                 "はい",
+	"a space is required after %s":        "%s の後には空白が必要です",
+	"backup %s is damaged: %v":            "バックアップ %s が破損しています: %v",
+	"backup %s is not a gzip archive":     "バックアップ %s はgzipアーカイブではありません",
+	"cannot create work on a future date": "未来の日付には作業を作成できません",
+	"cannot move a tracking work":         "追跡中の作業は移動できません",
+	"cannot move work to a future date":   "作業を未来の日付に移動できません",
+	"count:%d":                            "件数:%d",
+	"empty name in %s":                    "%s の名前が空です",
+	"estimate must not be negative":       "見積もり時間は0以上にしてください",
+	"import rule %q: tag %q is not allowed on project %q": "インポートルール %q: タグ %q はプロジェクト %q で使用できません",
+	"import rule %q: unknown project %q":                  "インポートルール %q: プロジェクト %q が見つかりません",
+	"import rule has no keyword":                          "キーワードのないインポートルールがあります",
+	"invalid date format":                                 "日付の形式が正しくありません",
+	"invalid day of month in recurrence: %s":              "繰り返しの日付が正しくありません: %s",
+	"invalid day of the week":                             "曜日が正しくありません",
+	"invalid estimate":                                    "見積もり時間が正しくありません",
+	"invalid recurrence: %s":                              "繰り返しの指定が正しくありません: %s",
+	"invalid spent time ~%s":                              "費やした時間 ~%s が正しくありません",
+	"invalid time: %s":                                    "時間が正しくありません: %s",
+	"invalid work id":                                     "作業IDが正しくありません",
+	"only one %s is allowed":                              "%s は1つだけ指定できます",
+	"recurrence is empty":                                 "繰り返しが指定されていません",
+	"spent time must be positive":                         "費やした時間は0より大きくしてください",
+	"stop tracking the work before changing its time":     "時間を変更する前に作業の追跡を停止してください",
+	"tag #%s is not allowed on project @%s":               "タグ #%s はプロジェクト @%s で使用できません",
+	"tag #%s requires a project":                          "タグ #%s にはプロジェクトの指定が必要です",
+	"tag %s is not allowed on project %s":                 "タグ %s はプロジェクト %s で使用できません",
+	"tag is not allowed on project %s":                    "タグはプロジェクト %s で使用できません",
+	"tag name is empty":                                   "タグ名が空です",
+	"tag requires a project":                              "タグにはプロジェクトの指定が必要です",
+	"the calendar cannot be read: %v":                     "カレンダーを読み込めません: %v",
+	"the day has several works with this title; change them in the work table": "この日には同じタイトルの作業が複数あります。作業一覧で変更してください",
+	"the end date is before the start date":                                    "終了日が開始日より前です",
+	"the interval must end after it starts":                                    "区間の終了は開始より後にしてください",
+	"the number of backups to keep must not be negative":                       "保持するバックアップ数は0以上にしてください",
+	"the report cannot be read: %v":                                            "レポートを読み込めません: %v",
+	"time must not be negative":                                                "時間は0以上にしてください",
+	"title is empty":                                                           "タイトルが空です",
+	"unknown project @%s":                                                      "プロジェクト @%s が見つかりません",
+	"unknown project: %s":                                                      "プロジェクトが見つかりません: %s",
+	"unterminated quote in %s":                                                 "%s の引用符が閉じられていません",
+	"work is already today":                                                    "作業はすでに今日の日付です",
+	"work is not confirmed":                                                    "作業は確定されていません",
+}
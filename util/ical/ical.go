@@ -0,0 +1,101 @@
+// Package ical reads and writes the events of iCalendar (RFC 5545) files.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// utcFormat is the format of the UTC date-times written to iCalendar files.
+const utcFormat = "20060102T150405Z"
+
+// maxLineOctets is the maximum length of a content line before it is folded.
+const maxLineOctets = 75
+
+// Event is a VEVENT of an iCalendar file.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Categories  []string
+	Start       time.Time
+	End         time.Time
+	// AllDay is set for the events whose start is a date without a time.
+	AllDay bool
+	// Status is the STATUS of the event, such as "CANCELLED".
+	Status string
+	// RRule is the recurrence rule of a recurring event, such as "FREQ=WEEKLY;BYDAY=MO".
+	RRule string
+	// ExDates are the starts of the occurrences excluded from the recurrence.
+	ExDates []time.Time
+	// RecurrenceID is the start of the occurrence of a recurring event that this event replaces.
+	RecurrenceID time.Time
+}
+
+// Write writes the events as an iCalendar file. stamp is the DTSTAMP of the events.
+func Write(w io.Writer, events []Event, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//chronowork//chronowork//EN")
+	line("CALSCALE", "GREGORIAN")
+	for _, event := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(event.UID))
+		line("DTSTAMP", stamp.UTC().Format(utcFormat))
+		line("DTSTART", event.Start.UTC().Format(utcFormat))
+		line("DTEND", event.End.UTC().Format(utcFormat))
+		line("SUMMARY", escape(event.Summary))
+		if len(event.Categories) > 0 {
+			categories := make([]string, len(event.Categories))
+			for i, category := range event.Categories {
+				categories[i] = escape(category)
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// writeFolded writes a content line, folding it into lines of at most
+// maxLineOctets octets without splitting UTF-8 characters.
+func writeFolded(w *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(line + "\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// escape escapes a TEXT value.
+func escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
@@ -0,0 +1,62 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	events := []Event{{
+		UID:         "interval-1@chronowork",
+		Summary:     "Review; design, notes",
+		Description: "Duration: 00:30:00\nWork total: 01:00:00",
+		Categories:  []string{"web", "review"},
+		Start:       start,
+		End:         start.Add(30 * time.Minute),
+	}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, events, start); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"BEGIN:VEVENT\r\nUID:interval-1@chronowork\r\n",
+		"DTSTART:20240501T090000Z\r\nDTEND:20240501T093000Z\r\n",
+		`SUMMARY:Review\; design\, notes` + "\r\n",
+		"CATEGORIES:web,review\r\n",
+		`DESCRIPTION:Duration: 00:30:00\nWork total: 01:00:00` + "\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteFoldsLongLines(t *testing.T) {
+	var buf bytes.Buffer
+	summary := strings.Repeat("作業", 40)
+	if err := Write(&buf, []Event{{Summary: summary}}, time.Now()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	var unfolded strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line longer than %d octets: %q", maxLineOctets, line)
+		}
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+		} else {
+			unfolded.WriteString("\n" + line)
+		}
+	}
+	if !strings.Contains(unfolded.String(), "\nSUMMARY:"+summary+"\n") {
+		t.Errorf("expected the summary to unfold to the original text, got %q", unfolded.String())
+	}
+}
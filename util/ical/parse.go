@@ -0,0 +1,271 @@
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	localFormat = "20060102T150405"
+	dateFormat  = "20060102"
+)
+
+// property is a content line split into its name, parameters and value.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse reads the events of an iCalendar file. Date-times without a time zone
+// and dates are read in the local time zone, and the components nested in
+// events, such as alarms, are ignored.
+func Parse(r io.Reader) ([]Event, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var event *Event
+	var hasEnd bool
+	var duration time.Duration
+	nested := 0
+	for i, line := range unfold(string(data)) {
+		p, ok := parseProperty(line)
+		if !ok {
+			return nil, fmt.Errorf("invalid iCalendar line %d: %q", i+1, line)
+		}
+		switch {
+		case p.name == "BEGIN" && p.value == "VEVENT" && event == nil:
+			event = &Event{}
+			hasEnd, duration = false, 0
+			continue
+		case event == nil:
+			continue
+		case p.name == "BEGIN":
+			nested++
+			continue
+		case p.name == "END" && nested > 0:
+			nested--
+			continue
+		case nested > 0:
+			continue
+		case p.name == "END" && p.value == "VEVENT":
+			if !hasEnd {
+				switch {
+				case duration > 0:
+					event.End = event.Start.Add(duration)
+				case event.AllDay:
+					event.End = event.Start.AddDate(0, 0, 1)
+				default:
+					event.End = event.Start
+				}
+			}
+			events = append(events, *event)
+			event = nil
+			continue
+		}
+
+		switch p.name {
+		case "UID":
+			event.UID = p.value
+		case "SUMMARY":
+			event.Summary = unescape(p.value)
+		case "DESCRIPTION":
+			event.Description = unescape(p.value)
+		case "CATEGORIES":
+			for _, category := range splitEscaped(p.value) {
+				event.Categories = append(event.Categories, unescape(category))
+			}
+		case "STATUS":
+			event.Status = strings.ToUpper(p.value)
+		case "RRULE":
+			event.RRule = p.value
+		case "DTSTART", "DTEND", "EXDATE", "RECURRENCE-ID":
+			var times []time.Time
+			for _, value := range strings.Split(p.value, ",") {
+				t, allDay, err := parseTime(value, p.params)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s on line %d: %w", p.name, i+1, err)
+				}
+				if p.name == "DTSTART" {
+					event.AllDay = allDay
+				}
+				times = append(times, t)
+			}
+			switch p.name {
+			case "DTSTART":
+				event.Start = times[0]
+			case "DTEND":
+				event.End = times[0]
+				hasEnd = true
+			case "EXDATE":
+				event.ExDates = append(event.ExDates, times...)
+			case "RECURRENCE-ID":
+				event.RecurrenceID = times[0]
+			}
+		case "DURATION":
+			if duration, err = parseDuration(p.value); err != nil {
+				return nil, fmt.Errorf("invalid DURATION on line %d: %w", i+1, err)
+			}
+		}
+	}
+	return events, nil
+}
+
+// unfold joins the folded content lines and drops the empty lines.
+func unfold(data string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseProperty splits a content line such as "DTSTART;TZID=Asia/Tokyo:20240501T090000".
+func parseProperty(line string) (property, bool) {
+	p := property{params: make(map[string]string)}
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return p, false
+	}
+
+	p.value = line[colon+1:]
+	fields := strings.Split(line[:colon], ";")
+	p.name = strings.ToUpper(fields[0])
+	for _, field := range fields[1:] {
+		if key, value, ok := strings.Cut(field, "="); ok {
+			p.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return p, true
+}
+
+// parseTime parses a DATE or DATE-TIME value and reports whether it is a date.
+func parseTime(value string, params map[string]string) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if params["VALUE"] == "DATE" || len(value) == len(dateFormat) {
+		t, err := time.ParseInLocation(dateFormat, value, time.Local)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcFormat, value)
+		return t.Local(), false, err
+	}
+	location := time.Local
+	if tzid := params["TZID"]; tzid != "" {
+		// time zones unknown to the system, such as Windows names, fall back to local time
+		if l, err := time.LoadLocation(tzid); err == nil {
+			location = l
+		}
+	}
+	t, err := time.ParseInLocation(localFormat, value, location)
+	return t.Local(), false, err
+}
+
+// parseDuration parses a duration such as "PT1H30M" or "P1D".
+func parseDuration(value string) (time.Duration, error) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(value, "+"), "P")
+	if rest == value || strings.HasPrefix(value, "-") {
+		return 0, fmt.Errorf("unsupported duration %q", value)
+	}
+	var duration time.Duration
+	inTime := false
+	number := ""
+	for _, r := range rest {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		number = ""
+		switch {
+		case r == 'W':
+			duration += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D':
+			duration += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			duration += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			duration += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			duration += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return duration, nil
+}
+
+// splitEscaped splits a list of TEXT values at the commas that are not escaped.
+func splitEscaped(value string) []string {
+	var fields []string
+	var field strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			field.WriteRune('\\')
+			field.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteRune(r)
+		}
+	}
+	return append(fields, field.String())
+}
+
+// unescape unescapes a TEXT value.
+func unescape(text string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range text {
+		switch {
+		case escaped && (r == 'n' || r == 'N'):
+			b.WriteRune('\n')
+			escaped = false
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
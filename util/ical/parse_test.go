@@ -0,0 +1,101 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:standup@example.com\r\n" +
+	"DTSTART;TZID=UTC:20240506T090000\r\n" +
+	"DTEND;TZID=UTC:20240506T091500\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE\r\n" +
+	"EXDATE;TZID=UTC:20240508T090000\r\n" +
+	"SUMMARY:Daily\\, stand-up\r\n" +
+	"CATEGORIES:web,meet\\,ing\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"SUMMARY:Alarm\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:review@example.com\r\n" +
+	"DTSTART:20240507T130000Z\r\n" +
+	"DURATION:PT1H30M\r\n" +
+	"SUMMARY:Design review with a long title that is folded onto\r\n" +
+	"  the next line\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:holiday@example.com\r\n" +
+	"DTSTART;VALUE=DATE:20240510\r\n" +
+	"SUMMARY:Holiday\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	events, err := Parse(strings.NewReader(sampleCalendar))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+
+	standup := events[0]
+	if standup.Summary != "Daily, stand-up" {
+		t.Errorf("expected the summary to be unescaped, got %q", standup.Summary)
+	}
+	if len(standup.Categories) != 2 || standup.Categories[1] != "meet,ing" {
+		t.Errorf("expected the categories [web meet,ing], got %q", standup.Categories)
+	}
+	if !standup.Start.Equal(time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)) || standup.End.Sub(standup.Start) != 15*time.Minute {
+		t.Errorf("unexpected times %v - %v", standup.Start, standup.End)
+	}
+	if standup.RRule != "FREQ=WEEKLY;BYDAY=MO,WE" || len(standup.ExDates) != 1 {
+		t.Errorf("unexpected recurrence %q %v", standup.RRule, standup.ExDates)
+	}
+
+	review := events[1]
+	if review.Summary != "Design review with a long title that is folded onto the next line" {
+		t.Errorf("expected the summary to be unfolded, got %q", review.Summary)
+	}
+	if review.Status != "CANCELLED" || review.End.Sub(review.Start) != 90*time.Minute {
+		t.Errorf("unexpected status %q or duration %v", review.Status, review.End.Sub(review.Start))
+	}
+
+	holiday := events[2]
+	if !holiday.AllDay || holiday.End.Sub(holiday.Start) != 24*time.Hour {
+		t.Errorf("expected an all-day event, got %+v", holiday)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, data := range []string{
+		"BEGIN:VEVENT\r\nDTSTART:2024-05-01\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nDURATION:1 hour\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nno colon\r\nEND:VEVENT\r\n",
+	} {
+		if _, err := Parse(strings.NewReader(data)); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT1H30M": 90 * time.Minute,
+		"PT45S":   45 * time.Second,
+		"P1D":     24 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"P1DT2H":  26 * time.Hour,
+	}
+	for value, want := range tests {
+		got, err := parseDuration(value)
+		if err != nil || got != want {
+			t.Errorf("parseDuration(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+}
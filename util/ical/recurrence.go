@@ -0,0 +1,221 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxIterations bounds the expansion of a recurrence rule.
+const maxIterations = 10000
+
+// weekdays maps the BYDAY values of a recurrence rule to weekdays.
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// rule is a parsed recurrence rule.
+type rule struct {
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	byDay      []time.Weekday
+	byMonthDay []int
+}
+
+// Occurrences returns the occurrences of the events that start from start up
+// to but excluding end, expanding the recurring events. Occurrences excluded
+// by EXDATE or replaced by an event with the same UID and a RECURRENCE-ID are
+// dropped. The recurring events whose rule is not supported are returned as
+// unsupported instead.
+func Occurrences(events []Event, start, end time.Time) ([]Event, []Event) {
+	overridden := make(map[string]bool)
+	for _, event := range events {
+		if !event.RecurrenceID.IsZero() {
+			overridden[occurrenceKey(event.UID, event.RecurrenceID)] = true
+		}
+	}
+
+	var occurrences, unsupported []Event
+	for _, event := range events {
+		if event.RRule == "" || !event.RecurrenceID.IsZero() {
+			if !event.Start.Before(start) && event.Start.Before(end) {
+				occurrences = append(occurrences, event)
+			}
+			continue
+		}
+
+		r, err := parseRule(event.RRule)
+		if err != nil {
+			unsupported = append(unsupported, event)
+			continue
+		}
+		excluded := make(map[int64]bool)
+		for _, exDate := range event.ExDates {
+			excluded[exDate.Unix()] = true
+		}
+		length := event.End.Sub(event.Start)
+		for _, occurrenceStart := range r.starts(event.Start, end) {
+			if occurrenceStart.Before(start) || excluded[occurrenceStart.Unix()] || overridden[occurrenceKey(event.UID, occurrenceStart)] {
+				continue
+			}
+			occurrence := event
+			occurrence.Start = occurrenceStart
+			occurrence.End = occurrenceStart.Add(length)
+			occurrence.RRule = ""
+			occurrence.ExDates = nil
+			occurrence.RecurrenceID = occurrenceStart
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences, unsupported
+}
+
+func occurrenceKey(uid string, start time.Time) string {
+	return uid + "@" + strconv.FormatInt(start.Unix(), 10)
+}
+
+// parseRule parses the DAILY, WEEKLY and MONTHLY rules. BYDAY is supported
+// without ordinals and BYMONTHDAY with positive days.
+func parseRule(value string) (rule, error) {
+	r := rule{interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return r, fmt.Errorf("invalid recurrence rule %q", value)
+		}
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.freq = strings.ToUpper(val)
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(val)
+			if err == nil && r.interval < 1 {
+				err = fmt.Errorf("invalid interval %q", val)
+			}
+		case "COUNT":
+			r.count, err = strconv.Atoi(val)
+		case "UNTIL":
+			r.until, _, err = parseTime(val, nil)
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(val), ",") {
+				weekday, ok := weekdays[day]
+				if !ok {
+					return r, fmt.Errorf("unsupported BYDAY %q", val)
+				}
+				r.byDay = append(r.byDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(day)
+				if err != nil || n < 1 || n > 31 {
+					return r, fmt.Errorf("unsupported BYMONTHDAY %q", val)
+				}
+				r.byMonthDay = append(r.byMonthDay, n)
+			}
+		case "WKST":
+		default:
+			return r, fmt.Errorf("unsupported recurrence rule part %q", part)
+		}
+		if err != nil {
+			return r, err
+		}
+	}
+	switch {
+	case r.freq != "DAILY" && r.freq != "WEEKLY" && r.freq != "MONTHLY":
+		return r, fmt.Errorf("unsupported frequency %q", r.freq)
+	case len(r.byDay) > 0 && r.freq != "WEEKLY":
+		return r, fmt.Errorf("unsupported BYDAY with frequency %q", r.freq)
+	case len(r.byMonthDay) > 0 && r.freq != "MONTHLY":
+		return r, fmt.Errorf("unsupported BYMONTHDAY with frequency %q", r.freq)
+	}
+	return r, nil
+}
+
+// starts returns the starts of the occurrences from first up to but excluding end.
+func (r rule) starts(first, end time.Time) []time.Time {
+	var starts []time.Time
+	n := 0
+	// add reports whether the expansion continues after t.
+	add := func(t time.Time) bool {
+		if t.Before(first) {
+			return true
+		}
+		if !t.Before(end) || (!r.until.IsZero() && t.After(r.until)) || (r.count > 0 && n >= r.count) {
+			return false
+		}
+		starts = append(starts, t)
+		n++
+		return true
+	}
+
+	hour, minute, second := first.Clock()
+	for i := 0; i < maxIterations; i++ {
+		switch r.freq {
+		case "DAILY":
+			if !add(first.AddDate(0, 0, i*r.interval)) {
+				return starts
+			}
+		case "WEEKLY":
+			if len(r.byDay) == 0 {
+				if !add(first.AddDate(0, 0, 7*i*r.interval)) {
+					return starts
+				}
+				continue
+			}
+			// weeks start on Monday
+			monday := first.AddDate(0, 0, -(int(first.Weekday())+6)%7+7*i*r.interval)
+			for offset := 0; offset < 7; offset++ {
+				day := monday.AddDate(0, 0, offset)
+				if containsWeekday(r.byDay, day.Weekday()) && !add(day) {
+					return starts
+				}
+			}
+		case "MONTHLY":
+			year, month, _ := first.Date()
+			monthStart := time.Date(year, month+time.Month(i*r.interval), 1, hour, minute, second, 0, first.Location())
+			days := r.byMonthDay
+			if len(days) == 0 {
+				days = []int{first.Day()}
+			}
+			for _, day := range sortedDays(days) {
+				t := monthStart.AddDate(0, 0, day-1)
+				// months without the day are skipped
+				if t.Month() != monthStart.Month() {
+					continue
+				}
+				if !add(t) {
+					return starts
+				}
+			}
+		}
+	}
+	return starts
+}
+
+func containsWeekday(days []time.Weekday, weekday time.Weekday) bool {
+	for _, day := range days {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedDays(days []int) []int {
+	sorted := append([]int(nil), days...)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && sorted[j] < sorted[j-1]; j-- {
+			sorted[j], sorted[j-1] = sorted[j-1], sorted[j]
+		}
+	}
+	return sorted
+}
@@ -0,0 +1,102 @@
+package ical
+
+import (
+	"testing"
+	"time"
+)
+
+func at(day, hour int) time.Time {
+	return time.Date(2024, 5, day, hour, 0, 0, 0, time.UTC)
+}
+
+func starts(events []Event) []time.Time {
+	var times []time.Time
+	for _, event := range events {
+		times = append(times, event.Start)
+	}
+	return times
+}
+
+func assertStarts(t *testing.T, got []Event, want ...time.Time) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, starts(got))
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i]) {
+			t.Fatalf("expected %v, got %v", want, starts(got))
+		}
+	}
+}
+
+func TestOccurrencesWeekly(t *testing.T) {
+	events := []Event{
+		{
+			UID:     "standup",
+			Start:   at(6, 9),
+			End:     at(6, 10),
+			RRule:   "FREQ=WEEKLY;BYDAY=MO,WE",
+			ExDates: []time.Time{at(8, 9)},
+		},
+		// the occurrence of 13 May moved to the afternoon
+		{UID: "standup", Start: at(13, 15), End: at(13, 16), RecurrenceID: at(13, 9)},
+	}
+
+	got, unsupported := Occurrences(events, at(6, 0), at(17, 0))
+	if len(unsupported) != 0 {
+		t.Fatalf("expected no unsupported events, got %v", unsupported)
+	}
+	assertStarts(t, got, at(6, 9), at(15, 9), at(13, 15))
+	if got[0].End.Sub(got[0].Start) != time.Hour || got[0].RRule != "" {
+		t.Errorf("expected an hour long occurrence without the rule, got %+v", got[0])
+	}
+}
+
+func TestOccurrencesLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		event      Event
+		start, end time.Time
+		want       []time.Time
+	}{
+		{
+			name:  "daily with interval and count",
+			event: Event{Start: at(1, 9), End: at(1, 10), RRule: "FREQ=DAILY;INTERVAL=2;COUNT=3"},
+			start: at(1, 0),
+			end:   at(31, 0),
+			want:  []time.Time{at(1, 9), at(3, 9), at(5, 9)},
+		},
+		{
+			name:  "daily until",
+			event: Event{Start: at(1, 9), End: at(1, 10), RRule: "FREQ=DAILY;UNTIL=20240502T090000Z"},
+			start: at(1, 0),
+			end:   at(31, 0),
+			want:  []time.Time{at(1, 9), at(2, 9)},
+		},
+		{
+			name:  "monthly on the day of the start",
+			event: Event{Start: time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC), RRule: "FREQ=MONTHLY"},
+			// months without the 31st are skipped
+			start: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			want:  []time.Time{time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC), at(31, 9)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := Occurrences([]Event{tt.event}, tt.start, tt.end)
+			assertStarts(t, got, tt.want...)
+		})
+	}
+}
+
+func TestOccurrencesUnsupported(t *testing.T) {
+	events := []Event{
+		{Start: at(1, 9), End: at(1, 10), RRule: "FREQ=YEARLY"},
+		{Start: at(1, 9), End: at(1, 10), RRule: "FREQ=MONTHLY;BYDAY=1MO"},
+	}
+	got, unsupported := Occurrences(events, at(1, 0), at(31, 0))
+	if len(got) != 0 || len(unsupported) != 2 {
+		t.Errorf("expected 2 unsupported events, got %d occurrences and %d unsupported", len(got), len(unsupported))
+	}
+}
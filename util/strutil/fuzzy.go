@@ -0,0 +1,64 @@
+package strutil
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// FuzzyMatch reports whether all characters of pattern appear in text in order,
+// ignoring case. The score is higher for consecutive characters and for
+// characters at the start of words, so better matches can be listed first.
+func FuzzyMatch(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return 0, true
+	}
+	t := []rune(strings.ToLower(text))
+
+	score, pi, prev := 0, 0, -2
+	for ti, r := range t {
+		if pi == len(p) {
+			break
+		}
+		if r != p[pi] {
+			continue
+		}
+		score++
+		if ti == prev+1 {
+			score += 2
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3
+		}
+		prev = ti
+		pi++
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	return score, true
+}
+
+// FuzzyFilter returns the indexes of the candidates matching pattern, best matches first.
+// Candidates with the same score keep their original order.
+func FuzzyFilter(pattern string, candidates []string) []int {
+	type match struct {
+		index int
+		score int
+	}
+	var matches []match
+	for i, c := range candidates {
+		if score, ok := FuzzyMatch(pattern, c); ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	result := make([]int, len(matches))
+	for i, m := range matches {
+		result[i] = m.index
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package strutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		text     string
+		expected bool
+	}{
+		{"", "anything", true},
+		{"gtw", "Go to Works", true},
+		{"WORKS", "Go to Works", true},
+		{"wg", "Go to Works", false},
+		{"xyz", "Go to Works", false},
+	}
+
+	for _, tc := range tests {
+		_, ok := FuzzyMatch(tc.pattern, tc.text)
+		if ok != tc.expected {
+			t.Errorf("FuzzyMatch(%q, %q) = %v, want %v", tc.pattern, tc.text, ok, tc.expected)
+		}
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	candidates := []string{"Go to Setting", "Export CSV", "Go to Tags", "Start tracking: test"}
+
+	result := FuzzyFilter("gt", candidates)
+	// word starts ("Go to") rank above the scattered match in "Start tracking"
+	expected := []int{0, 2, 3}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("FuzzyFilter(%q) = %v, want %v", "gt", result, expected)
+	}
+
+	result = FuzzyFilter("", candidates)
+	if !reflect.DeepEqual(result, []int{0, 1, 2, 3}) {
+		t.Errorf("FuzzyFilter with empty pattern should keep all candidates, got %v", result)
+	}
+}
@@ -13,3 +13,25 @@ func RemoveDuplicates(input []string) []string {
 	}
 	return result
 }
+
+// MoveToFront returns items with the ones listed in front placed first, in the
+// order of front. Entries of front that are not in items are ignored.
+func MoveToFront(items, front []string) []string {
+	rest := make(map[string]bool, len(items))
+	for _, item := range items {
+		rest[item] = true
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range front {
+		if rest[item] {
+			result = append(result, item)
+			delete(rest, item)
+		}
+	}
+	for _, item := range items {
+		if rest[item] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
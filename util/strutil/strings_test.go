@@ -0,0 +1,18 @@
+package strutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMoveToFront(t *testing.T) {
+	items := []string{"Alpha", "Beta", "Gamma", "Delta"}
+	got := MoveToFront(items, []string{"Gamma", "Unknown", "Alpha"})
+	want := []string{"Gamma", "Alpha", "Beta", "Delta"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MoveToFront() = %v, want %v", got, want)
+	}
+	if got := MoveToFront(items, nil); !reflect.DeepEqual(got, items) {
+		t.Errorf("MoveToFront() without front = %v, want %v", got, items)
+	}
+}
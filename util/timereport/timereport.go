@@ -0,0 +1,171 @@
+// Package timereport reads the detailed reports exported as CSV by time
+// trackers such as Toggl Track and Clockify.
+package timereport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Format is the time tracker that exported a report.
+type Format string
+
+const (
+	// Toggl is the detailed report of Toggl Track.
+	Toggl Format = "toggl"
+	// Clockify is the detailed report of Clockify.
+	Clockify Format = "clockify"
+)
+
+// Formats are the supported report formats.
+var Formats = []Format{Toggl, Clockify}
+
+// Entry is a time entry of a report.
+type Entry struct {
+	// Line is the line of the entry in the report, the header being line 1.
+	Line        int
+	Client      string
+	Project     string
+	Description string
+	Tags        []string
+	Start       time.Time
+	End         time.Time
+}
+
+// Duration returns the tracked time of the entry.
+func (e Entry) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
+
+// layout is the columns and date and time layouts of a report format.
+type layout struct {
+	client, project, description, tags string
+	startDate, startTime               string
+	endDate, endTime                   string
+	dateLayouts, timeLayouts           []string
+}
+
+var layouts = map[Format]layout{
+	Toggl: {
+		client:      "Client",
+		project:     "Project",
+		description: "Description",
+		tags:        "Tags",
+		startDate:   "Start date",
+		startTime:   "Start time",
+		endDate:     "End date",
+		endTime:     "End time",
+		dateLayouts: []string{"2006-01-02"},
+		timeLayouts: []string{"15:04:05"},
+	},
+	// Clockify formats the dates and times with the settings of the workspace,
+	// so the common ones are accepted. Slashed dates are read month first.
+	Clockify: {
+		client:      "Client",
+		project:     "Project",
+		description: "Description",
+		tags:        "Tags",
+		startDate:   "Start Date",
+		startTime:   "Start Time",
+		endDate:     "End Date",
+		endTime:     "End Time",
+		dateLayouts: []string{"01/02/2006", "2006-01-02", "02.01.2006"},
+		timeLayouts: []string{"03:04:05 PM", "03:04 PM", "15:04:05", "15:04"},
+	},
+}
+
+// ParseFormat returns the format named name.
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := layouts[format]; !ok {
+		return "", fmt.Errorf("unknown report format %q", name)
+	}
+	return format, nil
+}
+
+// Parse reads the entries of a detailed report in format. The columns are
+// found by name in the header, ignoring case, and the other columns are
+// ignored. Times are read in the local time zone.
+func Parse(r io.Reader, format Format) ([]Entry, error) {
+	l, ok := layouts[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown report format %q", format)
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("empty %s report", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// spreadsheets often save a byte order mark before the header
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{l.project, l.description, l.startDate, l.startTime, l.endDate, l.endTime} {
+		if _, ok := columns[strings.ToLower(name)]; !ok {
+			return nil, fmt.Errorf("not a %s detailed report: no %q column", format, name)
+		}
+	}
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			i, ok := columns[strings.ToLower(name)]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		entry := Entry{
+			Line:        line,
+			Client:      field(l.client),
+			Project:     field(l.project),
+			Description: field(l.description),
+		}
+		for _, tag := range strings.Split(field(l.tags), ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				entry.Tags = append(entry.Tags, tag)
+			}
+		}
+		if entry.Start, err = parseDateTime(field(l.startDate), field(l.startTime), l); err != nil {
+			return nil, fmt.Errorf("line %d: invalid start: %w", line, err)
+		}
+		// running entries have no end yet
+		if field(l.endDate) == "" && field(l.endTime) == "" {
+			entry.End = entry.Start
+		} else if entry.End, err = parseDateTime(field(l.endDate), field(l.endTime), l); err != nil {
+			return nil, fmt.Errorf("line %d: invalid end: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func parseDateTime(date, clock string, l layout) (time.Time, error) {
+	for _, dateLayout := range l.dateLayouts {
+		for _, timeLayout := range l.timeLayouts {
+			if t, err := time.ParseInLocation(dateLayout+" "+timeLayout, date+" "+clock, time.Local); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q", date+" "+clock)
+}
@@ -0,0 +1,94 @@
+package timereport
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseFixture(t *testing.T, name string, format Format) []Entry {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	entries, err := Parse(f, format)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return entries
+}
+
+func local(day, hour, minute int) time.Time {
+	return time.Date(2024, 5, day, hour, minute, 0, 0, time.Local)
+}
+
+func TestParseToggl(t *testing.T) {
+	entries := parseFixture(t, "toggl_detailed.csv", Toggl)
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 entries, got %d", len(entries))
+	}
+
+	first := entries[0]
+	expected := Entry{
+		Line:        2,
+		Client:      "Acme",
+		Project:     "Website",
+		Description: "Fix login bug",
+		Tags:        []string{"bug", "frontend"},
+		Start:       local(6, 9, 0),
+		End:         local(6, 10, 30),
+	}
+	if !reflect.DeepEqual(first, expected) {
+		t.Errorf("first entry = %+v, want %+v", first, expected)
+	}
+
+	last := entries[4]
+	if last.Description != "Release 1.2, part 1" || last.Tags != nil || last.Duration() != 45*time.Minute {
+		t.Errorf("unexpected last entry %+v", last)
+	}
+}
+
+func TestParseClockify(t *testing.T) {
+	entries := parseFixture(t, "clockify_detailed.csv", Clockify)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].Project != "Website" || entries[0].Client != "Acme" || !reflect.DeepEqual(entries[0].Tags, []string{"bug", "frontend"}) {
+		t.Errorf("unexpected first entry %+v", entries[0])
+	}
+	if !entries[1].Start.Equal(local(6, 15, 0)) || entries[1].Duration() != 30*time.Minute {
+		t.Errorf("expected the afternoon times to be read, got %v - %v", entries[1].Start, entries[1].End)
+	}
+	if entries[2].Project != "" || entries[2].Line != 4 {
+		t.Errorf("unexpected entry without a project %+v", entries[2])
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"empty":          "",
+		"no columns":     "Project,Description,Duration\n",
+		"clockify":       "Project,Description,Start Date,Start Time,End Date,End Time\nweb,Fix,05/06/2024,09:00:00 AM,05/06/2024,10:00:00 AM\n",
+		"invalid start":  "Project,Description,Start date,Start time,End date,End time\nweb,Fix,2024/05/06,09:00:00,2024-05-06,10:00:00\n",
+		"invalid end":    "Project,Description,Start date,Start time,End date,End time\nweb,Fix,2024-05-06,09:00:00,2024-05-06,25:00:00\n",
+		"unquoted comma": "Project,Description,Start date,Start time,End date,End time\nweb,Fix \"a\" bug,2024-05-06\n",
+	}
+	for name, data := range tests {
+		if _, err := Parse(strings.NewReader(data), Toggl); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format, err := ParseFormat(" Toggl "); err != nil || format != Toggl {
+		t.Errorf("ParseFormat(Toggl) = %q, %v", format, err)
+	}
+	if _, err := ParseFormat("harvest"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -32,6 +32,16 @@ func TodayEndTime() time.Time {
 	return time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, time.Local)
 }
 
+// StartOfDay returns the start (00:00:00) of the day of t.
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// EndOfDay returns the end (23:59:59) of the day of t.
+func EndOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, time.Local)
+}
+
 // RelativeStartTimeWithDays returns the start of today minus the specified number of days.
 func RelativeStartTimeWithDays(days int) time.Time {
 	now := time.Now()
@@ -45,6 +55,17 @@ func IsToday(t time.Time) bool {
 	return t.Year() == now.Year() && t.Month() == now.Month() && t.Day() == now.Day()
 }
 
+// StartOfWeek returns the start of the Monday of the week of t.
+func StartOfWeek(t time.Time) time.Time {
+	return StartOfDay(t).AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+}
+
+// IsSameDay reports whether a and b fall on the same local day.
+func IsSameDay(a, b time.Time) bool {
+	a, b = a.Local(), b.Local()
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
 // SecondsToHourAndMinute returns the HH:MM portion of formatted time.
 func SecondsToHourAndMinute(seconds int) string {
 	time := FormatTime(seconds)
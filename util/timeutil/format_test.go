@@ -117,3 +117,39 @@ func TestRelativeStartTimeWithDays(t *testing.T) {
 		t.Error("RelativeStartTimeWithDays(7) should return 7 days ago")
 	}
 }
+
+func TestStartAndEndOfDay(t *testing.T) {
+	base := time.Date(2024, 3, 15, 13, 45, 10, 0, time.Local)
+
+	start := StartOfDay(base)
+	if !start.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("StartOfDay(%v) = %v", base, start)
+	}
+	end := EndOfDay(base)
+	if !end.Equal(time.Date(2024, 3, 15, 23, 59, 59, 0, time.Local)) {
+		t.Errorf("EndOfDay(%v) = %v", base, end)
+	}
+}
+
+func TestIsSameDay(t *testing.T) {
+	morning := time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local)
+	if !IsSameDay(morning, morning.Add(12*time.Hour)) {
+		t.Error("expected times on the same day to match")
+	}
+	if IsSameDay(morning, morning.AddDate(0, 0, 1)) {
+		t.Error("expected times on different days not to match")
+	}
+	if IsSameDay(time.Time{}, morning) {
+		t.Error("expected zero time not to match")
+	}
+}
+
+func TestStartOfWeek(t *testing.T) {
+	monday := time.Date(2024, 4, 29, 0, 0, 0, 0, time.Local)
+	for i := 0; i < 7; i++ {
+		day := monday.AddDate(0, 0, i).Add(15 * time.Hour)
+		if got := StartOfWeek(day); !got.Equal(monday) {
+			t.Errorf("StartOfWeek(%v) = %v, want %v", day, got, monday)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+// Package timew reads and writes the intervals of Timewarrior, both its data
+// files and the JSON of "timew export" and of its report extensions.
+package timew
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// timeFormat is the format of the UTC date-times of Timewarrior.
+const timeFormat = "20060102T150405Z"
+
+// dataFile matches the names of the monthly data files, such as "2024-05.data".
+var dataFile = regexp.MustCompile(`^\d{4}-\d{2}\.data$`)
+
+// Interval is a tracked interval of Timewarrior.
+type Interval struct {
+	Start time.Time
+	// End is zero for the interval being tracked.
+	End        time.Time
+	Tags       []string
+	Annotation string
+}
+
+// IsOpen reports whether the interval is still being tracked.
+func (i Interval) IsOpen() bool {
+	return i.End.IsZero()
+}
+
+// jsonInterval is an interval of the JSON export.
+type jsonInterval struct {
+	ID         int      `json:"id,omitempty"`
+	Start      string   `json:"start"`
+	End        string   `json:"end,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	Annotation string   `json:"annotation,omitempty"`
+}
+
+// Parse reads the intervals of a data file or of the JSON of "timew export".
+func Parse(r io.Reader) ([]Interval, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return parseJSON(trimmed)
+	}
+	return parseData(data)
+}
+
+// ReadPath reads the intervals of a file, or of the monthly data files of a
+// directory such as "~/.timewarrior/data".
+func ReadPath(path string) ([]Interval, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return Parse(f)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var intervals []Interval
+	for _, entry := range entries {
+		if entry.IsDir() || !dataFile.MatchString(entry.Name()) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		fileIntervals, err := parseData(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		intervals = append(intervals, fileIntervals...)
+	}
+	return intervals, nil
+}
+
+func parseJSON(data []byte) ([]Interval, error) {
+	var exported []jsonInterval
+	if err := json.Unmarshal(data, &exported); err != nil {
+		return nil, err
+	}
+	intervals := make([]Interval, len(exported))
+	for i, e := range exported {
+		start, err := time.Parse(timeFormat, e.Start)
+		if err != nil {
+			return nil, fmt.Errorf("interval %d: invalid start %q", i+1, e.Start)
+		}
+		interval := Interval{Start: start.Local(), Tags: e.Tags, Annotation: e.Annotation}
+		if e.End != "" {
+			end, err := time.Parse(timeFormat, e.End)
+			if err != nil {
+				return nil, fmt.Errorf("interval %d: invalid end %q", i+1, e.End)
+			}
+			interval.End = end.Local()
+		}
+		intervals[i] = interval
+	}
+	return intervals, nil
+}
+
+// parseData reads the lines of a data file, such as
+//
+//	inc 20240506T090000Z - 20240506T103000Z # web meeting # "Sprint planning"
+func parseData(data []byte) ([]Interval, error) {
+	var intervals []Interval
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		interval, err := parseLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		intervals = append(intervals, interval)
+	}
+	return intervals, scanner.Err()
+}
+
+func parseLine(text string) (Interval, error) {
+	var interval Interval
+	tokens, err := tokenize(text)
+	if err != nil {
+		return interval, err
+	}
+	if len(tokens) < 2 || tokens[0].text != "inc" {
+		return interval, fmt.Errorf("not an interval: %q", text)
+	}
+	start, err := time.Parse(timeFormat, tokens[1].text)
+	if err != nil {
+		return interval, fmt.Errorf("invalid start %q", tokens[1].text)
+	}
+	interval.Start = start.Local()
+	tokens = tokens[2:]
+	if len(tokens) >= 2 && tokens[0].text == "-" && !tokens[0].quoted {
+		end, err := time.Parse(timeFormat, tokens[1].text)
+		if err != nil {
+			return interval, fmt.Errorf("invalid end %q", tokens[1].text)
+		}
+		interval.End = end.Local()
+		tokens = tokens[2:]
+	}
+	if len(tokens) == 0 {
+		return interval, nil
+	}
+	if tokens[0].text != "#" || tokens[0].quoted {
+		return interval, fmt.Errorf("unexpected %q", tokens[0].text)
+	}
+	for i, token := range tokens[1:] {
+		if token.text == "#" && !token.quoted {
+			// the annotation follows the second "#"
+			interval.Annotation = joinTokens(tokens[i+2:])
+			break
+		}
+		interval.Tags = append(interval.Tags, token.text)
+	}
+	return interval, nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+// tokenize splits a line at spaces, keeping quoted strings with their
+// backslash escapes resolved together.
+func tokenize(text string) ([]token, error) {
+	var tokens []token
+	var current strings.Builder
+	inToken, quoted, inQuotes, escaped := false, false, false, false
+	for _, r := range text {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case inQuotes && r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+			inToken, quoted = true, true
+		case r == ' ' && !inQuotes:
+			if inToken {
+				tokens = append(tokens, token{text: current.String(), quoted: quoted})
+				current.Reset()
+				inToken, quoted = false, false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in %q", text)
+	}
+	if inToken {
+		tokens = append(tokens, token{text: current.String(), quoted: quoted})
+	}
+	return tokens, nil
+}
+
+func joinTokens(tokens []token) string {
+	texts := make([]string, len(tokens))
+	for i, token := range tokens {
+		texts[i] = token.text
+	}
+	return strings.Join(texts, " ")
+}
+
+// WriteData writes the intervals as the lines of a data file.
+func WriteData(w io.Writer, intervals []Interval) error {
+	bw := bufio.NewWriter(w)
+	for _, interval := range intervals {
+		bw.WriteString(formatLine(interval) + "\n")
+	}
+	return bw.Flush()
+}
+
+func formatLine(interval Interval) string {
+	var b strings.Builder
+	b.WriteString("inc " + interval.Start.UTC().Format(timeFormat))
+	if !interval.IsOpen() {
+		b.WriteString(" - " + interval.End.UTC().Format(timeFormat))
+	}
+	if len(interval.Tags) > 0 || interval.Annotation != "" {
+		b.WriteString(" #")
+		for _, tag := range interval.Tags {
+			b.WriteString(" " + quoteIfNeeded(tag))
+		}
+	}
+	if interval.Annotation != "" {
+		b.WriteString(` # "` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(interval.Annotation) + `"`)
+	}
+	return b.String()
+}
+
+func quoteIfNeeded(tag string) string {
+	if tag != "" && tag != "#" && tag != "-" && !strings.ContainsAny(tag, ` "\`) {
+		return tag
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(tag) + `"`
+}
+
+// WriteDataFiles writes the intervals to the monthly data files of dir, as
+// Timewarrior stores them, with the tags.data file of their tags. It returns
+// the paths of the files and fails if one of them already exists.
+func WriteDataFiles(dir string, intervals []Interval) ([]string, error) {
+	sorted := append([]Interval(nil), intervals...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	months := map[string][]Interval{}
+	tags := map[string]map[string]int{}
+	for _, interval := range sorted {
+		name := interval.Start.UTC().Format("2006-01") + ".data"
+		months[name] = append(months[name], interval)
+		for _, tag := range interval.Tags {
+			if tags[tag] == nil {
+				tags[tag] = map[string]int{"count": 0}
+			}
+			tags[tag]["count"]++
+		}
+	}
+
+	names := make([]string, 0, len(months)+1)
+	for name := range months {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	names = append(names, "tags.data")
+
+	var paths []string
+	for _, name := range names {
+		var buf bytes.Buffer
+		if name == "tags.data" {
+			data, err := json.MarshalIndent(tags, "", "  ")
+			if err != nil {
+				return paths, err
+			}
+			buf.Write(append(data, '\n'))
+		} else if err := WriteData(&buf, months[name]); err != nil {
+			return paths, err
+		}
+
+		path := filepath.Join(dir, name)
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return paths, err
+		}
+		_, err = f.Write(buf.Bytes())
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// WriteReport writes the intervals as the input of a report extension: a
+// header with the range of the report, an empty line and the intervals as
+// JSON, like "timew export".
+func WriteReport(w io.Writer, intervals []Interval, start, end time.Time) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "color: off\ndebug: off\nverbose: on\n")
+	fmt.Fprintf(bw, "temp.report.start: %s\ntemp.report.end: %s\ntemp.report.tags: \n\n",
+		start.UTC().Format(timeFormat), end.UTC().Format(timeFormat))
+	if err := writeJSON(bw, intervals); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func writeJSON(w io.Writer, intervals []Interval) error {
+	exported := make([]jsonInterval, len(intervals))
+	for i, interval := range intervals {
+		// ids count from the latest interval, as in Timewarrior
+		exported[i] = jsonInterval{
+			ID:         len(intervals) - i,
+			Start:      interval.Start.UTC().Format(timeFormat),
+			Tags:       interval.Tags,
+			Annotation: interval.Annotation,
+		}
+		if !interval.IsOpen() {
+			exported[i].End = interval.End.UTC().Format(timeFormat)
+		}
+	}
+	data, err := json.Marshal(exported)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
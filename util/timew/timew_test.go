@@ -0,0 +1,159 @@
+package timew
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func utc(day, hour, minute int) time.Time {
+	return time.Date(2024, 5, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestReadPathDataFile(t *testing.T) {
+	intervals, err := ReadPath("testdata/2024-05.data")
+	if err != nil {
+		t.Fatalf("ReadPath failed: %v", err)
+	}
+	if len(intervals) != 4 {
+		t.Fatalf("expected 4 intervals, got %d", len(intervals))
+	}
+
+	first := intervals[0]
+	if !first.Start.Equal(utc(6, 0, 0)) || !first.End.Equal(utc(6, 1, 30)) {
+		t.Errorf("unexpected times %v - %v", first.Start, first.End)
+	}
+	if !reflect.DeepEqual(first.Tags, []string{"web", "bug"}) || first.Annotation != "Fix login bug" {
+		t.Errorf("unexpected tags %q or annotation %q", first.Tags, first.Annotation)
+	}
+	if !reflect.DeepEqual(intervals[1].Tags, []string{"team sync", "meeting"}) || intervals[1].Annotation != "" {
+		t.Errorf("expected a quoted tag without an annotation, got %+v", intervals[1])
+	}
+	if intervals[2].Tags != nil || intervals[2].Annotation != `Reply to "urgent" mail` {
+		t.Errorf("expected an annotation without tags, got %+v", intervals[2])
+	}
+	if !intervals[3].IsOpen() {
+		t.Errorf("expected the last interval to be open, got %+v", intervals[3])
+	}
+}
+
+func TestReadPathDirectory(t *testing.T) {
+	// the JSON export and tags.data of the directory are not data files
+	intervals, err := ReadPath("testdata")
+	if err != nil {
+		t.Fatalf("ReadPath failed: %v", err)
+	}
+	if len(intervals) != 4 {
+		t.Errorf("expected the 4 intervals of the data file, got %d", len(intervals))
+	}
+}
+
+func TestReadPathJSON(t *testing.T) {
+	intervals, err := ReadPath("testdata/export.json")
+	if err != nil {
+		t.Fatalf("ReadPath failed: %v", err)
+	}
+	if len(intervals) != 2 {
+		t.Fatalf("expected 2 intervals, got %d", len(intervals))
+	}
+	if intervals[0].Annotation != "Fix login bug" || !intervals[0].End.Equal(utc(6, 1, 30)) {
+		t.Errorf("unexpected first interval %+v", intervals[0])
+	}
+	if !intervals[1].IsOpen() || !reflect.DeepEqual(intervals[1].Tags, []string{"reading"}) {
+		t.Errorf("unexpected open interval %+v", intervals[1])
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, data := range []string{
+		"exc 20240506T000000Z\n",
+		"inc 2024-05-06\n",
+		"inc 20240506T000000Z - later\n",
+		"inc 20240506T000000Z web\n",
+		`inc 20240506T000000Z # "web` + "\n",
+		`[{"start":"yesterday"}]`,
+	} {
+		if _, err := Parse(strings.NewReader(data)); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
+
+func TestWriteDataRoundTrip(t *testing.T) {
+	intervals := []Interval{
+		{Start: utc(6, 0, 0), End: utc(6, 1, 30), Tags: []string{"web", "bug fix"}, Annotation: `Say "hi"`},
+		{Start: utc(6, 3, 0), End: utc(6, 3, 30), Annotation: "No tags"},
+		{Start: utc(6, 5, 0), End: utc(6, 6, 0)},
+	}
+	var buf bytes.Buffer
+	if err := WriteData(&buf, intervals); err != nil {
+		t.Fatalf("WriteData failed: %v", err)
+	}
+	expected := "inc 20240506T000000Z - 20240506T013000Z # web \"bug fix\" # \"Say \\\"hi\\\"\"\n" +
+		"inc 20240506T030000Z - 20240506T033000Z # # \"No tags\"\n" +
+		"inc 20240506T050000Z - 20240506T060000Z\n"
+	if buf.String() != expected {
+		t.Errorf("WriteData() =\n%s\nwant\n%s", buf.String(), expected)
+	}
+
+	parsed, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	for i := range intervals {
+		if !parsed[i].Start.Equal(intervals[i].Start) || !parsed[i].End.Equal(intervals[i].End) ||
+			!reflect.DeepEqual(parsed[i].Tags, intervals[i].Tags) || parsed[i].Annotation != intervals[i].Annotation {
+			t.Errorf("interval %d = %+v, want %+v", i, parsed[i], intervals[i])
+		}
+	}
+}
+
+func TestWriteDataFiles(t *testing.T) {
+	dir := t.TempDir()
+	intervals := []Interval{
+		{Start: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC), End: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC), Tags: []string{"web"}},
+		{Start: utc(31, 9, 0), End: utc(31, 10, 0), Tags: []string{"web", "bug"}},
+	}
+	paths, err := WriteDataFiles(dir, intervals)
+	if err != nil {
+		t.Fatalf("WriteDataFiles failed: %v", err)
+	}
+	var names []string
+	for _, path := range paths {
+		names = append(names, filepath.Base(path))
+	}
+	if !reflect.DeepEqual(names, []string{"2024-05.data", "2024-06.data", "tags.data"}) {
+		t.Errorf("unexpected files %v", names)
+	}
+	tags, _ := os.ReadFile(filepath.Join(dir, "tags.data"))
+	if !strings.Contains(string(tags), `"web": {`) || !strings.Contains(string(tags), `"count": 2`) {
+		t.Errorf("unexpected tags.data %s", tags)
+	}
+
+	if _, err := WriteDataFiles(dir, intervals); err == nil {
+		t.Error("expected an error when the files exist")
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	var buf bytes.Buffer
+	intervals := []Interval{
+		{Start: utc(6, 0, 0), End: utc(6, 1, 0), Tags: []string{"web"}, Annotation: "Fix"},
+		{Start: utc(6, 2, 0), End: utc(6, 3, 0)},
+	}
+	if err := WriteReport(&buf, intervals, utc(6, 0, 0), utc(7, 0, 0)); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+	header, body, ok := strings.Cut(buf.String(), "\n\n")
+	if !ok || !strings.Contains(header, "temp.report.start: 20240506T000000Z") {
+		t.Fatalf("unexpected header %q", header)
+	}
+	expected := `[{"id":2,"start":"20240506T000000Z","end":"20240506T010000Z","tags":["web"],"annotation":"Fix"},{"id":1,"start":"20240506T020000Z","end":"20240506T030000Z"}]` + "\n"
+	if body != expected {
+		t.Errorf("body = %s, want %s", body, expected)
+	}
+}
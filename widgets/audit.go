@@ -0,0 +1,148 @@
+package widgets
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/rivo/tview"
+)
+
+var (
+	auditHeader = []string{
+		"Date",
+		"Action",
+		"Actor",
+		"Old",
+		"New",
+	}
+)
+
+type Audit struct {
+	Layout       *tview.Grid
+	Form         *tview.Form
+	Table        *tview.Table
+	auditLogUC   *usecase.AuditLogUseCase
+	errorHandler *service.ErrorHandler
+	theme        *service.Theme
+}
+
+func NewAudit(auditLogUC *usecase.AuditLogUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Audit {
+	return &Audit{
+		Layout: tview.NewGrid().
+			SetRows(5, 0).
+			SetColumns(0).
+			SetBorders(true),
+		Form: tview.NewForm().
+			SetButtonBackgroundColor(theme.Accent).
+			SetButtonTextColor(theme.AccentText).
+			SetLabelColor(theme.Accent).
+			SetFieldTextColor(theme.FieldText).
+			SetFieldBackgroundColor(theme.FieldBackground),
+		Table: tview.NewTable().
+			SetSelectable(true, false).
+			SetFixed(1, 1),
+		auditLogUC:   auditLogUC,
+		errorHandler: errorHandler,
+		theme:        theme,
+	}
+}
+
+func (a *Audit) GenerateInitAudit(tui *service.TUI) *Audit {
+	a.setForm(tui)
+	a.restoreTable(0)
+
+	a.Layout.AddItem(a.Form, 0, 0, 1, 1, 0, 0, true)
+	a.Layout.AddItem(a.Table, 1, 0, 1, 1, 0, 0, false)
+
+	a.formCapture(tui)
+	return a
+}
+
+// Show displays the audit history of the given work.
+func (a *Audit) Show(workID uint) {
+	a.Form.GetFormItemByLabel(i18n.T("Work ID")).(*tview.InputField).SetText(fmt.Sprint(workID))
+	a.restoreTable(workID)
+}
+
+func (a *Audit) setForm(tui *service.TUI) {
+	a.Form.Clear(true)
+	a.Form.
+		AddInputField(i18n.T("Work ID"), "", 20, tview.InputFieldInteger, nil).
+		AddButton(i18n.T("Show"), func() {
+			id := a.Form.GetFormItemByLabel(i18n.T("Work ID")).(*tview.InputField).GetText()
+			intId, err := strconv.ParseUint(id, 10, 0)
+			if err != nil {
+				a.errorHandler.ShowErrorWithErr(usecase.NewValidationError("invalid work id"), "auditForm")
+				return
+			}
+			a.restoreTable(uint(intId))
+			tui.SetFocus("auditTable")
+		}).
+		AddButton(i18n.T("Cancel"), func() {
+			tui.SetFocus("menu")
+		})
+}
+
+func (a *Audit) formCapture(tui *service.TUI) {
+	a.Form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch tui.Keymap.Action(service.ContextForm, event) {
+		case "back":
+			tui.SetFocus("auditTable")
+		}
+		return event
+	})
+}
+
+func (a *Audit) restoreTable(workID uint) {
+	a.Table.Clear()
+	a.setTableHeader()
+	if workID == 0 {
+		return
+	}
+	a.setTableBody(workID)
+}
+
+func (a *Audit) setTableHeader() {
+	for i, header := range auditHeader {
+		tableCell := tview.NewTableCell(i18n.T(header)).
+			SetAlign(tview.AlignLeft).
+			SetTextColor(a.theme.AccentText).
+			SetBackgroundColor(a.theme.Accent).
+			SetSelectable(false)
+		if header == "Old" || header == "New" {
+			tableCell.SetExpansion(1)
+		}
+		a.Table.SetCell(0, i, tableCell)
+	}
+}
+
+func (a *Audit) setTableBody(workID uint) {
+	logs, err := a.auditLogUC.FindByWorkID(workID)
+	if err != nil {
+		a.errorHandler.ShowErrorWithErr(err, "auditForm")
+		return
+	}
+	for i, log := range logs {
+		a.Table.SetCell(i+1, 0,
+			tview.NewTableCell(i18n.FormatDateTime(log.CreatedAt)).
+				SetAlign(tview.AlignLeft))
+		a.Table.SetCell(i+1, 1,
+			tview.NewTableCell(string(log.Action)).
+				SetAlign(tview.AlignLeft))
+		a.Table.SetCell(i+1, 2,
+			tview.NewTableCell(log.Actor).
+				SetAlign(tview.AlignLeft))
+		a.Table.SetCell(i+1, 3,
+			tview.NewTableCell(log.OldValue).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(1))
+		a.Table.SetCell(i+1, 4,
+			tview.NewTableCell(log.NewValue).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(1))
+	}
+}
@@ -0,0 +1,307 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+	"github.com/rivo/tview"
+)
+
+const (
+	// calendarLevels is the number of shades of the days with tracked time.
+	// The darkest shade is a full person day.
+	calendarLevels = 4
+	// calendarConfirmedMark marks the days whose works are all confirmed.
+	calendarConfirmedMark = "✓"
+)
+
+// Calendar shows the tracked time of each day as a month grid or a year heatmap.
+type Calendar struct {
+	Layout       *tview.Flex
+	Title        *tview.TextView
+	Detail       *tview.TextView
+	Table        *tview.Table
+	chronoWorkUC *usecase.ChronoWorkUseCase
+	settingUC    *usecase.SettingUseCase
+	errorHandler *service.ErrorHandler
+	theme        *service.Theme
+	// date is the selected day, which also decides the displayed month or year.
+	date      time.Time
+	yearView  bool
+	summaries map[string]usecase.DaySummary
+}
+
+func NewCalendar(chronoWorkUC *usecase.ChronoWorkUseCase, settingUC *usecase.SettingUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Calendar {
+	title := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextColor(theme.Accent)
+	detail := tview.NewTextView().
+		SetDynamicColors(true)
+	table := tview.NewTable().
+		SetSelectable(true, true)
+	return &Calendar{
+		Layout: tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(title, 1, 0, false).
+			AddItem(detail, 1, 0, false).
+			AddItem(table, 0, 1, true),
+		Title:        title,
+		Detail:       detail,
+		Table:        table,
+		chronoWorkUC: chronoWorkUC,
+		settingUC:    settingUC,
+		errorHandler: errorHandler,
+		theme:        theme,
+		date:         timeutil.StartOfDay(time.Now()),
+	}
+}
+
+func (c *Calendar) GenerateInitCalendar(tui *service.TUI, work *Work) *Calendar {
+	c.Table.SetSelectionChangedFunc(func(row, column int) {
+		if date, ok := c.Table.GetCell(row, column).GetReference().(time.Time); ok {
+			c.date = date
+			c.setDetail()
+		}
+	})
+	c.tableCapture(tui, work)
+	return c
+}
+
+// Restore reloads the tracked time of the displayed month or year.
+func (c *Calendar) Restore() {
+	start, end := c.monthRange()
+	if c.yearView {
+		start = time.Date(c.date.Year(), time.January, 1, 0, 0, 0, 0, time.Local)
+		end = time.Date(c.date.Year(), time.December, 31, 0, 0, 0, 0, time.Local)
+	}
+	summaries, err := c.chronoWorkUC.DailySummaries(start, end)
+	if err != nil {
+		c.errorHandler.ShowErrorWithErr(err, "calendarTable")
+		return
+	}
+
+	c.summaries = make(map[string]usecase.DaySummary, len(summaries))
+	totalSeconds := 0
+	for _, s := range summaries {
+		c.summaries[s.Date.Format(time.DateOnly)] = s
+		totalSeconds += s.TotalSeconds
+	}
+
+	personDay := 8
+	if setting, err := c.settingUC.Get(); err == nil && setting.PersonDay > 0 {
+		personDay = int(setting.PersonDay)
+	}
+
+	c.Table.Clear()
+	if c.yearView {
+		c.Title.SetText(i18n.T("%d  Total %s", c.date.Year(), timeutil.SecondsToHourAndMinute(totalSeconds)))
+		c.setYear(summaries, personDay)
+	} else {
+		c.Title.SetText(i18n.T("%s  Total %s", i18n.FormatMonth(c.date), timeutil.SecondsToHourAndMinute(totalSeconds)))
+		c.setMonth(summaries, personDay)
+	}
+	c.selectDate()
+}
+
+// ShowToday selects today and displays its month or year.
+func (c *Calendar) ShowToday() {
+	c.date = timeutil.StartOfDay(time.Now())
+	c.Restore()
+}
+
+func (c *Calendar) monthRange() (time.Time, time.Time) {
+	start := time.Date(c.date.Year(), c.date.Month(), 1, 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 1, -1)
+}
+
+// setMonth draws the days of the month as a grid of weeks starting on Monday.
+func (c *Calendar) setMonth(summaries []usecase.DaySummary, personDay int) {
+	for i := 0; i < 7; i++ {
+		c.Table.SetCell(0, i, tview.NewTableCell(i18n.ShortWeekday(calendarWeekday(i))).
+			SetAlign(tview.AlignCenter).
+			SetTextColor(c.theme.AccentText).
+			SetBackgroundColor(c.theme.Accent).
+			SetExpansion(1).
+			SetSelectable(false))
+	}
+
+	offset := weekdayColumn(summaries[0].Date)
+	for i, s := range summaries {
+		spent := "  -  "
+		if s.TotalSeconds > 0 {
+			spent = timeutil.SecondsToHourAndMinute(s.TotalSeconds)
+		}
+		mark := " "
+		if s.AllConfirmed() {
+			mark = calendarConfirmedMark
+		}
+		cell := tview.NewTableCell(fmt.Sprintf("%2d  %s %s", s.Date.Day(), spent, mark)).
+			SetAlign(tview.AlignCenter).
+			SetExpansion(1).
+			SetReference(s.Date)
+		if level := calendarLevel(s.TotalSeconds, personDay); level > 0 {
+			cell.SetTextColor(c.theme.AccentText).
+				SetBackgroundColor(c.shade(level))
+		}
+		c.Table.SetCell((i+offset)/7+1, (i+offset)%7, cell)
+	}
+	c.fillBlankCells()
+}
+
+// setYear draws the days of the year as a heatmap with a column per week and
+// a row per day of the week.
+func (c *Calendar) setYear(summaries []usecase.DaySummary, personDay int) {
+	c.Table.SetCell(0, 0, tview.NewTableCell("").SetSelectable(false))
+	for i := 0; i < 7; i++ {
+		c.Table.SetCell(i+1, 0, tview.NewTableCell(i18n.ShortWeekday(calendarWeekday(i))+" ").
+			SetTextColor(c.theme.Accent).
+			SetSelectable(false))
+	}
+
+	offset := weekdayColumn(summaries[0].Date)
+	for i, s := range summaries {
+		column := (i+offset)/7 + 1
+		if s.Date.Day() == 1 {
+			c.Table.SetCell(0, column, tview.NewTableCell(i18n.ShortMonth(s.Date.Month())).
+				SetTextColor(c.theme.Accent).
+				SetSelectable(false))
+		} else if c.Table.GetCell(0, column).Text == "" {
+			c.Table.SetCell(0, column, tview.NewTableCell(" ").SetSelectable(false))
+		}
+
+		text := "■"
+		if s.AllConfirmed() {
+			text = calendarConfirmedMark
+		}
+		color := c.theme.FieldText
+		if level := calendarLevel(s.TotalSeconds, personDay); level > 0 {
+			color = c.shade(level)
+		}
+		c.Table.SetCell((i+offset)%7+1, column, tview.NewTableCell(text).
+			SetTextColor(color).
+			SetReference(s.Date))
+	}
+	c.fillBlankCells()
+}
+
+// fillBlankCells makes the cells before the first and after the last day
+// unselectable, so that the selection moves between days only.
+func (c *Calendar) fillBlankCells() {
+	for row := 1; row < c.Table.GetRowCount(); row++ {
+		for column := 0; column < c.Table.GetColumnCount(); column++ {
+			if cell := c.Table.GetCell(row, column); cell.GetReference() == nil && cell.Text == "" {
+				c.Table.SetCell(row, column, tview.NewTableCell("").SetSelectable(false))
+			}
+		}
+	}
+}
+
+// selectDate selects the cell of the selected day.
+func (c *Calendar) selectDate() {
+	for row := 0; row < c.Table.GetRowCount(); row++ {
+		for column := 0; column < c.Table.GetColumnCount(); column++ {
+			if date, ok := c.Table.GetCell(row, column).GetReference().(time.Time); ok && timeutil.IsSameDay(date, c.date) {
+				c.Table.Select(row, column)
+				c.setDetail()
+				return
+			}
+		}
+	}
+}
+
+// setDetail shows the tracked time of the selected day and the legend of the shades.
+func (c *Calendar) setDetail() {
+	s := c.summaries[c.date.Format(time.DateOnly)]
+	var shades strings.Builder
+	for level := 1; level <= calendarLevels; level++ {
+		shades.WriteString(service.ColorTag(c.shade(level)) + "■")
+	}
+	shades.WriteString("[-]")
+	c.Detail.SetText(fmt.Sprintf("%s (%s)  %s  %s    %s",
+		i18n.FormatDate(c.date),
+		i18n.ShortWeekday(c.date.Weekday()),
+		timeutil.SecondsToHourAndMinute(s.TotalSeconds),
+		i18n.T("%d works, %d confirmed", s.Works, s.Confirmed),
+		i18n.T("Less %s More  %s all confirmed", shades.String(), calendarConfirmedMark),
+	))
+}
+
+// calendarLevel returns the shade of the tracked time, 0 for no time and
+// calendarLevels for a person day of personDay hours or more.
+func calendarLevel(seconds, personDay int) int {
+	if seconds <= 0 {
+		return 0
+	}
+	level := (seconds*calendarLevels + personDay*3600 - 1) / (personDay * 3600)
+	return min(level, calendarLevels)
+}
+
+// shade blends the background into the positive color by the level.
+func (c *Calendar) shade(level int) tcell.Color {
+	r1, g1, b1 := c.theme.Background.RGB()
+	r2, g2, b2 := c.theme.Positive.RGB()
+	if r1 < 0 || r2 < 0 {
+		return c.theme.Positive
+	}
+	blend := func(from, to int32) int32 {
+		return from + (to-from)*int32(level)/calendarLevels
+	}
+	return tcell.NewRGBColor(blend(r1, r2), blend(g1, g2), blend(b1, b2))
+}
+
+// shift moves the selected day by months, or by years in the year view.
+func (c *Calendar) shift(n int) {
+	if c.yearView {
+		c.date = addMonths(c.date, 12*n)
+	} else {
+		c.date = addMonths(c.date, n)
+	}
+	c.Restore()
+}
+
+func (c *Calendar) tableCapture(tui *service.TUI, work *Work) {
+	c.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch tui.Keymap.Action(service.ContextCalendar, event) {
+		case "open":
+			if err := work.ReStoreTable(timeutil.StartOfDay(c.date), timeutil.EndOfDay(c.date)); err != nil {
+				c.errorHandler.ShowErrorWithErr(err, "calendarTable")
+				return nil
+			}
+			tui.ChangeToPage("work")
+			tui.SetFocus("mainWorkContent")
+			return nil
+		case "prev":
+			c.shift(-1)
+		case "next":
+			c.shift(1)
+		case "today":
+			c.ShowToday()
+		case "toggle_view":
+			c.yearView = !c.yearView
+			c.Restore()
+		}
+		return event
+	})
+}
+
+// calendarWeekday returns the day of the week of a calendar column, from Monday.
+func calendarWeekday(column int) time.Weekday {
+	return time.Weekday((column + 1) % 7)
+}
+
+// weekdayColumn returns the calendar column of the day of the week of t.
+func weekdayColumn(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+// addMonths adds n months to t, keeping the day within the resulting month.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, time.Local)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
@@ -0,0 +1,190 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+	"github.com/rivo/tview"
+)
+
+const (
+	// chartLabelWidth is the width of the names left of the bars.
+	chartLabelWidth = 20
+	// chartBarWidth is the width of the longest bar of a chart.
+	chartBarWidth = 40
+)
+
+// chartRanges are the preset ranges of the charts. Custom keeps the dates of the form.
+var chartRanges = []string{
+	"This week",
+	"Last week",
+	"This month",
+	"Last month",
+	"Last 30 days",
+	"Custom",
+}
+
+// Chart shows the distribution of the tracked time by project, tag and day of the week.
+type Chart struct {
+	Layout       *tview.Grid
+	Form         *tview.Form
+	View         *tview.TextView
+	chronoWorkUC *usecase.ChronoWorkUseCase
+	errorHandler *service.ErrorHandler
+	theme        *service.Theme
+}
+
+func NewChart(chronoWorkUC *usecase.ChronoWorkUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Chart {
+	return &Chart{
+		Layout: tview.NewGrid().
+			SetRows(5, 0).
+			SetColumns(0).
+			SetBorders(true),
+		Form: tview.NewForm().
+			SetHorizontal(true).
+			SetButtonBackgroundColor(theme.Accent).
+			SetButtonTextColor(theme.AccentText).
+			SetLabelColor(theme.Accent).
+			SetFieldTextColor(theme.FieldText).
+			SetFieldBackgroundColor(theme.FieldBackground),
+		View: tview.NewTextView().
+			SetDynamicColors(true).
+			SetScrollable(true),
+		chronoWorkUC: chronoWorkUC,
+		errorHandler: errorHandler,
+		theme:        theme,
+	}
+}
+
+func (c *Chart) GenerateInitChart(tui *service.TUI) *Chart {
+	c.setForm()
+	c.Layout.AddItem(c.Form, 0, 0, 1, 1, 0, 0, true)
+	c.Layout.AddItem(c.View, 1, 0, 1, 1, 0, 0, false)
+	return c
+}
+
+// ShowThisWeek resets the range to this week and draws the charts.
+func (c *Chart) ShowThisWeek() {
+	c.Form.GetFormItemByLabel(i18n.T("Range")).(*tview.DropDown).SetCurrentOption(0)
+}
+
+func (c *Chart) setForm() {
+	from := tview.NewInputField().
+		SetLabel(i18n.T("From(YYYY/MM/DD)")).
+		SetFieldWidth(12)
+	to := tview.NewInputField().
+		SetLabel(i18n.T("To(YYYY/MM/DD)")).
+		SetFieldWidth(12)
+
+	options := make([]string, len(chartRanges))
+	for i, r := range chartRanges {
+		options[i] = i18n.T(r)
+	}
+	c.Form.AddDropDown(i18n.T("Range"), options, -1, func(option string, index int) {
+		if index < 0 || chartRanges[index] == "Custom" {
+			return
+		}
+		start, end := chartRange(chartRanges[index], time.Now())
+		from.SetText(start.Format("2006/01/02"))
+		to.SetText(end.Format("2006/01/02"))
+		c.show(start, end)
+	}).
+		AddFormItem(from).
+		AddFormItem(to).
+		AddButton(i18n.T("Show"), func() {
+			start, err1 := time.ParseInLocation("2006/01/02", from.GetText(), time.Local)
+			end, err2 := time.ParseInLocation("2006/01/02", to.GetText(), time.Local)
+			if err1 != nil || err2 != nil {
+				c.errorHandler.ShowErrorWithErr(usecase.NewValidationError("invalid date format"), "chartForm")
+				return
+			}
+			if end.Before(start) {
+				c.errorHandler.ShowErrorWithErr(usecase.NewValidationError("the end date is before the start date"), "chartForm")
+				return
+			}
+			c.Form.GetFormItemByLabel(i18n.T("Range")).(*tview.DropDown).SetCurrentOption(len(chartRanges) - 1)
+			c.show(start, end)
+		})
+	c.ShowThisWeek()
+}
+
+// chartRange returns the first and last days of a preset range containing now.
+func chartRange(name string, now time.Time) (time.Time, time.Time) {
+	today := timeutil.StartOfDay(now)
+	monthStart := today.AddDate(0, 0, 1-today.Day())
+	switch name {
+	case "Last week":
+		start := timeutil.StartOfWeek(today).AddDate(0, 0, -7)
+		return start, start.AddDate(0, 0, 6)
+	case "This month":
+		return monthStart, monthStart.AddDate(0, 1, -1)
+	case "Last month":
+		return monthStart.AddDate(0, -1, 0), monthStart.AddDate(0, 0, -1)
+	case "Last 30 days":
+		return today.AddDate(0, 0, -29), today
+	default:
+		start := timeutil.StartOfWeek(today)
+		return start, start.AddDate(0, 0, 6)
+	}
+}
+
+// show draws the charts of the works created from the day of start to the day of end.
+func (c *Chart) show(start, end time.Time) {
+	d, err := c.chronoWorkUC.Distribution(timeutil.StartOfDay(start), timeutil.EndOfDay(end))
+	if err != nil {
+		c.errorHandler.ShowErrorWithErr(err, "chartForm")
+		return
+	}
+
+	var b strings.Builder
+	period := i18n.FormatDate(start) + " - " + i18n.FormatDate(end)
+	fmt.Fprintf(&b, "%s%s[-]\n", service.ColorTag(c.theme.Accent), i18n.T("%s  Total %s", period, timeutil.SecondsToHourAndMinute(d.TotalSeconds)))
+	if d.TotalSeconds == 0 {
+		b.WriteString("\n" + i18n.T("No tracked time in this range.") + "\n")
+		c.View.SetText(b.String()).ScrollToBeginning()
+		return
+	}
+
+	c.writeChart(&b, d, i18n.T("By project"), d.Projects, i18n.T("No project"))
+	c.writeChart(&b, d, i18n.T("By tag"), d.Tags, i18n.T("No tag"))
+	weekdays := make([]usecase.DistributionEntry, 7)
+	for i := range weekdays {
+		weekday := calendarWeekday(i)
+		weekdays[i] = usecase.DistributionEntry{Name: i18n.Weekday(weekday), Seconds: d.Weekdays[weekday]}
+	}
+	c.writeChart(&b, d, i18n.T("By weekday"), weekdays, "")
+	c.View.SetText(b.String()).ScrollToBeginning()
+}
+
+// writeChart writes a titled chart with a bar per entry, scaled to the largest entry.
+func (c *Chart) writeChart(b *strings.Builder, d usecase.TimeDistribution, title string, entries []usecase.DistributionEntry, noName string) {
+	fmt.Fprintf(b, "\n%s%s[-]\n", service.ColorTag(c.theme.Accent), title)
+	largest := 0
+	for _, entry := range entries {
+		largest = max(largest, entry.Seconds)
+	}
+	for _, entry := range entries {
+		name := entry.Name
+		if name == "" {
+			name = noName
+		}
+		length := 0
+		if largest > 0 {
+			length = (entry.Seconds*chartBarWidth + largest - 1) / largest
+		}
+		fmt.Fprintf(b, "%s %s%s[-]%s %s %5.1f%%\n",
+			tview.Escape(runewidth.FillRight(runewidth.Truncate(name, chartLabelWidth, "…"), chartLabelWidth)),
+			service.ColorTag(c.theme.Positive),
+			strings.Repeat("█", length),
+			strings.Repeat(" ", chartBarWidth-length),
+			timeutil.SecondsToHourAndMinute(entry.Seconds),
+			d.Percent(entry.Seconds),
+		)
+	}
+}
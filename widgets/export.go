@@ -7,9 +7,9 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/gdamore/tcell/v2"
 	"github.com/niiharamegumu/chronowork/internal/usecase"
 	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
 	"github.com/niiharamegumu/chronowork/util/timeutil"
 	"github.com/rivo/tview"
 )
@@ -21,10 +21,10 @@ type Export struct {
 	errorHandler *service.ErrorHandler
 }
 
-func NewExport(chronoWorkUC *usecase.ChronoWorkUseCase, settingUC *usecase.SettingUseCase, errorHandler *service.ErrorHandler) *Export {
+func NewExport(chronoWorkUC *usecase.ChronoWorkUseCase, settingUC *usecase.SettingUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Export {
 	return &Export{
 		Form: tview.NewForm().
-			SetLabelColor(tcell.ColorPurple),
+			SetLabelColor(theme.Accent),
 		chronoWorkUC: chronoWorkUC,
 		settingUC:    settingUC,
 		errorHandler: errorHandler,
@@ -32,12 +32,33 @@ func NewExport(chronoWorkUC *usecase.ChronoWorkUseCase, settingUC *usecase.Setti
 }
 
 func (e *Export) GenerateInitExport(tui *service.TUI) {
-	e.Form.AddButton("Export", func() {
-		e.export()
+	// the range of the tracking intervals exported to iCalendar and Timewarrior, this month by default
+	today := time.Now()
+	e.Form.AddInputField(i18n.T("From(YYYY/MM/DD)"), today.AddDate(0, 0, 1-today.Day()).Format("2006/01/02"), 20, nil, nil).
+		AddInputField(i18n.T("To(YYYY/MM/DD)"), today.Format("2006/01/02"), 20, nil, nil)
+
+	e.Form.AddButton(i18n.T("Export"), func() {
+		if path := e.export(); path != "" {
+			tui.Status.Info("Exported to %s", path)
+		}
 		e.ReStore(tui)
 		tui.SetFocus("menu")
 	}).
-		AddButton("Cancel", func() {
+		AddButton(i18n.T("Export iCalendar"), func() {
+			if !e.exportICS(tui) {
+				return
+			}
+			e.ReStore(tui)
+			tui.SetFocus("menu")
+		}).
+		AddButton(i18n.T("Export Timewarrior"), func() {
+			if !e.exportTimew(tui) {
+				return
+			}
+			e.ReStore(tui)
+			tui.SetFocus("menu")
+		}).
+		AddButton(i18n.T("Cancel"), func() {
 			e.ReStore(tui)
 			tui.SetFocus("menu")
 		})
@@ -48,25 +69,100 @@ func (e *Export) ReStore(tui *service.TUI) {
 	e.GenerateInitExport(tui)
 }
 
-func (e *Export) export() {
+// exportICS writes the tracking intervals of the range of the form to an
+// iCalendar file, reports the result in the status bar and returns false if
+// nothing was exported.
+func (e *Export) exportICS(tui *service.TUI) bool {
+	start, end, ok := e.formRange()
+	if !ok {
+		return false
+	}
+
+	path, count, err := e.chronoWorkUC.ExportICS(start, end, time.Now())
+	if err != nil {
+		e.errorHandler.ShowErrorWithErr(err, "exportForm")
+		return false
+	}
+	return e.exported(tui, path, count, start, end)
+}
+
+// exportTimew writes the tracking intervals of the range of the form to
+// Timewarrior data files, reports the result in the status bar and returns
+// false if nothing was exported.
+func (e *Export) exportTimew(tui *service.TUI) bool {
+	start, end, ok := e.formRange()
+	if !ok {
+		return false
+	}
+
+	dir, count, err := e.chronoWorkUC.ExportTimew(start, end, time.Now())
+	if err != nil {
+		e.errorHandler.ShowErrorWithErr(err, "exportForm")
+		return false
+	}
+	return e.exported(tui, dir, count, start, end)
+}
+
+// exported reports an export of the intervals of the range, with a warning
+// when some of the time of the range has no intervals and is missing.
+func (e *Export) exported(tui *service.TUI, path string, count int, start, end time.Time) bool {
+	if count == 0 {
+		e.errorHandler.ShowWarning("There are no tracked intervals to export.", "exportForm")
+		return false
+	}
+	untracked, err := e.chronoWorkUC.UntrackedWorks(start, end)
+	if err != nil {
+		e.errorHandler.ShowErrorWithErr(err, "exportForm")
+		return true
+	}
+	if len(untracked) == 0 {
+		tui.Status.Info("Exported %d intervals to %s", count, path)
+		return true
+	}
+	seconds := 0
+	for _, u := range untracked {
+		seconds += u.Seconds
+	}
+	tui.Status.Warn("Exported %d intervals to %s; %d works have %s without intervals, which is not exported", count, path, len(untracked), timeutil.FormatTime(seconds))
+	return true
+}
+
+// formRange returns the range from the start of the day of From to the end of
+// the day of To, or false after showing an error if a date is invalid.
+func (e *Export) formRange() (time.Time, time.Time, bool) {
+	from := e.Form.GetFormItemByLabel(i18n.T("From(YYYY/MM/DD)")).(*tview.InputField).GetText()
+	to := e.Form.GetFormItemByLabel(i18n.T("To(YYYY/MM/DD)")).(*tview.InputField).GetText()
+	start, err1 := time.ParseInLocation("2006/01/02", from, time.Local)
+	end, err2 := time.ParseInLocation("2006/01/02", to, time.Local)
+	if err1 != nil || err2 != nil {
+		e.errorHandler.ShowErrorWithErr(usecase.NewValidationError("invalid date format"), "exportForm")
+		return time.Time{}, time.Time{}, false
+	}
+	return timeutil.StartOfDay(start), timeutil.EndOfDay(end), true
+}
+
+// export writes every work to a CSV file in the download path and returns
+// its path, or "" if nothing was exported.
+func (e *Export) export() string {
 	setting, err := e.settingUC.Get()
 	if err != nil {
 		e.errorHandler.ShowErrorWithErr(err, "exportForm")
-		return
+		return ""
 	}
 	path := setting.DownloadPath
 
 	chronoWorks, err := e.chronoWorkUC.GetAll("id", 0)
 	if err != nil {
 		e.errorHandler.ShowErrorWithErr(err, "exportForm")
-		return
+		return ""
 	}
 	if len(chronoWorks) < 1 {
-		return
+		e.errorHandler.ShowWarning("There are no works to export.", "exportForm")
+		return ""
 	}
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		e.errorHandler.ShowErrorWithErr(err, "exportForm")
-		return
+		return ""
 	}
 	if path[len(path)-1:] != "/" {
 		path += "/"
@@ -78,7 +174,7 @@ func (e *Export) export() {
 	f, err := os.Create(exportPath)
 	if err != nil {
 		e.errorHandler.ShowErrorWithErr(err, "exportForm")
-		return
+		return ""
 	}
 	defer f.Close()
 
@@ -88,7 +184,7 @@ func (e *Export) export() {
 	header := []string{"ID", "Title", "ProjectName", "TagName", "Date", "Time"}
 	if err := w.Write(header); err != nil {
 		e.errorHandler.ShowErrorWithErr(err, "exportForm")
-		return
+		return ""
 	}
 
 	for _, c := range chronoWorks {
@@ -113,4 +209,5 @@ func (e *Export) export() {
 			continue
 		}
 	}
+	return exportPath
 }
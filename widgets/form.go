@@ -3,47 +3,75 @@ package widgets
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/niiharamegumu/chronowork/internal/domain"
 	"github.com/niiharamegumu/chronowork/internal/usecase"
 	"github.com/niiharamegumu/chronowork/service"
-	"github.com/niiharamegumu/chronowork/util/timeutil"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/niiharamegumu/chronowork/util/strutil"
 	"github.com/rivo/tview"
 )
 
-var notSelectText = "Not Select"
+// recentWorksLimit is the number of latest works whose projects and tags are
+// listed first in the pickers.
+const recentWorksLimit = 200
+
+var (
+	notSelectText = "Not Select"
+	createTagText = "+ Create tag: %s"
+	batchActions  = []string{
+		"Confirm",
+		"Reopen",
+		"Delete",
+		"Reassign Project/Tag",
+		"Move to Date",
+		"Copy to Today",
+	}
+)
 
 type Form struct {
-	Form          *tview.Form
-	chronoWorkUC  *usecase.ChronoWorkUseCase
-	projectTypeUC *usecase.ProjectTypeUseCase
-	errorHandler  *service.ErrorHandler
+	Form             *tview.Form
+	chronoWorkUC     *usecase.ChronoWorkUseCase
+	projectTypeUC    *usecase.ProjectTypeUseCase
+	settingUC        *usecase.SettingUseCase
+	quickAddUC       *usecase.QuickAddUseCase
+	errorHandler     *service.ErrorHandler
+	projects         []domain.ProjectType
+	recentProjects   []string
+	recentTags       []string
+	titles           []usecase.TitleSuggestion
+	allowTagCreation bool
 }
 
-func NewForm(chronoWorkUC *usecase.ChronoWorkUseCase, projectTypeUC *usecase.ProjectTypeUseCase, errorHandler *service.ErrorHandler) *Form {
+func NewForm(chronoWorkUC *usecase.ChronoWorkUseCase, projectTypeUC *usecase.ProjectTypeUseCase, settingUC *usecase.SettingUseCase, quickAddUC *usecase.QuickAddUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Form {
 	form := &Form{
 		Form: tview.NewForm().
-			SetButtonBackgroundColor(tcell.ColorPurple).
-			SetLabelColor(tcell.ColorPurple).
-			SetFieldTextColor(tcell.ColorGray).
-			SetFieldBackgroundColor(tcell.ColorWhite),
+			SetButtonBackgroundColor(theme.Accent).
+			SetButtonTextColor(theme.AccentText).
+			SetLabelColor(theme.Accent).
+			SetFieldTextColor(theme.FieldText).
+			SetFieldBackgroundColor(theme.FieldBackground),
 		chronoWorkUC:  chronoWorkUC,
 		projectTypeUC: projectTypeUC,
+		settingUC:     settingUC,
+		quickAddUC:    quickAddUC,
 		errorHandler:  errorHandler,
 	}
 	return form
 }
 
-func (f *Form) GenerateInitForm(tui *service.TUI, work *Work, relativeDays int) *Form {
-	f.ConfigureStoreForm(tui, work, relativeDays)
+func (f *Form) GenerateInitForm(tui *service.TUI, work *Work) *Form {
+	f.ConfigureStoreForm(tui, work)
 	return f
 }
 
 func (f *Form) FormCapture(tui *service.TUI) {
 	f.Form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyCtrlB:
+		switch tui.Keymap.Action(service.ContextForm, event) {
+		case "back":
 			tui.SetFocus("mainWorkContent")
 		}
 		return event
@@ -51,149 +79,401 @@ func (f *Form) FormCapture(tui *service.TUI) {
 }
 
 func (f *Form) ResetForm() {
-	f.Form.GetFormItemByLabel("Title").(*tview.InputField).SetText("")
-	f.Form.GetFormItemByLabel("Project").(*tview.DropDown).SetCurrentOption(0)
-	f.Form.GetFormItemByLabel("Tags").(*tview.DropDown).SetOptions([]string{notSelectText}, nil).SetCurrentOption(0)
+	f.Form.GetFormItemByLabel(i18n.T("Title")).(*tview.InputField).SetText("")
+	f.projectPicker().SetText("")
+	f.tagPicker().SetText("")
+	f.Form.GetFormItemByLabel(i18n.T("Date(YYYY/MM/DD)")).(*tview.InputField).SetText(time.Now().Format("2006/01/02"))
 }
 
-func (f *Form) ConfigureStoreForm(tui *service.TUI, work *Work, relativeDays int) {
-	f.Form.
-		AddInputField("Title", "", 50, nil, nil).
-		AddDropDown("Project", append([]string{notSelectText}, f.projectTypeUC.GetAllNames()...), 0, f.projectDropDownChanged).
-		AddDropDown("Tags", []string{notSelectText}, 0, nil).
-		AddButton("Store", func() {
+func (f *Form) ConfigureStoreForm(tui *service.TUI, work *Work) {
+	f.addTitleField()
+	f.addProjectAndTagPickers(tui, "", "")
+	f.Form.AddInputField(i18n.T("Date(YYYY/MM/DD)"), work.endTime.Format("2006/01/02"), 20, nil, nil).
+		AddButton(i18n.T("Store"), func() {
 			if err := f.store(); err != nil {
 				f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
 				return
 			}
-			if err := work.ReStoreTable(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime()); err != nil {
+			if err := work.Refresh(); err != nil {
 				f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
 				return
 			}
 			tui.SetFocus("mainWorkContent")
 		}).
-		AddButton("Cancel", func() {
+		AddButton(i18n.T("Cancel"), func() {
 			tui.SetFocus("mainWorkContent")
 		})
 }
 
-func (f *Form) configureUpdateForm(tui *service.TUI, work *Work, chronoWork *domain.ChronoWork, relativeDays int) {
-	projectOptions := append([]string{notSelectText}, f.projectTypeUC.GetAllNames()...)
-	tagsOptions := []string{notSelectText}
-	f.Form.AddInputField("Title", chronoWork.Title, 50, nil, nil).
-		AddDropDown("Project", projectOptions, 0, f.projectDropDownChanged).
-		AddDropDown("Tags", tagsOptions, 0, nil)
-
-	if chronoWork.ProjectType != nil && chronoWork.ProjectType.Name != "" {
-		projectType, err := f.projectTypeUC.FindByName(chronoWork.ProjectType.Name)
-		if err != nil {
-			f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
-			return
-		}
-		for i, projectOption := range projectOptions {
-			if projectOption == chronoWork.ProjectType.Name {
-				f.Form.GetFormItemByLabel("Project").(*tview.DropDown).SetCurrentOption(i)
-				break
+func (f *Form) configureQuickAddForm(tui *service.TUI, work *Work, timer *Timer) {
+	f.Form.AddInputField(i18n.T("Quick Add"), "", 60, nil, nil).
+		AddCheckbox(i18n.T("Start Tracking"), false, nil).
+		AddButton(i18n.T("Add"), func() {
+			line := f.Form.GetFormItemByLabel(i18n.T("Quick Add")).(*tview.InputField).GetText()
+			start := f.Form.GetFormItemByLabel(i18n.T("Start Tracking")).(*tview.Checkbox).IsChecked()
+			chronoWork, err := f.quickAddUC.Create(line)
+			if err != nil {
+				f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
+				return
 			}
-		}
-
-		tagsOptions = append(tagsOptions, projectType.GetTagNames()...)
-		f.Form.GetFormItemByLabel("Tags").(*tview.DropDown).SetOptions(tagsOptions, nil)
-		if chronoWork.Tag != nil && chronoWork.Tag.Name != "" {
-			for i, tagOption := range tagsOptions {
-				if tagOption == chronoWork.Tag.Name {
-					f.Form.GetFormItemByLabel("Tags").(*tview.DropDown).SetCurrentOption(i)
-					break
-				}
+			if start {
+				_, err = work.toggleTracking(tui, timer, chronoWork.ID)
+			} else {
+				err = work.Refresh()
 			}
-		}
+			if err != nil {
+				f.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+				return
+			}
+			tui.Status.Info("Added %s.", chronoWork.Title)
+			tui.SetFocus("mainWorkContent")
+		}).
+		AddButton(i18n.T("Cancel"), func() {
+			tui.SetFocus("mainWorkContent")
+		})
+}
+
+func (f *Form) configureUpdateForm(tui *service.TUI, work *Work, chronoWork *domain.ChronoWork) {
+	var projectName, tagName string
+	if chronoWork.ProjectType != nil {
+		projectName = chronoWork.ProjectType.Name
+	}
+	if chronoWork.Tag != nil {
+		tagName = chronoWork.Tag.Name
 	}
+	f.Form.AddInputField(i18n.T("Title"), chronoWork.Title, 50, nil, nil)
+	f.addProjectAndTagPickers(tui, projectName, tagName)
 
-	f.Form.AddButton("Update", func() {
+	f.Form.AddButton(i18n.T("Update"), func() {
 		if err := f.update(chronoWork); err != nil {
 			f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
 			return
 		}
-		if err := work.ReStoreTable(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime()); err != nil {
+		if err := work.Refresh(); err != nil {
 			f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
 			return
 		}
 		tui.SetFocus("mainWorkContent")
 	}).
-		AddButton("Cancel", func() {
+		AddButton(i18n.T("Cancel"), func() {
 			tui.SetFocus("mainWorkContent")
 		})
 }
 
-func (f *Form) configureTimerForm(tui *service.TUI, work *Work, chronoWork *domain.ChronoWork, relativeDays int) {
+func (f *Form) configureTimerForm(tui *service.TUI, work *Work, chronoWork *domain.ChronoWork) {
 	hour := chronoWork.TotalSeconds / 3600
 	minute := (chronoWork.TotalSeconds - hour*3600) / 60
 	second := chronoWork.TotalSeconds - hour*3600 - minute*60
 
-	f.Form.AddInputField("Hour(0-)", fmt.Sprint(hour), 20, nil, nil).
-		AddInputField("Minute(0-59)", fmt.Sprint(minute), 20, nil, nil).
-		AddInputField("Second(0-59)", fmt.Sprint(second), 20, nil, nil).
-		AddButton("Reset", func() {
+	f.Form.AddInputField(i18n.T("Hour(0-)"), fmt.Sprint(hour), 20, nil, nil).
+		AddInputField(i18n.T("Minute(0-59)"), fmt.Sprint(minute), 20, nil, nil).
+		AddInputField(i18n.T("Second(0-59)"), fmt.Sprint(second), 20, nil, nil).
+		AddButton(i18n.T("Reset"), func() {
 			if err := f.resetTimer(chronoWork); err != nil {
 				f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
 				return
 			}
-			if err := work.ReStoreTable(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime()); err != nil {
+			if err := work.Refresh(); err != nil {
+				f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
+				return
+			}
+			tui.SetFocus("mainWorkContent")
+		}).
+		AddButton(i18n.T("Cancel"), func() {
+			tui.SetFocus("mainWorkContent")
+		})
+}
+
+func (f *Form) configureClosePeriodForm(tui *service.TUI, work *Work, timer *Timer) {
+	f.Form.AddInputField(i18n.T("Close Until(YYYY/MM/DD)"), time.Now().Format("2006/01/02"), 20, nil, nil).
+		AddButton(i18n.T("Close"), func() {
+			count, err := f.closePeriod()
+			if err != nil {
+				f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
+				return
+			}
+			// closing the period stops the tracking of the works it confirms
+			tracking, err := f.chronoWorkUC.FindTracking()
+			if err != nil {
+				f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
+				return
+			}
+			if len(tracking) == 0 {
+				timer.ResetSetText()
+				timer.StopCalculateSeconds()
+			}
+			if err := work.Refresh(); err != nil {
+				f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
+				return
+			}
+			tui.Status.Info("Period closed (%d works confirmed).", count)
+			tui.SetFocus("mainWorkContent")
+		}).
+		AddButton(i18n.T("Cancel"), func() {
+			tui.SetFocus("mainWorkContent")
+		})
+}
+
+func (f *Form) configureGoToDateForm(tui *service.TUI, work *Work) {
+	f.Form.AddInputField(i18n.T("Date(YYYY/MM/DD)"), work.endTime.Format("2006/01/02"), 20, nil, nil).
+		AddButton(i18n.T("Go"), func() {
+			date := f.Form.GetFormItemByLabel(i18n.T("Date(YYYY/MM/DD)")).(*tview.InputField).GetText()
+			parsed, err := time.ParseInLocation("2006/01/02", date, time.Local)
+			if err != nil {
+				f.errorHandler.ShowErrorWithErr(usecase.NewValidationError("invalid date format"), "mainWorkForm")
+				return
+			}
+			if err := work.GoToDate(parsed); err != nil {
 				f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
 				return
 			}
 			tui.SetFocus("mainWorkContent")
 		}).
-		AddButton("Cancel", func() {
+		AddButton(i18n.T("Cancel"), func() {
 			tui.SetFocus("mainWorkContent")
 		})
 }
 
-func (f *Form) projectDropDownChanged(option string, optionIndex int) {
-	var tagsOptions []string
-	if f.Form.GetFormItemByLabel("Tags") == nil {
-		return
+func (f *Form) configureSearchForm(tui *service.TUI, work *Work) {
+	f.Form.AddInputField(i18n.T("Search"), work.FilterQuery(), 50, nil, func(text string) {
+		if err := work.SetFilter(text); err != nil {
+			f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
+		}
+	}).
+		AddButton(i18n.T("Done"), func() {
+			tui.SetFocus("mainWorkContent")
+		}).
+		AddButton(i18n.T("Clear"), func() {
+			f.Form.GetFormItemByLabel(i18n.T("Search")).(*tview.InputField).SetText("")
+			tui.SetFocus("mainWorkContent")
+		})
+}
+
+func (f *Form) configureBatchForm(tui *service.TUI, work *Work, ids []uint) {
+	batchOptions := make([]string, len(batchActions))
+	for i, action := range batchActions {
+		batchOptions[i] = i18n.T(action)
 	}
-	projectType, err := f.projectTypeUC.FindByName(option)
+	f.Form.AddDropDown(i18n.T("Action(%d works)", len(ids)), batchOptions, 0, nil)
+	f.addProjectAndTagPickers(tui, "", "")
+	f.Form.AddInputField(i18n.T("Date(YYYY/MM/DD)"), work.endTime.Format("2006/01/02"), 20, nil, nil).
+		AddButton(i18n.T("Apply"), func() {
+			index, _ := f.Form.GetFormItem(0).(*tview.DropDown).GetCurrentOption()
+			action := batchActions[index]
+			apply := func() {
+				err := f.applyBatch(action, ids)
+				work.ClearMarks()
+				if refreshErr := work.Refresh(); refreshErr != nil && err == nil {
+					err = refreshErr
+				}
+				if err != nil {
+					f.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+					return
+				}
+				tui.Status.Info("%s: applied to %d works.", i18n.T(action), len(ids))
+				tui.SetFocus("mainWorkContent")
+			}
+			if action != "Delete" {
+				apply()
+				return
+			}
+			modal := tview.NewModal().
+				SetText(i18n.T("Are you sure you want to delete %d works?", len(ids))).
+				AddButtons([]string{i18n.T("Yes"), i18n.T("No")}).
+				SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+					tui.DeleteModal()
+					tui.SetFocus("mainWorkForm")
+					if buttonLabel == i18n.T("Yes") {
+						apply()
+					}
+				})
+			tui.SetModal(modal)
+			tui.SetFocus("modal")
+		}).
+		AddButton(i18n.T("Cancel"), func() {
+			tui.SetFocus("mainWorkContent")
+		})
+}
+
+// addTitleField adds a title field suggesting the titles of past works, most
+// frequent first. Taking a suggestion fills in the project and tag last used with it.
+func (f *Form) addTitleField() {
+	titles, err := f.chronoWorkUC.TitleSuggestions(recentWorksLimit)
 	if err != nil {
-		tagsOptions = []string{notSelectText}
-	} else {
-		tagsOptions = append([]string{notSelectText}, projectType.GetTagNames()...)
+		f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
 	}
-	f.Form.GetFormItemByLabel("Tags").(*tview.DropDown).
-		SetOptions(tagsOptions, nil).
-		SetCurrentOption(0)
+	f.titles = titles
+
+	var titleField *tview.InputField
+	titleField = newSuggestField(i18n.T("Title"), "", 50, f.titleEntries, func(entry string) {
+		titleField.SetText(entry)
+		for _, title := range f.titles {
+			if title.Title == entry {
+				f.fillProjectAndTag(title.ProjectTypeID, title.TagID)
+				break
+			}
+		}
+	})
+	f.Form.AddFormItem(titleField)
 }
 
-func (f *Form) store() error {
-	title := f.Form.GetFormItemByLabel("Title").(*tview.InputField).GetText()
-	_, projectVal := f.Form.GetFormItemByLabel("Project").(*tview.DropDown).GetCurrentOption()
-	_, tagVal := f.Form.GetFormItemByLabel("Tags").(*tview.DropDown).GetCurrentOption()
+func (f *Form) titleEntries(text string) []string {
+	titles := make([]string, len(f.titles))
+	for i, title := range f.titles {
+		titles[i] = title.Title
+	}
+	return fuzzyEntries(strings.TrimSpace(text), titles)
+}
 
-	if title == "" {
-		return nil
+// fillProjectAndTag sets the pickers to the project and tag with the given IDs.
+// Projects and tags that no longer exist are left out.
+func (f *Form) fillProjectAndTag(projectTypeID, tagID uint) {
+	var projectName, tagName string
+	for _, project := range f.projects {
+		if project.ID != projectTypeID {
+			continue
+		}
+		projectName = project.Name
+		for _, tag := range project.Tags {
+			if tag.ID == tagID {
+				tagName = tag.Name
+			}
+		}
 	}
+	f.projectPicker().SetText(projectName)
+	f.tagPicker().SetText(tagName)
+}
 
-	var projectTypeID uint
-	var tagID uint
-	if projectVal != notSelectText {
-		projectType, err := f.projectTypeUC.FindByName(projectVal)
+// addProjectAndTagPickers adds type-to-filter pickers for the project and its tag.
+// Tags are limited to the ones allowed on the project; a missing tag can be
+// created from the picker when the setting allows extending projects.
+func (f *Form) addProjectAndTagPickers(tui *service.TUI, projectName, tagName string) {
+	f.loadPickerCandidates()
+
+	var projectPicker, tagPicker *tview.InputField
+	projectPicker = newPicker(i18n.T("Project"), projectName, 50, f.projectEntries, func(entry string) {
+		projectPicker.SetText(entry)
+	})
+	tagPicker = newPicker(i18n.T("Tags"), tagName, 50, f.tagEntries, func(entry string) {
+		text := strings.TrimSpace(tagPicker.GetText())
+		if entry != i18n.T(createTagText, text) {
+			tagPicker.SetText(entry)
+			return
+		}
+		project := f.findProject(projectPicker.GetText())
+		if project == nil {
+			return
+		}
+		tag, err := f.projectTypeUC.AddTag(project.ID, text)
 		if err != nil {
 			f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
-			return err
+			return
 		}
-		projectTypeID = projectType.ID
-		if tagVal != notSelectText {
-			for _, tag := range projectType.Tags {
-				if tag.Name == tagVal {
-					tagID = tag.ID
-				}
+		f.loadPickerCandidates()
+		tagPicker.SetText(tag.Name)
+		tui.Status.Info("Tag %s added to project %s.", tag.Name, project.Name)
+	})
+	projectPicker.SetChangedFunc(func(text string) {
+		// A tag that the new project does not allow is dropped.
+		project := f.findProject(text)
+		if project == nil || !project.HasTag(tagPicker.GetText()) {
+			tagPicker.SetText("")
+		}
+	})
+	f.Form.AddFormItem(projectPicker).AddFormItem(tagPicker)
+}
+
+// loadPickerCandidates loads the projects with their allowed tags and the names
+// used by the latest works, which the pickers list first.
+func (f *Form) loadPickerCandidates() {
+	projects, err := f.projectTypeUC.FindAllWithTags()
+	if err != nil {
+		f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
+	}
+	f.projects = projects
+
+	f.recentProjects, f.recentTags = nil, nil
+	if works, err := f.chronoWorkUC.GetAll("updated_at desc", recentWorksLimit); err == nil {
+		for _, work := range works {
+			if work.ProjectType != nil {
+				f.recentProjects = append(f.recentProjects, work.ProjectType.Name)
+			}
+			if work.Tag != nil {
+				f.recentTags = append(f.recentTags, work.Tag.Name)
 			}
 		}
 	}
 
+	setting, err := f.settingUC.Get()
+	f.allowTagCreation = err == nil && setting.AllowTagCreation
+}
+
+func (f *Form) projectEntries(text string) []string {
+	names := make([]string, len(f.projects))
+	for i, project := range f.projects {
+		names[i] = project.Name
+	}
+	return fuzzyEntries(strings.TrimSpace(text), strutil.MoveToFront(names, f.recentProjects))
+}
+
+func (f *Form) tagEntries(text string) []string {
+	project := f.findProject(f.projectPicker().GetText())
+	if project == nil {
+		return nil
+	}
+	text = strings.TrimSpace(text)
+	entries := fuzzyEntries(text, strutil.MoveToFront(project.GetTagNames(), f.recentTags))
+	if f.allowTagCreation && text != "" && !project.HasTag(text) {
+		entries = append(entries, i18n.T(createTagText, text))
+	}
+	return entries
+}
+
+// findProject returns the loaded project named name, or nil if there is none.
+func (f *Form) findProject(name string) *domain.ProjectType {
+	name = strings.TrimSpace(name)
+	for i := range f.projects {
+		if f.projects[i].Name == name {
+			return &f.projects[i]
+		}
+	}
+	return nil
+}
+
+func (f *Form) projectPicker() *tview.InputField {
+	return f.Form.GetFormItemByLabel(i18n.T("Project")).(*tview.InputField)
+}
+
+func (f *Form) tagPicker() *tview.InputField {
+	return f.Form.GetFormItemByLabel(i18n.T("Tags")).(*tview.InputField)
+}
+
+// selectProjectAndTag preselects the project and one of its tags in the store form.
+func (f *Form) selectProjectAndTag(projectType domain.ProjectType, tagName string) {
+	f.projectPicker().SetText(projectType.Name)
+	f.tagPicker().SetText(tagName)
+}
+
+func (f *Form) store() error {
+	title := f.Form.GetFormItemByLabel(i18n.T("Title")).(*tview.InputField).GetText()
+	dateVal := f.Form.GetFormItemByLabel(i18n.T("Date(YYYY/MM/DD)")).(*tview.InputField).GetText()
+
+	if title == "" {
+		return nil
+	}
+
+	projectTypeID, tagID, err := f.selectedProjectAndTag()
+	if err != nil {
+		return err
+	}
+
+	date, err := time.ParseInLocation("2006/01/02", dateVal, time.Local)
+	if err != nil {
+		return usecase.NewValidationError("invalid date format")
+	}
+
 	// 4. ユースケースを呼び出す（ビジネスロジックに委譲）
-	if _, err := f.chronoWorkUC.Create(title, projectTypeID, tagID); err != nil {
+	if _, err := f.chronoWorkUC.CreateOnDate(title, projectTypeID, tagID, date); err != nil {
 		f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
 		return err
 	}
@@ -201,29 +481,14 @@ func (f *Form) store() error {
 }
 
 func (f *Form) update(chronoWork *domain.ChronoWork) error {
-	title := f.Form.GetFormItemByLabel("Title").(*tview.InputField).GetText()
-	_, projectVal := f.Form.GetFormItemByLabel("Project").(*tview.DropDown).GetCurrentOption()
-	_, tagVal := f.Form.GetFormItemByLabel("Tags").(*tview.DropDown).GetCurrentOption()
+	title := f.Form.GetFormItemByLabel(i18n.T("Title")).(*tview.InputField).GetText()
 
 	if title == "" {
 		return nil
 	}
-	var projectTypeID uint = 0
-	var tagID uint = 0
-	if projectVal != notSelectText {
-		projectType, err := f.projectTypeUC.FindByName(projectVal)
-		if err != nil {
-			f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
-			return err
-		}
-		projectTypeID = projectType.ID
-		if tagVal != notSelectText {
-			for _, tag := range projectType.Tags {
-				if tag.Name == tagVal {
-					tagID = tag.ID
-				}
-			}
-		}
+	projectTypeID, tagID, err := f.selectedProjectAndTag()
+	if err != nil {
+		return err
 	}
 	if err := f.chronoWorkUC.Update(chronoWork.ID, title, projectTypeID, tagID); err != nil {
 		f.errorHandler.ShowErrorWithErr(err, "mainWorkForm")
@@ -234,9 +499,9 @@ func (f *Form) update(chronoWork *domain.ChronoWork) error {
 }
 
 func (f *Form) resetTimer(chronoWork *domain.ChronoWork) error {
-	hour := f.Form.GetFormItemByLabel("Hour(0-)").(*tview.InputField).GetText()
-	minute := f.Form.GetFormItemByLabel("Minute(0-59)").(*tview.InputField).GetText()
-	second := f.Form.GetFormItemByLabel("Second(0-59)").(*tview.InputField).GetText()
+	hour := f.Form.GetFormItemByLabel(i18n.T("Hour(0-)")).(*tview.InputField).GetText()
+	minute := f.Form.GetFormItemByLabel(i18n.T("Minute(0-59)")).(*tview.InputField).GetText()
+	second := f.Form.GetFormItemByLabel(i18n.T("Second(0-59)")).(*tview.InputField).GetText()
 
 	var hourInt, minuteInt, secondInt uint64
 	var err error
@@ -258,3 +523,66 @@ func (f *Form) resetTimer(chronoWork *domain.ChronoWork) error {
 
 	return nil
 }
+
+func (f *Form) closePeriod() (int, error) {
+	until := f.Form.GetFormItemByLabel(i18n.T("Close Until(YYYY/MM/DD)")).(*tview.InputField).GetText()
+	untilDate, err := time.ParseInLocation("2006/01/02", until, time.Local)
+	if err != nil {
+		return 0, usecase.NewValidationError("invalid date format")
+	}
+	return f.chronoWorkUC.ClosePeriod(untilDate)
+}
+
+func (f *Form) applyBatch(action string, ids []uint) error {
+	var err error
+	switch action {
+	case "Confirm":
+		_, err = f.chronoWorkUC.BatchConfirm(ids, true)
+	case "Reopen":
+		_, err = f.chronoWorkUC.BatchConfirm(ids, false)
+	case "Delete":
+		_, err = f.chronoWorkUC.BatchDelete(ids)
+	case "Reassign Project/Tag":
+		projectTypeID, tagID, findErr := f.selectedProjectAndTag()
+		if findErr != nil {
+			return findErr
+		}
+		_, err = f.chronoWorkUC.BatchReassign(ids, projectTypeID, tagID)
+	case "Move to Date":
+		dateVal := f.Form.GetFormItemByLabel(i18n.T("Date(YYYY/MM/DD)")).(*tview.InputField).GetText()
+		date, parseErr := time.ParseInLocation("2006/01/02", dateVal, time.Local)
+		if parseErr != nil {
+			return usecase.NewValidationError("invalid date format")
+		}
+		_, err = f.chronoWorkUC.BatchMoveToDate(ids, date)
+	case "Copy to Today":
+		_, err = f.chronoWorkUC.BatchCopyToToday(ids)
+	}
+	return err
+}
+
+// selectedProjectAndTag resolves the IDs of the project and tag entered in the form.
+// The tag must be one of the tags allowed on the project.
+func (f *Form) selectedProjectAndTag() (uint, uint, error) {
+	projectName := strings.TrimSpace(f.projectPicker().GetText())
+	tagName := strings.TrimSpace(f.tagPicker().GetText())
+	if projectName == "" {
+		if tagName != "" {
+			return 0, 0, usecase.NewValidationError("tag requires a project")
+		}
+		return 0, 0, nil
+	}
+	projectType := f.findProject(projectName)
+	if projectType == nil {
+		return 0, 0, usecase.NewValidationErrorf("unknown project: %s", projectName)
+	}
+	if tagName == "" {
+		return projectType.ID, 0, nil
+	}
+	for _, tag := range projectType.Tags {
+		if tag.Name == tagName {
+			return projectType.ID, tag.ID, nil
+		}
+	}
+	return 0, 0, usecase.NewValidationErrorf("tag %s is not allowed on project %s", tagName, projectName)
+}
@@ -0,0 +1,225 @@
+package widgets
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/niiharamegumu/chronowork/util/timereport"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+	"github.com/niiharamegumu/chronowork/util/timew"
+	"github.com/rivo/tview"
+)
+
+// importFormats are the file formats of the import page.
+var importFormats = []string{
+	"iCalendar",
+	"Toggl CSV",
+	"Clockify CSV",
+	"Timewarrior",
+}
+
+// reportFormats are the report formats of the CSV import formats.
+var reportFormats = map[string]timereport.Format{
+	"Toggl CSV":    timereport.Toggl,
+	"Clockify CSV": timereport.Clockify,
+}
+
+// importSection is a titled list of the items that were not imported.
+type importSection struct {
+	title string
+	items []string
+}
+
+// Import creates works from the events of a calendar file, the entries of a
+// time tracker report or the intervals of Timewarrior and shows what was not
+// imported.
+type Import struct {
+	Layout         *tview.Grid
+	Form           *tview.Form
+	Result         *tview.TextView
+	icsImportUC    *usecase.ICSImportUseCase
+	reportImportUC *usecase.ReportImportUseCase
+	timewImportUC  *usecase.TimewImportUseCase
+	errorHandler   *service.ErrorHandler
+	theme          *service.Theme
+}
+
+func NewImport(icsImportUC *usecase.ICSImportUseCase, reportImportUC *usecase.ReportImportUseCase, timewImportUC *usecase.TimewImportUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Import {
+	return &Import{
+		Layout: tview.NewGrid().
+			SetRows(11, 0).
+			SetColumns(0).
+			SetBorders(true),
+		Form: tview.NewForm().
+			SetButtonBackgroundColor(theme.Accent).
+			SetButtonTextColor(theme.AccentText).
+			SetLabelColor(theme.Accent).
+			SetFieldTextColor(theme.FieldText).
+			SetFieldBackgroundColor(theme.FieldBackground),
+		Result: tview.NewTextView().
+			SetDynamicColors(true).
+			SetScrollable(true),
+		icsImportUC:    icsImportUC,
+		reportImportUC: reportImportUC,
+		timewImportUC:  timewImportUC,
+		errorHandler:   errorHandler,
+		theme:          theme,
+	}
+}
+
+func (im *Import) GenerateInitImport(tui *service.TUI) *Import {
+	im.Layout.AddItem(im.Form, 0, 0, 1, 1, 0, 0, true)
+	im.Layout.AddItem(im.Result, 1, 0, 1, 1, 0, 0, false)
+	im.setForm(tui)
+	return im
+}
+
+// ReStore resets the form and clears the result of the last import.
+func (im *Import) ReStore(tui *service.TUI) {
+	im.Form.Clear(true)
+	im.Result.Clear()
+	im.setForm(tui)
+}
+
+func (im *Import) setForm(tui *service.TUI) {
+	// the range of the imported events and entries, this week by default
+	start := timeutil.StartOfWeek(time.Now())
+	im.Form.AddDropDown(i18n.T("Format"), importFormats, 0, func(option string, _ int) {
+		if option == "iCalendar" {
+			im.showRules()
+		} else {
+			im.Result.Clear()
+		}
+	}).
+		AddInputField(i18n.T("File"), "", 60, nil, nil).
+		AddInputField(i18n.T("From(YYYY/MM/DD)"), start.Format("2006/01/02"), 20, nil, nil).
+		AddInputField(i18n.T("To(YYYY/MM/DD)"), start.AddDate(0, 0, 6).Format("2006/01/02"), 20, nil, nil).
+		AddButton(i18n.T("Import"), func() {
+			created, sections, ok := im.importFile()
+			if !ok {
+				return
+			}
+			tui.Status.Info("Imported %d works", created)
+			im.showResult(created, sections)
+		}).
+		AddButton(i18n.T("Cancel"), func() {
+			im.ReStore(tui)
+			tui.SetFocus("menu")
+		})
+}
+
+// importFile imports the file of the form in its range and returns the number
+// of works created and the items that were not imported, or false if the
+// import failed. Calendars use the rules of the rule file and reports the
+// mapping of the report mapping file. The file of Timewarrior may also be its
+// data directory.
+func (im *Import) importFile() (int, []importSection, bool) {
+	_, format := im.Form.GetFormItemByLabel(i18n.T("Format")).(*tview.DropDown).GetCurrentOption()
+	path := strings.TrimSpace(im.Form.GetFormItemByLabel(i18n.T("File")).(*tview.InputField).GetText())
+	from := im.Form.GetFormItemByLabel(i18n.T("From(YYYY/MM/DD)")).(*tview.InputField).GetText()
+	to := im.Form.GetFormItemByLabel(i18n.T("To(YYYY/MM/DD)")).(*tview.InputField).GetText()
+	start, err1 := time.ParseInLocation("2006/01/02", from, time.Local)
+	end, err2 := time.ParseInLocation("2006/01/02", to, time.Local)
+	if err1 != nil || err2 != nil {
+		im.errorHandler.ShowErrorWithErr(usecase.NewValidationError("invalid date format"), "importForm")
+		return 0, nil, false
+	}
+	start, end = timeutil.StartOfDay(start), timeutil.EndOfDay(end)
+
+	if format == "Timewarrior" {
+		intervals, err := timew.ReadPath(path)
+		if err != nil {
+			im.errorHandler.ShowErrorWithErr(err, "importForm")
+			return 0, nil, false
+		}
+		result, err := im.timewImportUC.Import(intervals, start, end)
+		if err != nil {
+			im.errorHandler.ShowErrorWithErr(err, "importForm")
+			return 0, nil, false
+		}
+		return result.Created, []importSection{{title: "Skipped:", items: result.Skipped}}, true
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		im.errorHandler.ShowErrorWithErr(err, "importForm")
+		return 0, nil, false
+	}
+	defer f.Close()
+
+	if reportFormat, ok := reportFormats[format]; ok {
+		mapping, err := service.LoadReportMapping(service.ReportMappingPath())
+		if err != nil {
+			im.errorHandler.ShowErrorWithErr(err, "importForm")
+			return 0, nil, false
+		}
+		result, err := im.reportImportUC.Import(f, reportFormat, start, end, mapping)
+		if err != nil {
+			im.errorHandler.ShowErrorWithErr(err, "importForm")
+			return 0, nil, false
+		}
+		return result.Created, []importSection{
+			{title: "Unmapped:", items: result.Unmapped},
+			{title: "Duplicates:", items: result.Duplicates},
+			{title: "Conflicts:", items: result.Conflicts},
+			{title: "Skipped:", items: result.Skipped},
+		}, true
+	}
+
+	rules, err := service.LoadICSRules(service.ICSRulesPath())
+	if err != nil {
+		im.errorHandler.ShowErrorWithErr(err, "importForm")
+		return 0, nil, false
+	}
+	result, err := im.icsImportUC.Import(f, start, end, rules)
+	if err != nil {
+		im.errorHandler.ShowErrorWithErr(err, "importForm")
+		return 0, nil, false
+	}
+	return result.Created, []importSection{{title: "Skipped:", items: result.Skipped}}, true
+}
+
+// showRules shows the rules of the rule file that set the projects and tags of
+// the works imported from calendars.
+func (im *Import) showRules() {
+	path := service.ICSRulesPath()
+	rules, err := service.LoadICSRules(path)
+	if err != nil {
+		im.Result.SetText(tview.Escape(err.Error()))
+		return
+	}
+	if len(rules) == 0 {
+		im.Result.SetText(tview.Escape(i18n.T("No rules in %s; events get no project or tag.", path)))
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s[-]\n", service.ColorTag(im.theme.Accent), tview.Escape(i18n.T("Rules of %s:", path)))
+	for _, rule := range rules {
+		target := rule.Project
+		if rule.Tag != "" {
+			target += " / " + rule.Tag
+		}
+		fmt.Fprintf(&b, "  %s\n", tview.Escape(fmt.Sprintf("%q -> %s", rule.Keyword, target)))
+	}
+	im.Result.SetText(b.String()).ScrollToBeginning()
+}
+
+func (im *Import) showResult(created int, sections []importSection) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s[-]\n", service.ColorTag(im.theme.Accent), i18n.T("Imported %d works", created))
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s\n", i18n.T(section.title))
+		for _, item := range section.items {
+			fmt.Fprintf(&b, "  %s\n", tview.Escape(item))
+		}
+	}
+	im.Result.SetText(b.String()).ScrollToBeginning()
+}
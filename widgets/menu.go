@@ -1,58 +1,131 @@
 package widgets
 
 import (
+	"time"
+
 	"github.com/niiharamegumu/chronowork/internal/usecase"
 	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
 	"github.com/niiharamegumu/chronowork/util/timeutil"
 	"github.com/rivo/tview"
 )
 
 type Menu struct {
-	List      *tview.List
-	settingUC *usecase.SettingUseCase
+	List         *tview.List
+	items        []menuItem
+	settingUC    *usecase.SettingUseCase
+	backupUC     *usecase.BackupUseCase
+	errorHandler *service.ErrorHandler
+}
+
+type menuItem struct {
+	text     string
+	selected func()
 }
 
-func NewMenu(settingUC *usecase.SettingUseCase) *Menu {
+func NewMenu(settingUC *usecase.SettingUseCase, backupUC *usecase.BackupUseCase, errorHandler *service.ErrorHandler) *Menu {
 	return &Menu{
-		List:      tview.NewList(),
-		settingUC: settingUC,
+		List:         tview.NewList(),
+		settingUC:    settingUC,
+		backupUC:     backupUC,
+		errorHandler: errorHandler,
 	}
 }
 
 func (m *Menu) addListItem(text string, shortcut rune, selected func()) *Menu {
-	m.List.AddItem(text, "", shortcut, selected)
+	m.List.AddItem(i18n.T(text), "", shortcut, selected)
+	m.items = append(m.items, menuItem{text: text, selected: selected})
 	return m
 }
 
-func (m *Menu) GenerateInitMenu(tui *service.TUI, work *Work, setting *Setting, project *Project) *Menu {
-	m.addListItem("Works", 'w', func() {
+func (m *Menu) GenerateInitMenu(tui *service.TUI, work *Work, setting *Setting, project *Project, notification *Notification, template *Template, calendar *Calendar, timeline *Timeline, chart *Chart, timesheet *Timesheet, imp *Import) *Menu {
+	m.addListItem("Works", tui.Keymap.Rune(service.ContextMenu, "works"), func() {
 		relativeDays := m.getRelativeDays()
 		work.ReStoreTable(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime())
 		tui.ChangeToPage("work")
 		tui.SetFocus("mainWorkContent")
 	})
-	m.addListItem("Projects", 'p', func() {
+	m.addListItem("Calendar", tui.Keymap.Rune(service.ContextMenu, "calendar"), func() {
+		calendar.Restore()
+		tui.ChangeToPage("calendar")
+		tui.SetFocus("calendarTable")
+	})
+	m.addListItem("Timeline", tui.Keymap.Rune(service.ContextMenu, "timeline"), func() {
+		timeline.ShowDate(time.Now())
+		tui.ChangeToPage("timeline")
+		tui.SetFocus("timelineView")
+	})
+	m.addListItem("Charts", tui.Keymap.Rune(service.ContextMenu, "charts"), func() {
+		chart.ShowThisWeek()
+		tui.ChangeToPage("chart")
+		tui.SetFocus("chartForm")
+	})
+	m.addListItem("Timesheet", tui.Keymap.Rune(service.ContextMenu, "timesheet"), func() {
+		timesheet.ShowWeek(time.Now())
+		tui.ChangeToPage("timesheet")
+		tui.SetFocus("timesheetTable")
+	})
+	m.addListItem("Projects", tui.Keymap.Rune(service.ContextMenu, "projects"), func() {
 		project.RestoreTable()
 		tui.ChangeToPage("project")
 		tui.SetFocus("projectTable")
 	})
-	m.addListItem("Tags", 't', func() {
+	m.addListItem("Tags", tui.Keymap.Rune(service.ContextMenu, "tags"), func() {
 		tui.ChangeToPage("tag")
 		tui.SetFocus("tagTable")
 	})
-	m.addListItem("Export", 'e', func() {
+	m.addListItem("Templates", tui.Keymap.Rune(service.ContextMenu, "templates"), func() {
+		template.RestoreTable()
+		tui.ChangeToPage("template")
+		tui.SetFocus("templateTable")
+	})
+	m.addListItem("Export", tui.Keymap.Rune(service.ContextMenu, "export"), func() {
 		tui.ChangeToPage("export")
 		tui.SetFocus("exportForm")
 	})
-	m.addListItem("Setting", 's', func() {
+	m.addListItem("Import", tui.Keymap.Rune(service.ContextMenu, "import"), func() {
+		tui.ChangeToPage("import")
+		tui.SetFocus("importForm")
+	})
+	m.addListItem("Backup", tui.Keymap.Rune(service.ContextMenu, "backup"), func() {
+		if path := m.backup(); path != "" {
+			tui.Status.Info("Backed up to %s", path)
+		}
+	})
+	m.addListItem("Audit", tui.Keymap.Rune(service.ContextMenu, "audit"), func() {
+		tui.ChangeToPage("audit")
+		tui.SetFocus("auditForm")
+	})
+	m.addListItem("Notifications", tui.Keymap.Rune(service.ContextMenu, "notifications"), func() {
+		notification.Show(tui)
+		tui.ChangeToPage("notification")
+		tui.SetFocus("notificationTable")
+	})
+	m.addListItem("Setting", tui.Keymap.Rune(service.ContextMenu, "setting"), func() {
 		setting.ReStore(tui)
 		tui.ChangeToPage("setting")
 		tui.SetFocus("settingForm")
 	})
-	m.addListItem("Quit", 'q', tui.Quit)
+	m.addListItem("Quit", tui.Keymap.Rune(service.ContextMenu, "quit"), tui.Quit)
 	return m
 }
 
+// backup writes a snapshot of the database to the backup directory of the
+// backup config file and returns the path of the archive, or "" if it failed.
+func (m *Menu) backup() string {
+	config, err := service.LoadBackupConfig(service.BackupConfigPath())
+	if err != nil {
+		m.errorHandler.ShowErrorWithErr(err, "menu")
+		return ""
+	}
+	path, err := m.backupUC.Backup(config.Dir, config.Keep, time.Now())
+	if err != nil {
+		m.errorHandler.ShowErrorWithErr(err, "menu")
+		return ""
+	}
+	return path
+}
+
 func (m *Menu) getRelativeDays() int {
 	setting, err := m.settingUC.Get()
 	if err != nil {
@@ -0,0 +1,68 @@
+package widgets
+
+import (
+	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/rivo/tview"
+)
+
+var (
+	notificationHeader = []string{
+		"Time",
+		"Level",
+		"Message",
+	}
+)
+
+// Notification lists the messages shown in the status bar, newest first.
+type Notification struct {
+	Table *tview.Table
+	theme *service.Theme
+}
+
+func NewNotification(theme *service.Theme) *Notification {
+	return &Notification{
+		Table: tview.NewTable().
+			SetSelectable(true, false).
+			SetFixed(1, 1),
+		theme: theme,
+	}
+}
+
+// Show fills the table with the current history of the status bar.
+func (n *Notification) Show(tui *service.TUI) {
+	n.Table.Clear()
+	n.setTableHeader()
+	n.setTableBody(tui.Status.History())
+	n.Table.ScrollToBeginning().Select(1, 0)
+}
+
+func (n *Notification) setTableHeader() {
+	for i, header := range notificationHeader {
+		tableCell := tview.NewTableCell(i18n.T(header)).
+			SetAlign(tview.AlignLeft).
+			SetTextColor(n.theme.AccentText).
+			SetBackgroundColor(n.theme.Accent).
+			SetSelectable(false)
+		if header == "Message" {
+			tableCell.SetExpansion(1)
+		}
+		n.Table.SetCell(0, i, tableCell)
+	}
+}
+
+func (n *Notification) setTableBody(history []service.Notification) {
+	for i, notification := range history {
+		row := i + 1
+		levelColor := n.theme.Positive
+		switch notification.Level {
+		case service.LevelWarning:
+			levelColor = n.theme.Warning
+		case service.LevelError:
+			levelColor = n.theme.Negative
+		}
+		n.Table.SetCell(row, 0, tview.NewTableCell(i18n.FormatDateTime(notification.Time)))
+		n.Table.SetCell(row, 1, tview.NewTableCell(i18n.T(notification.Level.String())).SetTextColor(levelColor))
+		n.Table.SetCell(row, 2, tview.NewTableCell(tview.Escape(notification.Message)).SetExpansion(1))
+	}
+}
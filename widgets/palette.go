@@ -0,0 +1,239 @@
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/niiharamegumu/chronowork/util/strutil"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+	"github.com/rivo/tview"
+)
+
+type paletteCommand struct {
+	title string
+	run   func()
+}
+
+// Palette is a fuzzy-searchable list of the actions of every page.
+type Palette struct {
+	Input         *tview.InputField
+	List          *tview.List
+	Layout        *tview.Flex
+	chronoWorkUC  *usecase.ChronoWorkUseCase
+	projectTypeUC *usecase.ProjectTypeUseCase
+	errorHandler  *service.ErrorHandler
+	commands      []paletteCommand
+	matches       []int
+	returnTo      string
+}
+
+func NewPalette(chronoWorkUC *usecase.ChronoWorkUseCase, projectTypeUC *usecase.ProjectTypeUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Palette {
+	input := tview.NewInputField().
+		SetLabel("> ").
+		SetLabelColor(theme.Accent).
+		SetFieldBackgroundColor(tcell.ColorDefault)
+	list := tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true)
+	box := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	box.SetBorder(true).SetTitle(i18n.T(" Commands ")).SetTitleAlign(tview.AlignLeft)
+
+	return &Palette{
+		Input: input,
+		List:  list,
+		Layout: tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+				AddItem(nil, 0, 1, false).
+				AddItem(box, 20, 0, true).
+				AddItem(nil, 0, 1, false), 80, 0, true).
+			AddItem(nil, 0, 1, false),
+		chronoWorkUC:  chronoWorkUC,
+		projectTypeUC: projectTypeUC,
+		errorHandler:  errorHandler,
+	}
+}
+
+func (p *Palette) GenerateInitPalette(tui *service.TUI, menu *Menu, work *Work, form *Form, timer *Timer, export *Export, template *Template) {
+	p.Input.SetChangedFunc(p.filter)
+	p.Input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyCtrlK:
+			if current := p.List.GetCurrentItem(); current > 0 {
+				p.List.SetCurrentItem(current - 1)
+			}
+			return nil
+		case tcell.KeyDown, tcell.KeyCtrlJ:
+			p.List.SetCurrentItem(p.List.GetCurrentItem() + 1)
+			return nil
+		case tcell.KeyEnter:
+			p.run(tui)
+			return nil
+		case tcell.KeyEscape:
+			p.close(tui)
+			return nil
+		}
+		return event
+	})
+
+	tui.SetGlobalAction("palette", func() {
+		p.Show(tui, p.buildCommands(tui, menu, work, form, timer, export, template))
+	})
+}
+
+// Show opens the palette with the given commands over the current page.
+// Closing it gives the focus back to the widget that had it, or to the menu.
+func (p *Palette) Show(tui *service.TUI, commands []paletteCommand) {
+	p.returnTo = tui.FocusedWidget()
+	if p.returnTo == "" {
+		p.returnTo = "menu"
+	}
+	p.commands = commands
+	p.Input.SetText("")
+	p.filter("")
+	tui.SetModal(p.Layout)
+	tui.App.SetFocus(p.Input)
+}
+
+func (p *Palette) filter(query string) {
+	titles := make([]string, len(p.commands))
+	for i, c := range p.commands {
+		titles[i] = c.title
+	}
+	p.matches = strutil.FuzzyFilter(query, titles)
+	p.List.Clear()
+	for _, i := range p.matches {
+		p.List.AddItem(tview.Escape(p.commands[i].title), "", 0, nil)
+	}
+}
+
+func (p *Palette) run(tui *service.TUI) {
+	if len(p.matches) == 0 {
+		return
+	}
+	command := p.commands[p.matches[p.List.GetCurrentItem()]]
+	p.close(tui)
+	command.run()
+}
+
+func (p *Palette) close(tui *service.TUI) {
+	tui.DeleteModal()
+	tui.SetFocus(p.returnTo)
+}
+
+func (p *Palette) buildCommands(tui *service.TUI, menu *Menu, work *Work, form *Form, timer *Timer, export *Export, template *Template) []paletteCommand {
+	var commands []paletteCommand
+
+	// pages
+	for _, item := range menu.items {
+		title := i18n.T("Go to %s", i18n.T(item.text))
+		// the actions of the menu are not pages
+		if item.text == "Quit" || item.text == "Backup" {
+			title = i18n.T(item.text)
+		}
+		commands = append(commands, paletteCommand{title: title, run: item.selected})
+	}
+
+	// tracking
+	chronoWorks, err := p.chronoWorkUC.FindInRange(work.startTime, work.endTime)
+	if err != nil {
+		p.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+	}
+	for _, cw := range chronoWorks {
+		id := cw.ID
+		title := i18n.T("Start tracking: %s", cw.Title)
+		if cw.IsTracking {
+			title = i18n.T("Stop tracking: %s", cw.Title)
+		}
+		if !timeutil.IsToday(cw.CreatedAt) {
+			title += i18n.T(" (%s, copy to today)", i18n.FormatDate(cw.CreatedAt))
+		}
+		commands = append(commands, paletteCommand{title: title, run: func() {
+			tui.ChangeToPage("work")
+			tui.SetFocus("mainWorkContent")
+			if _, err := work.toggleTracking(tui, timer, id); err != nil {
+				p.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+			}
+		}})
+	}
+
+	// work forms
+	workForm := func(configure func()) func() {
+		return func() {
+			form.Form.Clear(true)
+			configure()
+			tui.ChangeToPage("work")
+			tui.SetFocus("mainWorkForm")
+		}
+	}
+	commands = append(commands,
+		paletteCommand{title: i18n.T("Add work"), run: workForm(func() { form.ConfigureStoreForm(tui, work) })},
+		paletteCommand{title: i18n.T("Quick add work"), run: workForm(func() { form.configureQuickAddForm(tui, work, timer) })},
+		paletteCommand{title: i18n.T("Go to date"), run: workForm(func() { form.configureGoToDateForm(tui, work) })},
+		paletteCommand{title: i18n.T("Search works"), run: workForm(func() { form.configureSearchForm(tui, work) })},
+		paletteCommand{title: i18n.T("Close period"), run: workForm(func() { form.configureClosePeriodForm(tui, work, timer) })},
+		paletteCommand{title: i18n.T("Show today"), run: func() {
+			if err := work.ShowToday(); err != nil {
+				p.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+				return
+			}
+			tui.ChangeToPage("work")
+			tui.SetFocus("mainWorkContent")
+		}},
+	)
+	projectTypes, err := p.projectTypeUC.FindAllWithTags()
+	if err != nil {
+		p.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+	}
+	for _, projectType := range projectTypes {
+		projectType := projectType
+		commands = append(commands, paletteCommand{
+			title: i18n.T("Add work: @%s", projectType.Name),
+			run: workForm(func() {
+				form.ConfigureStoreForm(tui, work)
+				form.selectProjectAndTag(projectType, "")
+			}),
+		})
+		for _, tagName := range projectType.GetTagNames() {
+			tagName := tagName
+			commands = append(commands, paletteCommand{
+				title: i18n.T("Add work: @%s #%s", projectType.Name, tagName),
+				run: workForm(func() {
+					form.ConfigureStoreForm(tui, work)
+					form.selectProjectAndTag(projectType, tagName)
+				}),
+			})
+		}
+	}
+
+	// templates
+	commands = append(commands, paletteCommand{title: i18n.T("Create today's works from templates"), run: func() {
+		template.Materialize(tui, work)
+		tui.ChangeToPage("work")
+		tui.SetFocus("mainWorkContent")
+	}})
+
+	// export
+	commands = append(commands, paletteCommand{title: i18n.T("Export CSV"), run: func() {
+		tui.ChangeToPage("export")
+		if path := export.export(); path != "" {
+			tui.Status.Info("Exported to %s", path)
+		}
+		tui.SetFocus("exportForm")
+	}})
+	commands = append(commands, paletteCommand{title: i18n.T("Export iCalendar"), run: func() {
+		tui.ChangeToPage("export")
+		export.exportICS(tui)
+		tui.SetFocus("exportForm")
+	}})
+	commands = append(commands, paletteCommand{title: i18n.T("Export Timewarrior"), run: func() {
+		tui.ChangeToPage("export")
+		export.exportTimew(tui)
+		tui.SetFocus("exportForm")
+	}})
+
+	return commands
+}
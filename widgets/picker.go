@@ -0,0 +1,82 @@
+package widgets
+
+import (
+	"github.com/niiharamegumu/chronowork/util/strutil"
+	"github.com/rivo/tview"
+)
+
+// pickerMaxEntries is the maximum number of entries shown in a picker drop-down.
+const pickerMaxEntries = 10
+
+// newPicker creates a type-to-filter input field. entries returns the drop-down
+// entries for the current text and selected is called with the chosen entry.
+// The drop-down is shown only while the field has focus, so an empty field lists
+// the first entries as soon as it is entered.
+func newPicker(label, value string, width int, entries func(text string) []string, selected func(entry string)) *tview.InputField {
+	picker := tview.NewInputField().
+		SetLabel(label).
+		SetText(value).
+		SetFieldWidth(width)
+	picker.SetAutocompleteFunc(func(text string) []string {
+		if !picker.HasFocus() {
+			return nil
+		}
+		return entries(text)
+	})
+	picker.SetAutocompletedFunc(func(entry string, index, source int) bool {
+		if source == tview.AutocompletedNavigate {
+			return false
+		}
+		selected(entry)
+		return true
+	})
+	picker.SetFocusFunc(func() {
+		picker.Autocomplete()
+	})
+	return picker
+}
+
+// fuzzyEntries returns the candidates matching text, best matches first. The
+// order of candidates is kept among equally good matches.
+func fuzzyEntries(text string, candidates []string) []string {
+	var result []string
+	for _, index := range strutil.FuzzyFilter(text, candidates) {
+		if len(result) == pickerMaxEntries {
+			break
+		}
+		result = append(result, candidates[index])
+	}
+	return result
+}
+
+// newSuggestField creates an input field that suggests entries while typing
+// without forcing them: Enter and Tab take a suggestion only after the user
+// moved to it with the arrow keys, so new text can still be entered as typed.
+func newSuggestField(label, value string, width int, entries func(text string) []string, selected func(entry string)) *tview.InputField {
+	var navigated bool
+	field := tview.NewInputField().
+		SetLabel(label).
+		SetText(value).
+		SetFieldWidth(width)
+	field.SetAutocompleteFunc(func(text string) []string {
+		navigated = false
+		if !field.HasFocus() || text == "" {
+			return nil
+		}
+		return entries(text)
+	})
+	field.SetAutocompletedFunc(func(entry string, index, source int) bool {
+		switch source {
+		case tview.AutocompletedNavigate:
+			navigated = true
+			return false
+		case tview.AutocompletedEnter, tview.AutocompletedTab:
+			if !navigated {
+				return true
+			}
+		}
+		selected(entry)
+		return true
+	})
+	return field
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/niiharamegumu/chronowork/internal/usecase"
 	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
 	"github.com/niiharamegumu/chronowork/util/strutil"
 	"github.com/rivo/tview"
 )
@@ -29,24 +30,27 @@ type Project struct {
 	tagUC         *usecase.TagUseCase
 	chronoWorkUC  *usecase.ChronoWorkUseCase
 	errorHandler  *service.ErrorHandler
+	theme         *service.Theme
 }
 
-func NewProject(projectTypeUC *usecase.ProjectTypeUseCase, tagUC *usecase.TagUseCase, chronoWorkUC *usecase.ChronoWorkUseCase, errorHandler *service.ErrorHandler) *Project {
+func NewProject(projectTypeUC *usecase.ProjectTypeUseCase, tagUC *usecase.TagUseCase, chronoWorkUC *usecase.ChronoWorkUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Project {
 	return &Project{
 		Layout: tview.NewGrid().
 			SetRows(0, 0).
 			SetColumns(0, 0).
 			SetBorders(true),
 		Form: tview.NewForm().
-			SetButtonBackgroundColor(tcell.ColorPurple).
-			SetLabelColor(tcell.ColorPurple).
-			SetFieldTextColor(tcell.ColorGray).
-			SetFieldBackgroundColor(tcell.ColorWhite),
+			SetButtonBackgroundColor(theme.Accent).
+			SetButtonTextColor(theme.AccentText).
+			SetLabelColor(theme.Accent).
+			SetFieldTextColor(theme.FieldText).
+			SetFieldBackgroundColor(theme.FieldBackground),
 		ReadOnlyForm: tview.NewForm().
-			SetButtonBackgroundColor(tcell.ColorPurple).
-			SetLabelColor(tcell.ColorPurple).
-			SetFieldTextColor(tcell.ColorGray).
-			SetFieldBackgroundColor(tcell.ColorWhite),
+			SetButtonBackgroundColor(theme.Accent).
+			SetButtonTextColor(theme.AccentText).
+			SetLabelColor(theme.Accent).
+			SetFieldTextColor(theme.FieldText).
+			SetFieldBackgroundColor(theme.FieldBackground),
 		Table: tview.NewTable().
 			SetSelectable(true, false).
 			SetFixed(1, 1),
@@ -54,6 +58,7 @@ func NewProject(projectTypeUC *usecase.ProjectTypeUseCase, tagUC *usecase.TagUse
 		tagUC:         tagUC,
 		chronoWorkUC:  chronoWorkUC,
 		errorHandler:  errorHandler,
+		theme:         theme,
 	}
 }
 
@@ -79,13 +84,13 @@ func (p *Project) setStoreProjectForm(tui *service.TUI) {
 	p.Form.Clear(true)
 	p.ReadOnlyForm.Clear(true)
 
-	p.ReadOnlyForm.AddTextArea("Selected Tags", "", 50, 5, 0, nil)
+	p.ReadOnlyForm.AddTextArea(i18n.T("Selected Tags"), "", 50, 5, 0, nil)
 	tags := p.tagUC.GetAllNames()
-	tags = append([]string{notSelectText}, tags...)
-	p.Form.AddInputField("Project Name : ", "", 50, nil, nil).
-		AddDropDown("Tags : ", tags, 0, func(option string, optionIndex int) {
-			link := p.ReadOnlyForm.GetFormItemByLabel("Selected Tags").(*tview.TextArea)
-			if option == notSelectText {
+	tags = append([]string{i18n.T(notSelectText)}, tags...)
+	p.Form.AddInputField(i18n.T("Project Name : "), "", 50, nil, nil).
+		AddDropDown(i18n.T("Tags : "), tags, 0, func(option string, optionIndex int) {
+			link := p.ReadOnlyForm.GetFormItemByLabel(i18n.T("Selected Tags")).(*tview.TextArea)
+			if option == i18n.T(notSelectText) {
 				link.SetText("", false)
 				return
 			}
@@ -98,11 +103,11 @@ func (p *Project) setStoreProjectForm(tui *service.TUI) {
 			linkTagNames = strutil.RemoveDuplicates(linkTagNames)
 			link.SetText(strings.Join(linkTagNames, ","), false)
 		}).
-		AddButton("Save", func() {
+		AddButton(i18n.T("Save"), func() {
 			p.storeProject()
 			tui.SetFocus("projectTable")
 		}).
-		AddButton("Cancel", func() {
+		AddButton(i18n.T("Cancel"), func() {
 			tui.SetFocus("projectTable")
 		})
 }
@@ -111,13 +116,13 @@ func (p *Project) setUpdateProjectForm(tui *service.TUI, projectID uint, project
 	p.Form.Clear(true)
 	p.ReadOnlyForm.Clear(true)
 
-	p.ReadOnlyForm.AddTextArea("Selected Tags", "", 50, 5, 0, nil)
+	p.ReadOnlyForm.AddTextArea(i18n.T("Selected Tags"), "", 50, 5, 0, nil)
 	tags := p.tagUC.GetAllNames()
-	tags = append([]string{notSelectText}, tags...)
-	p.Form.AddInputField("Project Name : ", projectName, 50, nil, nil).
-		AddDropDown("Tags : ", tags, 0, func(option string, optionIndex int) {
-			link := p.ReadOnlyForm.GetFormItemByLabel("Selected Tags").(*tview.TextArea)
-			if option == notSelectText {
+	tags = append([]string{i18n.T(notSelectText)}, tags...)
+	p.Form.AddInputField(i18n.T("Project Name : "), projectName, 50, nil, nil).
+		AddDropDown(i18n.T("Tags : "), tags, 0, func(option string, optionIndex int) {
+			link := p.ReadOnlyForm.GetFormItemByLabel(i18n.T("Selected Tags")).(*tview.TextArea)
+			if option == i18n.T(notSelectText) {
 				link.SetText("", false)
 				return
 			}
@@ -130,20 +135,20 @@ func (p *Project) setUpdateProjectForm(tui *service.TUI, projectID uint, project
 			linkTagNames = strutil.RemoveDuplicates(linkTagNames)
 			link.SetText(strings.Join(linkTagNames, ","), false)
 		}).
-		AddButton("Update", func() {
+		AddButton(i18n.T("Update"), func() {
 			p.updateProject(projectID)
 			tui.SetFocus("projectTable")
 		}).
-		AddButton("Cancel", func() {
+		AddButton(i18n.T("Cancel"), func() {
 			tui.SetFocus("projectTable")
 		})
-	p.ReadOnlyForm.GetFormItemByLabel("Selected Tags").(*tview.TextArea).SetText(strings.Join(projectTagNames, ","), false)
+	p.ReadOnlyForm.GetFormItemByLabel(i18n.T("Selected Tags")).(*tview.TextArea).SetText(strings.Join(projectTagNames, ","), false)
 }
 
 func (p *Project) formCapture(tui *service.TUI) {
 	p.Form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyCtrlB:
+		switch tui.Keymap.Action(service.ContextForm, event) {
+		case "back":
 			tui.SetFocus("projectTable")
 		}
 		return event
@@ -151,8 +156,8 @@ func (p *Project) formCapture(tui *service.TUI) {
 }
 
 func (p *Project) storeProject() error {
-	projectName := p.Form.GetFormItemByLabel("Project Name : ").(*tview.InputField).GetText()
-	projectTags := p.ReadOnlyForm.GetFormItemByLabel("Selected Tags").(*tview.TextArea).GetText()
+	projectName := p.Form.GetFormItemByLabel(i18n.T("Project Name : ")).(*tview.InputField).GetText()
+	projectTags := p.ReadOnlyForm.GetFormItemByLabel(i18n.T("Selected Tags")).(*tview.TextArea).GetText()
 	if projectName == "" {
 		return nil
 	}
@@ -178,8 +183,8 @@ func (p *Project) storeProject() error {
 }
 
 func (p *Project) updateProject(projectID uint) {
-	projectName := p.Form.GetFormItemByLabel("Project Name : ").(*tview.InputField).GetText()
-	projectTags := p.ReadOnlyForm.GetFormItemByLabel("Selected Tags").(*tview.TextArea).GetText()
+	projectName := p.Form.GetFormItemByLabel(i18n.T("Project Name : ")).(*tview.InputField).GetText()
+	projectTags := p.ReadOnlyForm.GetFormItemByLabel(i18n.T("Selected Tags")).(*tview.TextArea).GetText()
 	if projectName == "" {
 		return
 	}
@@ -209,10 +214,10 @@ func (p *Project) setTable() {
 
 func (p *Project) setTableHeader() {
 	for i, header := range projectHeader {
-		tableCell := tview.NewTableCell(header).
+		tableCell := tview.NewTableCell(i18n.T(header)).
 			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite).
-			SetBackgroundColor(tcell.ColorPurple).
+			SetTextColor(p.theme.AccentText).
+			SetBackgroundColor(p.theme.Accent).
 			SetSelectable(false).
 			SetExpansion(1)
 		p.Table.SetCell(0, i, tableCell)
@@ -244,82 +249,71 @@ func (p *Project) setTableBody() {
 
 func (p *Project) tableCapture(tui *service.TUI) {
 	p.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyRune:
-			switch event.Rune() {
-			case 'a':
-				// store project
-				p.setStoreProjectForm(tui)
-				tui.SetFocus("projectForm")
-			case 'u':
-				// update project
-				row, _ := p.Table.GetSelection()
-				cell := p.Table.GetCell(row, 0)
-				if cell.Text == "" {
-					break
-				}
-				id := cell.Text
-				if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
-					unitId := uint(intId)
-					project, err := p.projectTypeUC.FindByID(unitId)
-					if err != nil {
-						p.errorHandler.ShowErrorWithErr(err, "projectTable")
-						break
-					}
-					p.setUpdateProjectForm(tui, project.ID, project.Name, project.GetTagNames())
-					tui.SetFocus("projectForm")
-				}
-			case 'd':
-				// delete project
-				row, _ := p.Table.GetSelection()
-				cell := p.Table.GetCell(row, 0)
-				if cell.Text == "" {
+		switch tui.Keymap.Action(service.ContextProject, event) {
+		case "add":
+			// store project
+			p.setStoreProjectForm(tui)
+			tui.SetFocus("projectForm")
+		case "update":
+			// update project
+			row, _ := p.Table.GetSelection()
+			cell := p.Table.GetCell(row, 0)
+			if cell.Text == "" {
+				break
+			}
+			id := cell.Text
+			if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
+				unitId := uint(intId)
+				project, err := p.projectTypeUC.FindByID(unitId)
+				if err != nil {
+					p.errorHandler.ShowErrorWithErr(err, "projectTable")
 					break
 				}
-				id := cell.Text
+				p.setUpdateProjectForm(tui, project.ID, project.Name, project.GetTagNames())
+				tui.SetFocus("projectForm")
+			}
+		case "delete":
+			// delete project
+			row, _ := p.Table.GetSelection()
+			cell := p.Table.GetCell(row, 0)
+			if cell.Text == "" {
+				break
+			}
+			id := cell.Text
 
-				var intId uint64
-				isExist := false
+			var intId uint64
+			isExist := false
 
-				intId, _ = strconv.ParseUint(id, 10, 0)
-				uintId := uint(intId)
-				project, _ := p.projectTypeUC.FindByID(uintId)
-				chronoWorks, err := p.chronoWorkUC.FindByProjectTypeID(project.ID)
-				if err != nil {
-					break
-				}
-				if len(chronoWorks) > 0 {
-					isExist = true
-				}
-				var modal *tview.Modal
-				if isExist {
-					modal = tview.NewModal().
-						SetText("Can't delete this project. Exist work that use this project.").
-						AddButtons([]string{"Close"}).
-						SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-							tui.DeleteModal()
-							tui.SetFocus("projectTable")
-							p.Table.ScrollToBeginning().Select(row, 0)
-						})
-				} else {
-					modal = tview.NewModal().
-						SetText("Are you sure you want to delete this project?").
-						AddButtons([]string{"Yes", "No"}).
-						SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-							if buttonLabel == "Yes" {
-								if err := p.projectTypeUC.Delete(project.ID); err != nil {
-									p.errorHandler.ShowErrorWithErr(err, "projectTable")
-								}
-								p.RestoreTable()
-							}
-							tui.DeleteModal()
-							tui.SetFocus("projectTable")
-							p.Table.ScrollToBeginning().Select(1, 0)
-						})
-				}
-				tui.SetModal(modal)
-				tui.SetFocus("modal")
+			intId, _ = strconv.ParseUint(id, 10, 0)
+			uintId := uint(intId)
+			project, _ := p.projectTypeUC.FindByID(uintId)
+			chronoWorks, err := p.chronoWorkUC.FindByProjectTypeID(project.ID)
+			if err != nil {
+				break
+			}
+			if len(chronoWorks) > 0 {
+				isExist = true
 			}
+			if isExist {
+				tui.Status.Warn("Can't delete this project. Exist work that use this project.")
+				break
+			}
+			modal := tview.NewModal().
+				SetText(i18n.T("Are you sure you want to delete this project?")).
+				AddButtons([]string{i18n.T("Yes"), i18n.T("No")}).
+				SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+					tui.DeleteModal()
+					tui.SetFocus("projectTable")
+					if buttonLabel == i18n.T("Yes") {
+						if err := p.projectTypeUC.Delete(project.ID); err != nil {
+							p.errorHandler.ShowErrorWithErr(err, "projectTable")
+						}
+						p.RestoreTable()
+					}
+					p.Table.ScrollToBeginning().Select(1, 0)
+				})
+			tui.SetModal(modal)
+			tui.SetFocus("modal")
 		}
 		return event
 	})
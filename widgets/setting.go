@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/gdamore/tcell/v2"
 	"github.com/niiharamegumu/chronowork/internal/domain"
 	"github.com/niiharamegumu/chronowork/internal/usecase"
 	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
 	"github.com/rivo/tview"
 )
 
@@ -15,14 +15,16 @@ type Setting struct {
 	Form         *tview.Form
 	settingUC    *usecase.SettingUseCase
 	errorHandler *service.ErrorHandler
+	themes       service.Themes
 }
 
-func NewSetting(settingUC *usecase.SettingUseCase, errorHandler *service.ErrorHandler) *Setting {
+func NewSetting(settingUC *usecase.SettingUseCase, errorHandler *service.ErrorHandler, themes service.Themes, theme *service.Theme) *Setting {
 	return &Setting{
 		Form: tview.NewForm().
-			SetLabelColor(tcell.ColorPurple),
+			SetLabelColor(theme.Accent),
 		settingUC:    settingUC,
 		errorHandler: errorHandler,
+		themes:       themes,
 	}
 }
 
@@ -32,16 +34,23 @@ func (s *Setting) GenerateInitSetting(tui *service.TUI) {
 		s.errorHandler.ShowErrorWithErr(err, "settingForm")
 		return
 	}
-	s.Form.AddInputField("Show Relative Date(0:Today Only) : ", fmt.Sprint(setting.RelativeDate), 20, nil, nil).
-		AddInputField("Person Day : ", fmt.Sprint(setting.PersonDay), 20, nil, nil).
-		AddCheckbox("Display As Person Day : ", setting.DisplayAsPersonDay, nil).
-		AddInputField("Download Path : ", setting.DownloadPath, 60, nil, nil).
-		AddButton("Save", func() {
-			s.update()
+	s.Form.AddInputField(i18n.T("Show Relative Date(0:Today Only) : "), fmt.Sprint(setting.RelativeDate), 20, nil, nil).
+		AddInputField(i18n.T("Person Day : "), fmt.Sprint(setting.PersonDay), 20, nil, nil).
+		AddCheckbox(i18n.T("Display As Person Day : "), setting.DisplayAsPersonDay, nil).
+		AddInputField(i18n.T("Download Path : "), setting.DownloadPath, 60, nil, nil).
+		AddCheckbox(i18n.T("Allow Creating Tags From Work Form : "), setting.AllowTagCreation, nil).
+		AddDropDown(i18n.T("Theme(Applied On Restart) : "), s.themes.Names(), s.themeIndex(setting.Theme), nil).
+		AddDropDown(i18n.T("Language(Applied On Restart) : "), i18n.Settings(), languageIndex(setting.Language), nil).
+		AddButton(i18n.T("Save"), func() {
+			if err := s.update(); err != nil {
+				s.errorHandler.ShowErrorWithErr(err, "settingForm")
+				return
+			}
+			tui.Status.Info("Settings saved.")
 			s.ReStore(tui)
 			tui.SetFocus("menu")
 		}).
-		AddButton("Cancel", func() {
+		AddButton(i18n.T("Cancel"), func() {
 			s.ReStore(tui)
 			tui.SetFocus("menu")
 		})
@@ -52,27 +61,27 @@ func (s *Setting) ReStore(tui *service.TUI) {
 	s.GenerateInitSetting(tui)
 }
 
-func (s *Setting) update() {
-	relativeDate := s.Form.GetFormItemByLabel("Show Relative Date(0:Today Only) : ").(*tview.InputField).GetText()
-	personDay := s.Form.GetFormItemByLabel("Person Day : ").(*tview.InputField).GetText()
-	displayAsPersonDay := s.Form.GetFormItemByLabel("Display As Person Day : ").(*tview.Checkbox).IsChecked()
-	downloadPath := s.Form.GetFormItemByLabel("Download Path : ").(*tview.InputField).GetText()
+func (s *Setting) update() error {
+	relativeDate := s.Form.GetFormItemByLabel(i18n.T("Show Relative Date(0:Today Only) : ")).(*tview.InputField).GetText()
+	personDay := s.Form.GetFormItemByLabel(i18n.T("Person Day : ")).(*tview.InputField).GetText()
+	displayAsPersonDay := s.Form.GetFormItemByLabel(i18n.T("Display As Person Day : ")).(*tview.Checkbox).IsChecked()
+	downloadPath := s.Form.GetFormItemByLabel(i18n.T("Download Path : ")).(*tview.InputField).GetText()
+	allowTagCreation := s.Form.GetFormItemByLabel(i18n.T("Allow Creating Tags From Work Form : ")).(*tview.Checkbox).IsChecked()
+	_, theme := s.Form.GetFormItemByLabel(i18n.T("Theme(Applied On Restart) : ")).(*tview.DropDown).GetCurrentOption()
+	_, language := s.Form.GetFormItemByLabel(i18n.T("Language(Applied On Restart) : ")).(*tview.DropDown).GetCurrentOption()
 
 	var dateInt, personDayInt int
 	var err error
 	if dateInt, err = strconv.Atoi(relativeDate); err != nil {
-		s.errorHandler.ShowErrorWithErr(err, "settingForm")
-		return
+		return err
 	}
 	if personDayInt, err = strconv.Atoi(personDay); err != nil {
-		s.errorHandler.ShowErrorWithErr(err, "settingForm")
-		return
+		return err
 	}
 
 	currentSetting, err := s.settingUC.Get()
 	if err != nil {
-		s.errorHandler.ShowErrorWithErr(err, "settingForm")
-		return
+		return err
 	}
 	updatedSetting := &domain.Setting{
 		ID:                 currentSetting.ID,
@@ -80,9 +89,27 @@ func (s *Setting) update() {
 		PersonDay:          uint(personDayInt),
 		DisplayAsPersonDay: displayAsPersonDay,
 		DownloadPath:       downloadPath,
+		Theme:              theme,
+		Language:           language,
+		AllowTagCreation:   allowTagCreation,
 	}
-	if err = s.settingUC.Update(updatedSetting); err != nil {
-		s.errorHandler.ShowErrorWithErr(err, "settingForm")
-		return
+	return s.settingUC.Update(updatedSetting)
+}
+
+func (s *Setting) themeIndex(name string) int {
+	for i, themeName := range s.themes.Names() {
+		if themeName == name {
+			return i
+		}
+	}
+	return 0
+}
+
+func languageIndex(language string) int {
+	for i, setting := range i18n.Settings() {
+		if setting == language {
+			return i
+		}
 	}
+	return 0
 }
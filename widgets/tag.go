@@ -7,6 +7,7 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/niiharamegumu/chronowork/internal/usecase"
 	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
 	"github.com/rivo/tview"
 )
 
@@ -23,24 +24,27 @@ type Tag struct {
 	Table        *tview.Table
 	tagUC        *usecase.TagUseCase
 	errorHandler *service.ErrorHandler
+	theme        *service.Theme
 }
 
-func NewTag(tagUC *usecase.TagUseCase, errorHandler *service.ErrorHandler) *Tag {
+func NewTag(tagUC *usecase.TagUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Tag {
 	return &Tag{
 		Layout: tview.NewGrid().
 			SetRows(10, 0).
 			SetColumns(0).
 			SetBorders(true),
 		Form: tview.NewForm().
-			SetButtonBackgroundColor(tcell.ColorPurple).
-			SetLabelColor(tcell.ColorPurple).
-			SetFieldTextColor(tcell.ColorGray).
-			SetFieldBackgroundColor(tcell.ColorWhite),
+			SetButtonBackgroundColor(theme.Accent).
+			SetButtonTextColor(theme.AccentText).
+			SetLabelColor(theme.Accent).
+			SetFieldTextColor(theme.FieldText).
+			SetFieldBackgroundColor(theme.FieldBackground),
 		Table: tview.NewTable().
 			SetSelectable(true, false).
 			SetFixed(1, 1),
 		tagUC:        tagUC,
 		errorHandler: errorHandler,
+		theme:        theme,
 	}
 }
 
@@ -58,30 +62,27 @@ func (t *Tag) GenerateInitTag(tui *service.TUI) *Tag {
 
 func (t *Tag) tableCapture(tui *service.TUI) {
 	t.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyRune:
-			switch event.Rune() {
-			case 'a':
-				t.setStoreTagForm(tui)
-				tui.SetFocus("tagForm")
-			case 'u':
-				row, _ := t.Table.GetSelection()
-				cell := t.Table.GetCell(row, 0)
-				if cell.Text == "" {
+		switch tui.Keymap.Action(service.ContextTag, event) {
+		case "add":
+			t.setStoreTagForm(tui)
+			tui.SetFocus("tagForm")
+		case "update":
+			row, _ := t.Table.GetSelection()
+			cell := t.Table.GetCell(row, 0)
+			if cell.Text == "" {
+				break
+			}
+			id := cell.Text
+			if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
+				unitId := uint(intId)
+				tag, err := t.tagUC.FindByID(unitId)
+				if err != nil {
 					break
 				}
-				id := cell.Text
-				if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
-					unitId := uint(intId)
-					tag, err := t.tagUC.FindByID(unitId)
-					if err != nil {
-						break
-					}
-					t.setUpdateTagForm(tui, tag.ID, tag.Name)
-					tui.SetFocus("tagForm")
-				}
-
+				t.setUpdateTagForm(tui, tag.ID, tag.Name)
+				tui.SetFocus("tagForm")
 			}
+
 		}
 		return event
 	})
@@ -89,8 +90,8 @@ func (t *Tag) tableCapture(tui *service.TUI) {
 
 func (t *Tag) formCapture(tui *service.TUI) {
 	t.Form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyCtrlB:
+		switch tui.Keymap.Action(service.ContextForm, event) {
+		case "back":
 			tui.SetFocus("tagTable")
 		}
 		return event
@@ -100,15 +101,15 @@ func (t *Tag) formCapture(tui *service.TUI) {
 func (t *Tag) setStoreTagForm(tui *service.TUI) {
 	t.Form.Clear(true)
 	t.Form.
-		AddInputField("Name", "", 50, nil, nil).
-		AddButton("Create", func() {
+		AddInputField(i18n.T("Name"), "", 50, nil, nil).
+		AddButton(i18n.T("Create"), func() {
 			err := t.storeTag()
 			if err != nil {
 				return
 			}
 			tui.SetFocus("tagTable")
 		}).
-		AddButton("Cancel", func() {
+		AddButton(i18n.T("Cancel"), func() {
 			tui.SetFocus("tagTable")
 		})
 }
@@ -116,21 +117,21 @@ func (t *Tag) setStoreTagForm(tui *service.TUI) {
 func (t *Tag) setUpdateTagForm(tui *service.TUI, tagID uint, tagName string) {
 	t.Form.Clear(true)
 	t.Form.
-		AddInputField("Name", tagName, 50, nil, nil).
-		AddButton("Update", func() {
+		AddInputField(i18n.T("Name"), tagName, 50, nil, nil).
+		AddButton(i18n.T("Update"), func() {
 			err := t.updateTag(tagID)
 			if err != nil {
 				return
 			}
 			tui.SetFocus("tagTable")
 		}).
-		AddButton("Cancel", func() {
+		AddButton(i18n.T("Cancel"), func() {
 			tui.SetFocus("tagTable")
 		})
 }
 
 func (t *Tag) storeTag() error {
-	tagName := t.Form.GetFormItemByLabel("Name").(*tview.InputField).GetText()
+	tagName := t.Form.GetFormItemByLabel(i18n.T("Name")).(*tview.InputField).GetText()
 	if tagName == "" {
 		return fmt.Errorf("tag name is empty")
 	}
@@ -143,7 +144,7 @@ func (t *Tag) storeTag() error {
 }
 
 func (t *Tag) updateTag(tagID uint) error {
-	tagName := t.Form.GetFormItemByLabel("Name").(*tview.InputField).GetText()
+	tagName := t.Form.GetFormItemByLabel(i18n.T("Name")).(*tview.InputField).GetText()
 	if tagName == "" {
 		return fmt.Errorf("tag name is empty")
 	}
@@ -162,10 +163,10 @@ func (t *Tag) setTable() {
 func (t *Tag) setTableHeader() {
 	for i, header := range tagHeader {
 		t.Table.SetCell(0, i,
-			tview.NewTableCell(header).
+			tview.NewTableCell(i18n.T(header)).
 				SetAlign(tview.AlignLeft).
-				SetTextColor(tcell.ColorWhite).
-				SetBackgroundColor(tcell.ColorPurple).
+				SetTextColor(t.theme.AccentText).
+				SetBackgroundColor(t.theme.Accent).
 				SetSelectable(false))
 	}
 }
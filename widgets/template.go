@@ -0,0 +1,328 @@
+package widgets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/niiharamegumu/chronowork/internal/domain"
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+	"github.com/rivo/tview"
+)
+
+var (
+	templateHeader = []string{
+		"ID",
+		"Title",
+		"Project",
+		"Tag",
+		"Estimate",
+		"Recurrence",
+		"Last Created",
+	}
+	recurrenceLabel = "Recurrence(daily/weekdays/mon,thu/monthly:1) : "
+)
+
+type Template struct {
+	Layout         *tview.Grid
+	Form           *tview.Form
+	Table          *tview.Table
+	workTemplateUC *usecase.WorkTemplateUseCase
+	projectTypeUC  *usecase.ProjectTypeUseCase
+	errorHandler   *service.ErrorHandler
+	theme          *service.Theme
+	projects       []domain.ProjectType
+}
+
+func NewTemplate(workTemplateUC *usecase.WorkTemplateUseCase, projectTypeUC *usecase.ProjectTypeUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Template {
+	return &Template{
+		Layout: tview.NewGrid().
+			SetRows(14, 0).
+			SetColumns(0).
+			SetBorders(true),
+		Form: tview.NewForm().
+			SetButtonBackgroundColor(theme.Accent).
+			SetButtonTextColor(theme.AccentText).
+			SetLabelColor(theme.Accent).
+			SetFieldTextColor(theme.FieldText).
+			SetFieldBackgroundColor(theme.FieldBackground),
+		Table: tview.NewTable().
+			SetSelectable(true, false).
+			SetFixed(1, 1),
+		workTemplateUC: workTemplateUC,
+		projectTypeUC:  projectTypeUC,
+		errorHandler:   errorHandler,
+		theme:          theme,
+	}
+}
+
+func (t *Template) GenerateInitTemplate(tui *service.TUI, work *Work) *Template {
+	t.setTemplateForm(tui, &domain.WorkTemplate{Recurrence: "weekdays"})
+	t.RestoreTable()
+
+	t.Layout.AddItem(t.Form, 0, 0, 1, 1, 0, 0, false)
+	t.Layout.AddItem(t.Table, 1, 0, 1, 1, 0, 0, true)
+
+	t.tableCapture(tui, work)
+	t.formCapture(tui)
+	return t
+}
+
+func (t *Template) RestoreTable() {
+	t.Table.Clear()
+	t.setTableHeader()
+	t.setTableBody()
+}
+
+// Materialize creates today's works from the templates and refreshes the work table.
+func (t *Template) Materialize(tui *service.TUI, work *Work) {
+	count, err := t.workTemplateUC.Materialize(time.Now())
+	t.RestoreTable()
+	if refreshErr := work.Refresh(); refreshErr != nil && err == nil {
+		err = refreshErr
+	}
+	if err != nil {
+		t.errorHandler.ShowErrorWithErr(err, "templateTable")
+		return
+	}
+	tui.Status.Info("Created %d works from templates.", count)
+}
+
+// setTemplateForm fills the form with the template. A template without ID is created on save.
+func (t *Template) setTemplateForm(tui *service.TUI, template *domain.WorkTemplate) {
+	t.Form.Clear(true)
+	projects, err := t.projectTypeUC.FindAllWithTags()
+	if err != nil {
+		t.errorHandler.ShowErrorWithErr(err, "templateTable")
+	}
+	t.projects = projects
+
+	var projectName, tagName string
+	if template.ProjectType != nil {
+		projectName = template.ProjectType.Name
+	}
+	if template.Tag != nil {
+		tagName = template.Tag.Name
+	}
+	estimate := ""
+	if template.EstimateSeconds > 0 {
+		estimate = fmt.Sprint(template.EstimateSeconds / 60)
+	}
+
+	var projectPicker, tagPicker *tview.InputField
+	projectPicker = newPicker(i18n.T("Project"), projectName, 50, func(text string) []string {
+		names := make([]string, len(t.projects))
+		for i, project := range t.projects {
+			names[i] = project.Name
+		}
+		return fuzzyEntries(strings.TrimSpace(text), names)
+	}, func(entry string) {
+		projectPicker.SetText(entry)
+	})
+	tagPicker = newPicker(i18n.T("Tags"), tagName, 50, func(text string) []string {
+		project := t.findProject(projectPicker.GetText())
+		if project == nil {
+			return nil
+		}
+		return fuzzyEntries(strings.TrimSpace(text), project.GetTagNames())
+	}, func(entry string) {
+		tagPicker.SetText(entry)
+	})
+	projectPicker.SetChangedFunc(func(text string) {
+		project := t.findProject(text)
+		if project == nil || !project.HasTag(tagPicker.GetText()) {
+			tagPicker.SetText("")
+		}
+	})
+
+	buttonLabel := "Create"
+	if template.ID != 0 {
+		buttonLabel = "Update"
+	}
+	t.Form.AddInputField(i18n.T("Title"), template.Title, 50, nil, nil).
+		AddFormItem(projectPicker).
+		AddFormItem(tagPicker).
+		AddInputField(i18n.T("Estimate(Minutes)"), estimate, 20, tview.InputFieldInteger, nil).
+		AddInputField(i18n.T(recurrenceLabel), template.Recurrence, 30, nil, nil).
+		AddButton(i18n.T(buttonLabel), func() {
+			if err := t.save(template.ID); err != nil {
+				t.errorHandler.ShowErrorWithErr(err, "templateForm")
+				return
+			}
+			t.RestoreTable()
+			tui.SetFocus("templateTable")
+		}).
+		AddButton(i18n.T("Cancel"), func() {
+			tui.SetFocus("templateTable")
+		})
+}
+
+func (t *Template) save(id uint) error {
+	title := t.Form.GetFormItemByLabel(i18n.T("Title")).(*tview.InputField).GetText()
+	projectName := strings.TrimSpace(t.Form.GetFormItemByLabel(i18n.T("Project")).(*tview.InputField).GetText())
+	tagName := strings.TrimSpace(t.Form.GetFormItemByLabel(i18n.T("Tags")).(*tview.InputField).GetText())
+	estimate := t.Form.GetFormItemByLabel(i18n.T("Estimate(Minutes)")).(*tview.InputField).GetText()
+	recurrence := t.Form.GetFormItemByLabel(i18n.T(recurrenceLabel)).(*tview.InputField).GetText()
+
+	template := &domain.WorkTemplate{
+		ID:         id,
+		Title:      title,
+		Recurrence: recurrence,
+	}
+	if estimate != "" {
+		minutes, err := strconv.Atoi(estimate)
+		if err != nil {
+			return usecase.NewValidationError("invalid estimate")
+		}
+		template.EstimateSeconds = minutes * 60
+	}
+	if projectName != "" {
+		project := t.findProject(projectName)
+		if project == nil {
+			return usecase.NewValidationErrorf("unknown project: %s", projectName)
+		}
+		template.ProjectTypeID = project.ID
+		for _, tag := range project.Tags {
+			if tag.Name == tagName {
+				template.TagID = tag.ID
+			}
+		}
+		if tagName != "" && template.TagID == 0 {
+			return usecase.NewValidationErrorf("tag %s is not allowed on project %s", tagName, projectName)
+		}
+	} else if tagName != "" {
+		return usecase.NewValidationError("tag requires a project")
+	}
+
+	if id == 0 {
+		return t.workTemplateUC.Create(template)
+	}
+	return t.workTemplateUC.Update(template)
+}
+
+// findProject returns the loaded project named name, or nil if there is none.
+func (t *Template) findProject(name string) *domain.ProjectType {
+	name = strings.TrimSpace(name)
+	for i := range t.projects {
+		if t.projects[i].Name == name {
+			return &t.projects[i]
+		}
+	}
+	return nil
+}
+
+func (t *Template) formCapture(tui *service.TUI) {
+	t.Form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch tui.Keymap.Action(service.ContextForm, event) {
+		case "back":
+			tui.SetFocus("templateTable")
+		}
+		return event
+	})
+}
+
+func (t *Template) setTableHeader() {
+	for i, header := range templateHeader {
+		tableCell := tview.NewTableCell(i18n.T(header)).
+			SetAlign(tview.AlignLeft).
+			SetTextColor(t.theme.AccentText).
+			SetBackgroundColor(t.theme.Accent).
+			SetSelectable(false)
+		if header == "Title" {
+			tableCell.SetExpansion(1)
+		}
+		t.Table.SetCell(0, i, tableCell)
+	}
+}
+
+func (t *Template) setTableBody() {
+	templates, err := t.workTemplateUC.FindAll()
+	if err != nil {
+		t.errorHandler.ShowErrorWithErr(err, "templateTable")
+		return
+	}
+	for i, template := range templates {
+		var projectName, tagName, estimate, lastCreated string
+		if template.ProjectType != nil {
+			projectName = template.ProjectType.Name
+		}
+		if template.Tag != nil {
+			tagName = template.Tag.Name
+		}
+		if template.EstimateSeconds > 0 {
+			estimate = timeutil.SecondsToHourAndMinute(template.EstimateSeconds)
+		}
+		if !template.LastCreatedOn.IsZero() {
+			lastCreated = i18n.FormatDate(template.LastCreatedOn)
+		}
+		cells := []string{fmt.Sprint(template.ID), template.Title, projectName, tagName, estimate, template.Recurrence, lastCreated}
+		for j, text := range cells {
+			tableCell := tview.NewTableCell(text).SetAlign(tview.AlignLeft)
+			if j == 1 {
+				tableCell.SetExpansion(1)
+			}
+			t.Table.SetCell(i+1, j, tableCell)
+		}
+	}
+}
+
+// selectedID returns the ID of the template in the selected row, or 0 if there is none.
+func (t *Template) selectedID() uint {
+	row, _ := t.Table.GetSelection()
+	id, err := strconv.ParseUint(t.Table.GetCell(row, 0).Text, 10, 0)
+	if err != nil {
+		return 0
+	}
+	return uint(id)
+}
+
+func (t *Template) tableCapture(tui *service.TUI, work *Work) {
+	t.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch tui.Keymap.Action(service.ContextTemplate, event) {
+		case "add":
+			t.setTemplateForm(tui, &domain.WorkTemplate{Recurrence: "weekdays"})
+			tui.SetFocus("templateForm")
+		case "update":
+			id := t.selectedID()
+			if id == 0 {
+				break
+			}
+			template, err := t.workTemplateUC.FindByID(id)
+			if err != nil {
+				t.errorHandler.ShowErrorWithErr(err, "templateTable")
+				break
+			}
+			t.setTemplateForm(tui, template)
+			tui.SetFocus("templateForm")
+		case "delete":
+			id := t.selectedID()
+			if id == 0 {
+				break
+			}
+			modal := tview.NewModal().
+				SetText(i18n.T("Are you sure you want to delete this template?")).
+				AddButtons([]string{i18n.T("Yes"), i18n.T("No")}).
+				SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+					tui.DeleteModal()
+					tui.SetFocus("templateTable")
+					if buttonLabel == i18n.T("Yes") {
+						if err := t.workTemplateUC.Delete(id); err != nil {
+							t.errorHandler.ShowErrorWithErr(err, "templateTable")
+						}
+						t.RestoreTable()
+					}
+					t.Table.ScrollToBeginning().Select(1, 0)
+				})
+			tui.SetModal(modal)
+			tui.SetFocus("modal")
+		case "materialize":
+			t.Materialize(tui, work)
+		}
+		return event
+	})
+}
@@ -0,0 +1,177 @@
+package widgets
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+	"github.com/rivo/tview"
+)
+
+const (
+	// timelineLabelWidth is the width of the work titles left of the bars.
+	timelineLabelWidth = 24
+	// timelineWorkStart and timelineWorkEnd are the hours always included in
+	// the working hours axis.
+	timelineWorkStart = 9
+	timelineWorkEnd   = 18
+)
+
+// timelineColors are the colors of the bars, chosen by project.
+var timelineColors = []tcell.Color{
+	tcell.ColorTeal,
+	tcell.ColorOlive,
+	tcell.ColorFuchsia,
+	tcell.ColorDodgerBlue,
+	tcell.ColorOrange,
+	tcell.ColorLime,
+	tcell.ColorSalmon,
+	tcell.ColorAqua,
+}
+
+// Timeline draws the tracking intervals of a day as bars on an hour axis.
+type Timeline struct {
+	View         *tview.Box
+	chronoWorkUC *usecase.ChronoWorkUseCase
+	errorHandler *service.ErrorHandler
+	theme        *service.Theme
+	date         time.Time
+	rows         []usecase.TimelineRow
+	// fullDay shows the 24 hours of the day instead of the working hours.
+	fullDay bool
+}
+
+func NewTimeline(chronoWorkUC *usecase.ChronoWorkUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Timeline {
+	return &Timeline{
+		View:         tview.NewBox(),
+		chronoWorkUC: chronoWorkUC,
+		errorHandler: errorHandler,
+		theme:        theme,
+		date:         timeutil.StartOfDay(time.Now()),
+	}
+}
+
+func (t *Timeline) GenerateInitTimeline(tui *service.TUI) *Timeline {
+	t.View.SetDrawFunc(t.draw)
+	t.capture(tui)
+	return t
+}
+
+// Restore reloads the tracking intervals of the displayed day.
+func (t *Timeline) Restore() {
+	rows, err := t.chronoWorkUC.Timeline(t.date, time.Now())
+	if err != nil {
+		t.errorHandler.ShowErrorWithErr(err, "timelineView")
+		return
+	}
+	t.rows = rows
+}
+
+// ShowDate displays the tracking intervals of the day of date.
+func (t *Timeline) ShowDate(date time.Time) {
+	t.date = timeutil.StartOfDay(date)
+	t.Restore()
+}
+
+// axis returns the first and last hours of the axis. The working hours axis
+// is widened to the intervals outside of the working hours.
+func (t *Timeline) axis() (int, int) {
+	if t.fullDay {
+		return 0, 24
+	}
+	start, end := timelineWorkStart, timelineWorkEnd
+	for _, row := range t.rows {
+		for _, interval := range row.Intervals {
+			start = min(start, interval.StartTime.Hour())
+			endHour := interval.EndTime.Hour()
+			if interval.EndTime.Minute() > 0 || interval.EndTime.Second() > 0 {
+				endHour++
+			}
+			end = max(end, endHour)
+		}
+	}
+	return start, end
+}
+
+func (t *Timeline) draw(screen tcell.Screen, x, y, width, height int) (int, int, int, int) {
+	barWidth := width - timelineLabelWidth
+	if barWidth <= 0 || height <= 0 {
+		return x, y, width, height
+	}
+	startHour, endHour := t.axis()
+	axisStart := t.date.Add(time.Duration(startHour) * time.Hour)
+	slot := time.Duration(endHour-startHour) * time.Hour / time.Duration(barWidth)
+
+	totalSeconds := 0
+	for _, row := range t.rows {
+		for _, interval := range row.Intervals {
+			totalSeconds += int(interval.EndTime.Sub(interval.StartTime).Seconds())
+		}
+	}
+	title := i18n.T("%s  Total %s", fmt.Sprintf("%s (%s)", i18n.FormatDate(t.date), i18n.ShortWeekday(t.date.Weekday())), timeutil.SecondsToHourAndMinute(totalSeconds))
+	tview.Print(screen, title, x, y, width, tview.AlignLeft, t.theme.Accent)
+
+	// hour labels, spaced to fit the width
+	step := 1
+	for step < endHour-startHour && barWidth/((endHour-startHour)/step) < 3 {
+		step *= 2
+	}
+	for hour := startHour; hour < endHour; hour += step {
+		column := int(time.Duration(hour-startHour) * time.Hour / slot)
+		tview.Print(screen, fmt.Sprintf("%d", hour), x+timelineLabelWidth+column, y+1, 3, tview.AlignLeft, t.theme.Text)
+	}
+
+	line := y + 2
+	for _, row := range t.rows {
+		if line >= y+height-1 {
+			break
+		}
+		label := row.Work.Title
+		if row.Work.ProjectType != nil {
+			label = "@" + row.Work.ProjectType.Name + " " + label
+		}
+		tview.Print(screen, label, x, line, timelineLabelWidth-1, tview.AlignLeft, t.theme.Text)
+
+		color := t.theme.FieldText
+		if row.Work.ProjectTypeID != 0 {
+			color = timelineColors[int(row.Work.ProjectTypeID)%len(timelineColors)]
+		}
+		for column := 0; column < barWidth; column++ {
+			from := axisStart.Add(time.Duration(column) * slot)
+			to := from.Add(slot)
+			char, style := '·', tcell.StyleDefault.Foreground(t.theme.Border).Background(t.theme.Background)
+			for _, interval := range row.Intervals {
+				if interval.StartTime.Before(to) && interval.EndTime.After(from) {
+					char, style = '█', tcell.StyleDefault.Foreground(color).Background(t.theme.Background)
+					break
+				}
+			}
+			screen.SetContent(x+timelineLabelWidth+column, line, char, nil, style)
+		}
+		line++
+	}
+	if len(t.rows) == 0 {
+		tview.Print(screen, i18n.T("No tracked time on this day."), x, line, width, tview.AlignLeft, t.theme.FieldText)
+	}
+	return x, y, width, height
+}
+
+func (t *Timeline) capture(tui *service.TUI) {
+	t.View.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch tui.Keymap.Action(service.ContextTimeline, event) {
+		case "prev_day":
+			t.ShowDate(t.date.AddDate(0, 0, -1))
+		case "next_day":
+			t.ShowDate(t.date.AddDate(0, 0, 1))
+		case "today":
+			t.ShowDate(time.Now())
+		case "toggle_axis":
+			t.fullDay = !t.fullDay
+		}
+		return event
+	})
+}
@@ -4,10 +4,10 @@ import (
 	"context"
 	"time"
 
-	"github.com/gdamore/tcell/v2"
 	"github.com/niiharamegumu/chronowork/internal/domain"
 	"github.com/niiharamegumu/chronowork/internal/usecase"
 	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
 	"github.com/niiharamegumu/chronowork/util/timeutil"
 	"github.com/rivo/tview"
 )
@@ -25,23 +25,23 @@ type Timer struct {
 	chronoWorkUC *usecase.ChronoWorkUseCase
 }
 
-func NewTimer(chronoWorkUC *usecase.ChronoWorkUseCase) *Timer {
+func NewTimer(chronoWorkUC *usecase.ChronoWorkUseCase, theme *service.Theme) *Timer {
 	time := tview.NewTextView().
-		SetLabel("Timer : ").
-		SetTextColor(tcell.ColorPurple).
+		SetLabel(i18n.T("Timer : ")).
+		SetTextColor(theme.Accent).
 		SetText("00:00:00")
 	title := tview.NewTextView().
-		SetTextColor(tcell.ColorPurple).
-		SetLabel("TItle : ")
+		SetTextColor(theme.Accent).
+		SetLabel(i18n.T("Title : "))
 	CreatedDate := tview.NewTextView().
-		SetTextColor(tcell.ColorPurple).
-		SetLabel("Created Date : ")
+		SetTextColor(theme.Accent).
+		SetLabel(i18n.T("Created Date : "))
 	projectName := tview.NewTextView().
-		SetTextColor(tcell.ColorPurple).
-		SetLabel("Project Name : ")
+		SetTextColor(theme.Accent).
+		SetLabel(i18n.T("Project Name : "))
 	tagName := tview.NewTextView().
-		SetTextColor(tcell.ColorPurple).
-		SetLabel("Tag Name : ")
+		SetTextColor(theme.Accent).
+		SetLabel(i18n.T("Tag Name : "))
 	timer := &Timer{
 		Wrapper: tview.NewGrid().
 			SetRows(0, 1, 1, 1, 0).
@@ -76,7 +76,7 @@ func (t *Timer) CheckActiveTracking(tui *service.TUI) error {
 
 func (t *Timer) SetTimerText(c domain.ChronoWork) {
 	t.Title.SetText(c.Title)
-	t.CreatedDate.SetText(c.CreatedAt.Format("2006-01-02 "))
+	t.CreatedDate.SetText(i18n.FormatDate(c.CreatedAt))
 	if c.ProjectType != nil {
 		t.ProjectName.SetText(c.ProjectType.Name)
 	}
@@ -108,7 +108,10 @@ func (t *Timer) SetCalculateSeconds(tui *service.TUI) {
 }
 
 func (t *Timer) StopCalculateSeconds() {
-	t.cancelFunc()
+	// the timer has never been started when nothing was tracked at launch
+	if t.cancelFunc != nil {
+		t.cancelFunc()
+	}
 }
 
 func (t *Timer) ResetSetText() {
@@ -0,0 +1,217 @@
+package widgets
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/niiharamegumu/chronowork/internal/usecase"
+	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
+	"github.com/niiharamegumu/chronowork/util/timeutil"
+	"github.com/rivo/tview"
+)
+
+const (
+	// timesheetDayColumn is the column of Monday in the timesheet table.
+	timesheetDayColumn = 3
+	timesheetTimeLabel = "Time(H:MM, 1h30m or minutes)"
+)
+
+// Timesheet shows the tracked time of a week with a row per title, project
+// and tag and a column per day, and edits the time of a day.
+type Timesheet struct {
+	Layout       *tview.Grid
+	Form         *tview.Form
+	Table        *tview.Table
+	chronoWorkUC *usecase.ChronoWorkUseCase
+	errorHandler *service.ErrorHandler
+	theme        *service.Theme
+	sheet        usecase.Timesheet
+}
+
+func NewTimesheet(chronoWorkUC *usecase.ChronoWorkUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Timesheet {
+	return &Timesheet{
+		Layout: tview.NewGrid().
+			SetRows(5, 0).
+			SetColumns(0).
+			SetBorders(true),
+		Form: tview.NewForm().
+			SetButtonBackgroundColor(theme.Accent).
+			SetButtonTextColor(theme.AccentText).
+			SetLabelColor(theme.Accent).
+			SetFieldTextColor(theme.FieldText).
+			SetFieldBackgroundColor(theme.FieldBackground),
+		Table: tview.NewTable().
+			SetSelectable(true, true).
+			SetFixed(1, 1),
+		chronoWorkUC: chronoWorkUC,
+		errorHandler: errorHandler,
+		theme:        theme,
+		sheet:        usecase.Timesheet{Start: timeutil.StartOfWeek(time.Now())},
+	}
+}
+
+func (t *Timesheet) GenerateInitTimesheet(tui *service.TUI) *Timesheet {
+	t.Layout.AddItem(t.Form, 0, 0, 1, 1, 0, 0, false)
+	t.Layout.AddItem(t.Table, 1, 0, 1, 1, 0, 0, true)
+
+	t.tableCapture(tui)
+	t.formCapture(tui)
+	return t
+}
+
+// ShowWeek displays the timesheet of the week of date.
+func (t *Timesheet) ShowWeek(date time.Time) {
+	t.sheet.Start = timeutil.StartOfWeek(date)
+	t.Restore()
+}
+
+// Restore reloads the displayed week, keeping the selected cell.
+func (t *Timesheet) Restore() {
+	sheet, err := t.chronoWorkUC.Timesheet(t.sheet.Start)
+	if err != nil {
+		t.errorHandler.ShowErrorWithErr(err, "timesheetTable")
+		return
+	}
+	t.sheet = sheet
+	t.Form.Clear(true)
+
+	row, column := t.Table.GetSelection()
+	t.Table.Clear()
+	t.setHeader()
+	t.setBody()
+	if row < 1 || row > len(sheet.Rows) {
+		row = 1
+	}
+	if column < timesheetDayColumn || column > timesheetDayColumn+6 {
+		column = timesheetDayColumn
+	}
+	t.Table.Select(row, column)
+}
+
+func (t *Timesheet) setHeader() {
+	headers := []string{i18n.T("Title"), i18n.T("Project"), i18n.T("Tag")}
+	for day := 0; day < 7; day++ {
+		date := t.sheet.Date(day)
+		headers = append(headers, i18n.ShortWeekday(date.Weekday())+" "+date.Format("1/2"))
+	}
+	headers = append(headers, i18n.T("Total"))
+
+	for i, header := range headers {
+		tableCell := tview.NewTableCell(header).
+			SetAlign(tview.AlignLeft).
+			SetTextColor(t.theme.AccentText).
+			SetBackgroundColor(t.theme.Accent).
+			SetSelectable(false)
+		if i == 0 {
+			tableCell.SetExpansion(1)
+		}
+		t.Table.SetCell(0, i, tableCell)
+	}
+}
+
+func (t *Timesheet) setBody() {
+	for i, row := range t.sheet.Rows {
+		t.Table.SetCell(i+1, 0, tview.NewTableCell(tview.Escape(row.Title)).SetExpansion(1).SetSelectable(false))
+		t.Table.SetCell(i+1, 1, tview.NewTableCell(tview.Escape(row.ProjectName)).SetSelectable(false))
+		t.Table.SetCell(i+1, 2, tview.NewTableCell(tview.Escape(row.TagName)).SetSelectable(false))
+		for day, seconds := range row.Seconds {
+			t.Table.SetCell(i+1, timesheetDayColumn+day, tview.NewTableCell(timesheetTime(seconds)).SetAlign(tview.AlignRight))
+		}
+		t.Table.SetCell(i+1, timesheetDayColumn+7, tview.NewTableCell(timesheetTime(row.Total())).
+			SetAlign(tview.AlignRight).
+			SetSelectable(false))
+	}
+
+	totalRow := len(t.sheet.Rows) + 1
+	t.Table.SetCell(totalRow, 0, tview.NewTableCell(i18n.T("Total")).
+		SetTextColor(t.theme.AccentText).
+		SetBackgroundColor(t.theme.Summary).
+		SetSelectable(false))
+	for column := 1; column <= timesheetDayColumn+7; column++ {
+		text := ""
+		switch {
+		case column == timesheetDayColumn+7:
+			text = timesheetTime(t.sheet.Total())
+		case column >= timesheetDayColumn:
+			text = timesheetTime(t.sheet.DayTotal(column - timesheetDayColumn))
+		}
+		t.Table.SetCell(totalRow, column, tview.NewTableCell(text).
+			SetAlign(tview.AlignRight).
+			SetTextColor(t.theme.AccentText).
+			SetBackgroundColor(t.theme.Summary).
+			SetSelectable(false))
+	}
+}
+
+// timesheetTime formats the seconds of a cell, leaving the days without time empty.
+func timesheetTime(seconds int) string {
+	if seconds == 0 {
+		return ""
+	}
+	return timeutil.SecondsToHourAndMinute(seconds)
+}
+
+// setEditForm fills the form to change the time of a row on a day.
+func (t *Timesheet) setEditForm(tui *service.TUI, row usecase.TimesheetRow, day int) {
+	t.Form.Clear(true)
+	date := t.sheet.Date(day)
+	current := ""
+	if row.Seconds[day] > 0 {
+		current = timeutil.SecondsToHourAndMinute(row.Seconds[day])
+	}
+	t.Form.AddTextView(i18n.T("Work"), tview.Escape(row.Title)+"  "+i18n.FormatDate(date)+" ("+i18n.ShortWeekday(date.Weekday())+")", 0, 1, true, false).
+		AddInputField(i18n.T(timesheetTimeLabel), current, 20, nil, nil).
+		AddButton(i18n.T("Save"), func() {
+			text := t.Form.GetFormItemByLabel(i18n.T(timesheetTimeLabel)).(*tview.InputField).GetText()
+			seconds, err := usecase.ParseTimesheetTime(text)
+			if err == nil {
+				err = t.chronoWorkUC.SetTimesheetTime(t.sheet, row, day, seconds)
+			}
+			if err != nil {
+				t.errorHandler.ShowErrorWithErr(err, "timesheetForm")
+				return
+			}
+			t.Restore()
+			tui.SetFocus("timesheetTable")
+		}).
+		AddButton(i18n.T("Cancel"), func() {
+			t.Form.Clear(true)
+			tui.SetFocus("timesheetTable")
+		})
+	t.Form.SetFocus(1)
+}
+
+func (t *Timesheet) formCapture(tui *service.TUI) {
+	t.Form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch tui.Keymap.Action(service.ContextForm, event) {
+		case "back":
+			t.Form.Clear(true)
+			tui.SetFocus("timesheetTable")
+		}
+		return event
+	})
+}
+
+func (t *Timesheet) tableCapture(tui *service.TUI) {
+	t.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch tui.Keymap.Action(service.ContextTimesheet, event) {
+		case "edit":
+			row, column := t.Table.GetSelection()
+			day := column - timesheetDayColumn
+			if row < 1 || row > len(t.sheet.Rows) || day < 0 || day > 6 {
+				return nil
+			}
+			t.setEditForm(tui, t.sheet.Rows[row-1], day)
+			tui.SetFocus("timesheetForm")
+			return nil
+		case "prev_week":
+			t.ShowWeek(t.sheet.Start.AddDate(0, 0, -7))
+		case "next_week":
+			t.ShowWeek(t.sheet.Start.AddDate(0, 0, 7))
+		case "this_week":
+			t.ShowWeek(time.Now())
+		}
+		return event
+	})
+}
@@ -2,14 +2,17 @@ package widgets
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/niiharamegumu/chronowork/internal/domain"
 	"github.com/niiharamegumu/chronowork/internal/usecase"
 	"github.com/niiharamegumu/chronowork/service"
+	"github.com/niiharamegumu/chronowork/util/i18n"
 	"github.com/niiharamegumu/chronowork/util/timeutil"
 	"github.com/rivo/tview"
 	"golang.design/x/clipboard"
@@ -28,178 +31,359 @@ var (
 )
 
 type Work struct {
+	Title        *tview.TextView
 	Table        *tview.Table
 	chronoWorkUC *usecase.ChronoWorkUseCase
 	settingUC    *usecase.SettingUseCase
 	errorHandler *service.ErrorHandler
+	filterQuery  string
+	filter       usecase.WorkFilter
+	startTime    time.Time
+	endTime      time.Time
+	marked       map[uint]bool
+	visualAnchor int
+	visualBase   map[uint]bool
+	theme        *service.Theme
 }
 
-func NewWork(chronoWorkUC *usecase.ChronoWorkUseCase, settingUC *usecase.SettingUseCase, errorHandler *service.ErrorHandler) *Work {
+func NewWork(chronoWorkUC *usecase.ChronoWorkUseCase, settingUC *usecase.SettingUseCase, errorHandler *service.ErrorHandler, theme *service.Theme) *Work {
 	work := &Work{
+		Title: tview.NewTextView().
+			SetTextAlign(tview.AlignCenter).
+			SetTextColor(theme.Accent),
 		Table: tview.NewTable().
 			SetSelectable(true, false).
 			SetFixed(1, 1),
 		chronoWorkUC: chronoWorkUC,
 		settingUC:    settingUC,
 		errorHandler: errorHandler,
+		marked:       map[uint]bool{},
+		visualAnchor: -1,
+		theme:        theme,
 	}
 	return work
 }
 
 func (w *Work) GenerateInitWork(tui *service.TUI, relativeDays int) (*Work, error) {
-	w.setHeader()
-	if err := w.setBody(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime()); err != nil {
+	if err := w.ReStoreTable(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime()); err != nil {
 		return nil, err
 	}
 	return w, nil
 }
 
-func (w *Work) TableCapture(tui *service.TUI, form *Form, timer *Timer, relativeDays int) {
+// Refresh redraws the table for the currently displayed range.
+func (w *Work) Refresh() error {
+	return w.ReStoreTable(w.startTime, w.endTime)
+}
+
+// ShowToday displays the default range of the setting, ending today.
+func (w *Work) ShowToday() error {
+	relativeDays := 0
+	if setting, err := w.settingUC.Get(); err == nil {
+		relativeDays = int(setting.RelativeDate)
+	}
+	return w.ReStoreTable(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime())
+}
+
+// GoToDate displays the range ending on the given date, keeping the current number of days.
+// Dates after today are clamped to today.
+func (w *Work) GoToDate(date time.Time) error {
+	if date.After(timeutil.TodayEndTime()) {
+		date = time.Now()
+	}
+	span := w.spanDays()
+	return w.ReStoreTable(timeutil.StartOfDay(date).AddDate(0, 0, -span), timeutil.EndOfDay(date))
+}
+
+// shiftRange moves the displayed range by the given number of days.
+func (w *Work) shiftRange(days int) error {
+	return w.GoToDate(w.endTime.AddDate(0, 0, days))
+}
+
+// spanDays returns the number of days before the end date in the displayed range.
+func (w *Work) spanDays() int {
+	days := timeutil.StartOfDay(w.endTime).Sub(timeutil.StartOfDay(w.startTime)).Hours() / 24
+	return int(math.Round(days))
+}
+
+// FilterQuery returns the search query currently applied to the table.
+func (w *Work) FilterQuery() string {
+	return w.filterQuery
+}
+
+// SetFilter filters the rows of the current range by the search query.
+func (w *Work) SetFilter(query string) error {
+	w.filterQuery = query
+	w.filter = usecase.ParseWorkFilter(query)
+	return w.ReStoreTable(w.startTime, w.endTime)
+}
+
+func (w *Work) TableCapture(tui *service.TUI, form *Form, timer *Timer, audit *Audit) {
+	w.Table.SetSelectionChangedFunc(func(row, column int) {
+		if w.visualAnchor >= 0 {
+			w.extendVisual(row)
+		}
+	})
 	w.Table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyRune:
-			switch event.Rune() {
-			case 's':
-				// table top
-				w.goToTop()
-			case 'e':
-				// table bottom
-				w.goToBottom()
-			case 'a':
-				// add new work
+		switch tui.Keymap.Action(service.ContextWork, event) {
+		case "mark":
+			// mark or unmark work
+			row, _ := w.Table.GetSelection()
+			w.toggleMark(row)
+			return nil
+		case "visual":
+			// start or end visual range selection
+			row, _ := w.Table.GetSelection()
+			w.toggleVisual(row)
+		case "clear_marks":
+			// clear marks
+			w.ClearMarks()
+		case "batch":
+			// batch actions on marked works
+			ids := w.markedIDs()
+			if len(ids) == 0 {
+				break
+			}
+			w.visualAnchor = -1
+			form.Form.Clear(true)
+			form.configureBatchForm(tui, w, ids)
+			tui.SetFocus("mainWorkForm")
+		case "top":
+			// table top
+			w.goToTop()
+		case "bottom":
+			// table bottom
+			w.goToBottom()
+		case "add":
+			// add new work
+			form.Form.Clear(true)
+			form.ConfigureStoreForm(tui, w)
+			tui.SetFocus("mainWorkForm")
+		case "quick_add":
+			// add new work from a single line
+			form.Form.Clear(true)
+			form.configureQuickAddForm(tui, w, timer)
+			tui.SetFocus("mainWorkForm")
+		case "update":
+			// update work
+			row, _ := w.Table.GetSelection()
+			cell := w.Table.GetCell(row, 0)
+			if cell.Text == "" {
+				break
+			}
+			id := cell.Text
+			if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
+				uintId := uint(intId)
+				chronoWork, err := w.chronoWorkUC.FindByID(uintId)
+				if err != nil {
+					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+					break
+				}
 				form.Form.Clear(true)
-				form.ConfigureStoreForm(tui, w, relativeDays)
+				form.configureUpdateForm(tui, w, chronoWork)
 				tui.SetFocus("mainWorkForm")
-			case 'u':
-				// update work
-				row, _ := w.Table.GetSelection()
-				cell := w.Table.GetCell(row, 0)
-				if cell.Text == "" {
+			}
+		case "reset_timer":
+			// reset timer or update timer
+			row, _ := w.Table.GetSelection()
+			cell := w.Table.GetCell(row, 0)
+			if cell.Text == "" {
+				break
+			}
+			id := cell.Text
+			if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
+				uintId := uint(intId)
+				chronoWork, err := w.chronoWorkUC.FindByID(uintId)
+				if err != nil {
+					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
 					break
 				}
-				id := cell.Text
-				if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
-					uintId := uint(intId)
-					chronoWork, err := w.chronoWorkUC.FindByID(uintId)
-					if err != nil {
-						w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
-						break
+				form.Form.Clear(true)
+				form.configureTimerForm(tui, w, chronoWork)
+				tui.SetFocus("mainWorkForm")
+			}
+		case "delete":
+			// delete work
+			row, _ := w.Table.GetSelection()
+			cell := w.Table.GetCell(row, 0)
+			if cell.Text == "" {
+				break
+			}
+			modal := tview.NewModal().
+				SetText(i18n.T("Are you sure you want to delete this work?")).
+				AddButtons([]string{i18n.T("Yes"), i18n.T("No")}).
+				SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+					if buttonLabel == i18n.T("Yes") {
+						id := cell.Text
+						if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
+							uintId := uint(intId)
+							if err := w.chronoWorkUC.Delete(uintId); err != nil {
+								w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+							}
+							if err := w.Refresh(); err != nil {
+								w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+							}
+						}
 					}
-					form.Form.Clear(true)
-					form.configureUpdateForm(tui, w, chronoWork, relativeDays)
-					tui.SetFocus("mainWorkForm")
+					tui.DeleteModal()
+					tui.SetFocus("mainWorkContent")
+					w.goToTop()
+				})
+			tui.SetModal(modal)
+			tui.SetFocus("modal")
+		case "copy_title":
+			// copy work title
+			row, _ := w.Table.GetSelection()
+			cell := w.Table.GetCell(row, 0)
+			if cell.Text == "" {
+				break
+			}
+			id := cell.Text
+			if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
+				chronoWork, err := w.chronoWorkUC.FindByID(uint(intId))
+				if err != nil {
+					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+					break
 				}
-			case 'r':
-				// reset timer or update timer
-				row, _ := w.Table.GetSelection()
-				cell := w.Table.GetCell(row, 0)
-				if cell.Text == "" {
+				err = clipboard.Init()
+				if err != nil {
+					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
 					break
 				}
-				id := cell.Text
-				if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
-					uintId := uint(intId)
-					chronoWork, err := w.chronoWorkUC.FindByID(uintId)
-					if err != nil {
-						w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
-						break
-					}
-					form.Form.Clear(true)
-					form.configureTimerForm(tui, w, chronoWork, relativeDays)
-					tui.SetFocus("mainWorkForm")
+				clipboard.Write(clipboard.FmtText, []byte(chronoWork.Title))
+				tui.Status.Info("Copied title: %s", chronoWork.Title)
+			}
+		case "prev_day":
+			// previous day
+			if err := w.shiftRange(-1); err != nil {
+				w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+			}
+		case "next_day":
+			// next day
+			if err := w.shiftRange(1); err != nil {
+				w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+			}
+		case "prev_week":
+			// previous week
+			if err := w.shiftRange(-7); err != nil {
+				w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+			}
+		case "next_week":
+			// next week
+			if err := w.shiftRange(7); err != nil {
+				w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+			}
+		case "today":
+			// back to today
+			if err := w.ShowToday(); err != nil {
+				w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+			}
+		case "go_to_date":
+			// go to date
+			form.Form.Clear(true)
+			form.configureGoToDateForm(tui, w)
+			tui.SetFocus("mainWorkForm")
+		case "search":
+			// search works
+			form.Form.Clear(true)
+			form.configureSearchForm(tui, w)
+			tui.SetFocus("mainWorkForm")
+		case "copy_time":
+			// copy hour and minute
+			row, _ := w.Table.GetSelection()
+			cell := w.Table.GetCell(row, 0)
+			if cell.Text == "" {
+				break
+			}
+			id := cell.Text
+			if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
+				unitId := uint(intId)
+				chronoWork, err := w.chronoWorkUC.FindByID(unitId)
+				if err != nil {
+					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+					break
 				}
-			case 'd':
-				// delete work
-				row, _ := w.Table.GetSelection()
-				cell := w.Table.GetCell(row, 0)
-				if cell.Text == "" {
+				err = clipboard.Init()
+				if err != nil {
+					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
 					break
 				}
-				modal := tview.NewModal().
-					SetText("Are you sure you want to delete this work?").
-					AddButtons([]string{"Yes", "No"}).
-					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-						if buttonLabel == "Yes" {
-							id := cell.Text
-							if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
-								uintId := uint(intId)
-								if err := w.chronoWorkUC.Delete(uintId); err != nil {
+				hourAndMinute := timeutil.SecondsToHourAndMinute(chronoWork.TotalSeconds)
+				clipboard.Write(clipboard.FmtText, []byte(hourAndMinute))
+				tui.Status.Info("Copied time: %s", hourAndMinute)
+			}
+		case "audit":
+			// show audit log
+			row, _ := w.Table.GetSelection()
+			cell := w.Table.GetCell(row, 0)
+			if cell.Text == "" {
+				break
+			}
+			id := cell.Text
+			if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
+				audit.Show(uint(intId))
+				tui.ChangeToPage("audit")
+				tui.SetFocus("auditTable")
+			}
+		case "confirm":
+			// confirmed work
+			row, _ := w.Table.GetSelection()
+			cell := w.Table.GetCell(row, 0)
+			if cell.Text == "" {
+				break
+			}
+			id := cell.Text
+			if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
+				uintId := uint(intId)
+				chronoWork, err := w.chronoWorkUC.FindByID(uintId)
+				if err != nil {
+					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+					break
+				}
+				if chronoWork.Confirmed {
+					// reopening a locked work is an explicit action
+					modal := tview.NewModal().
+						SetText(i18n.T("This work is confirmed and locked. Reopen it?")).
+						AddButtons([]string{i18n.T("Yes"), i18n.T("No")}).
+						SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+							tui.DeleteModal()
+							tui.SetFocus("mainWorkContent")
+							if buttonLabel == i18n.T("Yes") {
+								if err := w.chronoWorkUC.Reopen(uintId); err != nil {
 									w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+									return
 								}
-								if err := w.ReStoreTable(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime()); err != nil {
+								if err := w.Refresh(); err != nil {
 									w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
+									return
 								}
 							}
-						}
-						tui.DeleteModal()
-						tui.SetFocus("mainWorkContent")
-						w.goToTop()
-					})
-				tui.SetModal(modal)
-				tui.SetFocus("modal")
-			case 't':
-				// copy work title
-				row, _ := w.Table.GetSelection()
-				cell := w.Table.GetCell(row, 2)
-				if cell.Text == "" {
+							w.Table.Select(row, 0)
+						})
+					tui.SetModal(modal)
+					tui.SetFocus("modal")
 					break
 				}
-				title := cell.Text
-				err := clipboard.Init()
-				if err != nil {
+				if err := w.chronoWorkUC.UpdateConfirmed(uintId, true); err != nil {
 					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
 					break
 				}
-				clipboard.Write(clipboard.FmtText, []byte(title))
-			case 'h':
-				// copy hour and minute
-				row, _ := w.Table.GetSelection()
-				cell := w.Table.GetCell(row, 0)
-				if cell.Text == "" {
-					break
+				if chronoWork.IsTracking {
+					// confirming stopped the tracking
+					timer.ResetSetText()
+					timer.StopCalculateSeconds()
 				}
-				id := cell.Text
-				if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
-					unitId := uint(intId)
-					chronoWork, err := w.chronoWorkUC.FindByID(unitId)
-					if err != nil {
-						w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
-						break
-					}
-					err = clipboard.Init()
-					if err != nil {
-						w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
-						break
-					}
-					clipboard.Write(clipboard.FmtText, []byte(timeutil.SecondsToHourAndMinute(chronoWork.TotalSeconds)))
-				}
-			case 'c':
-				// confirmed work
-				row, _ := w.Table.GetSelection()
-				cell := w.Table.GetCell(row, 0)
-				if cell.Text == "" {
-					break
-				}
-				id := cell.Text
-				if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
-					uintId := uint(intId)
-					chronoWork, err := w.chronoWorkUC.FindByID(uintId)
-					if err != nil {
-						w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
-						break
-					}
-					if chronoWork.Confirmed {
-						w.chronoWorkUC.UpdateConfirmed(uintId, false)
-					} else {
-						w.chronoWorkUC.UpdateConfirmed(uintId, true)
-					}
-					if err := w.ReStoreTable(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime()); err != nil {
-						w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
-					}
-					w.Table.Select(row, 0)
+				if err := w.Refresh(); err != nil {
+					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
 				}
+				w.Table.Select(row, 0)
 			}
-		case tcell.KeyEnter:
+		case "close_period":
+			// close accounting period
+			form.Form.Clear(true)
+			form.configureClosePeriodForm(tui, w, timer)
+			tui.SetFocus("mainWorkForm")
+		case "toggle_tracking":
 			// toggle tracking
 			row, _ := w.Table.GetSelection()
 			cell := w.Table.GetCell(row, 0)
@@ -208,58 +392,15 @@ func (w *Work) TableCapture(tui *service.TUI, form *Form, timer *Timer, relative
 			}
 			id := cell.Text
 			if intId, err := strconv.ParseUint(id, 10, 0); err == nil {
-				uintId := uint(intId)
-				chronoWork, err := w.chronoWorkUC.FindByID(uintId)
-				if err != nil {
-					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
-					break
-				}
-				chronoWorks, err := w.chronoWorkUC.FindTracking()
+				copied, err := w.toggleTracking(tui, timer, uint(intId))
 				if err != nil {
 					w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
 					break
 				}
-				// if tracking work exists, stop tracking
-				if len(chronoWorks) > 0 {
-					for _, cw := range chronoWorks {
-						if cw.ID != chronoWork.ID || !timeutil.IsToday(cw.CreatedAt) {
-							if err := w.chronoWorkUC.StopTracking(cw.ID); err != nil {
-								w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
-								break
-							}
-						}
-					}
-				}
-				if timeutil.IsToday(chronoWork.CreatedAt) {
-					// target tracking work
-					if chronoWork.IsTracking {
-						w.chronoWorkUC.StopTracking(uintId)
-						timer.ResetSetText()
-						timer.StopCalculateSeconds()
-					} else {
-						w.chronoWorkUC.StartTracking(uintId)
-						// Refetch to get updated StartTime
-						updatedWork, _ := w.chronoWorkUC.FindByID(uintId)
-						timer.SetStartTimer(updatedWork.StartTime)
-						timer.SetCalculateSeconds(tui)
-						timer.SetTimerText(*updatedWork)
-					}
-					w.ReStoreTable(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime())
-					w.Table.Select(row, 0)
-				} else {
-					// chronowork copy
-					newChronoWork, err := w.chronoWorkUC.Create(chronoWork.Title, chronoWork.ProjectTypeID, chronoWork.TagID)
-					if err != nil {
-						w.errorHandler.ShowErrorWithErr(err, "mainWorkContent")
-						break
-					}
-					w.chronoWorkUC.StartTracking(newChronoWork.ID)
-					updatedWork, _ := w.chronoWorkUC.FindByID(newChronoWork.ID)
-					timer.SetStartTimer(updatedWork.StartTime)
-					timer.SetCalculateSeconds(tui)
-					timer.SetTimerText(*updatedWork)
-					w.ReStoreTable(timeutil.RelativeStartTimeWithDays(relativeDays), timeutil.TodayEndTime())
+				if copied {
 					w.Table.Select(1, 0)
+				} else {
+					w.Table.Select(row, 0)
 				}
 			}
 		}
@@ -267,20 +408,102 @@ func (w *Work) TableCapture(tui *service.TUI, form *Form, timer *Timer, relative
 	})
 }
 
+// toggleTracking starts or stops tracking the work and stops any other tracked work.
+// A past work is copied to today and the copy is tracked instead, in which case
+// the table is moved back to today and copied is true.
+func (w *Work) toggleTracking(tui *service.TUI, timer *Timer, id uint) (copied bool, err error) {
+	chronoWork, err := w.chronoWorkUC.FindByID(id)
+	if err != nil {
+		return false, err
+	}
+	chronoWorks, err := w.chronoWorkUC.FindTracking()
+	if err != nil {
+		return false, err
+	}
+	// if tracking work exists, stop tracking
+	for _, cw := range chronoWorks {
+		if cw.ID != chronoWork.ID || !timeutil.IsToday(cw.CreatedAt) {
+			if err := w.chronoWorkUC.StopTracking(cw.ID); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if timeutil.IsToday(chronoWork.CreatedAt) {
+		// target tracking work
+		if chronoWork.IsTracking {
+			if err := w.chronoWorkUC.StopTracking(id); err != nil {
+				return false, err
+			}
+			timer.ResetSetText()
+			timer.StopCalculateSeconds()
+		} else {
+			if err := w.chronoWorkUC.StartTracking(id); err != nil {
+				return false, err
+			}
+			// Refetch to get updated StartTime
+			updatedWork, err := w.chronoWorkUC.FindByID(id)
+			if err != nil {
+				return false, err
+			}
+			timer.SetStartTimer(updatedWork.StartTime)
+			timer.SetCalculateSeconds(tui)
+			timer.SetTimerText(*updatedWork)
+		}
+		return false, w.Refresh()
+	}
+
+	// chronowork copy
+	newChronoWork, err := w.chronoWorkUC.Create(chronoWork.Title, chronoWork.ProjectTypeID, chronoWork.TagID)
+	if err != nil {
+		return false, err
+	}
+	if err := w.chronoWorkUC.StartTracking(newChronoWork.ID); err != nil {
+		return false, err
+	}
+	updatedWork, err := w.chronoWorkUC.FindByID(newChronoWork.ID)
+	if err != nil {
+		return false, err
+	}
+	timer.SetStartTimer(updatedWork.StartTime)
+	timer.SetCalculateSeconds(tui)
+	timer.SetTimerText(*updatedWork)
+	return true, w.ShowToday()
+}
+
 func (w *Work) ReStoreTable(startTime, endTime time.Time) error {
+	w.startTime = startTime
+	w.endTime = endTime
+	w.setTitle()
 	w.Table.Clear()
 	w.setHeader()
 	if err := w.setBody(startTime, endTime); err != nil {
 		return err
 	}
+	w.paintMarks()
 	return nil
 }
 
+func (w *Work) setTitle() {
+	now := time.Now()
+	title := i18n.T("Today is %s (%s)", i18n.FormatDate(now), i18n.Weekday(now.Weekday()))
+	title += fmt.Sprintf("  |  %s (%s) - %s (%s)",
+		i18n.FormatDate(w.startTime), i18n.ShortWeekday(w.startTime.Weekday()),
+		i18n.FormatDate(w.endTime), i18n.ShortWeekday(w.endTime.Weekday()))
+	if w.filterQuery != "" {
+		title += "  |  " + i18n.T("Search: %s", w.filterQuery)
+	}
+	if len(w.marked) > 0 {
+		title += "  |  " + i18n.T("Marked: %d", len(w.marked))
+	}
+	w.Title.SetText(title)
+}
+
 func (w *Work) setHeader() {
 	for i, header := range workHeader {
-		tableCell := tview.NewTableCell(header).
-			SetTextColor(tcell.ColorWhite).
-			SetBackgroundColor(tcell.ColorPurple).
+		tableCell := tview.NewTableCell(i18n.T(header)).
+			SetTextColor(w.theme.AccentText).
+			SetBackgroundColor(w.theme.Accent).
 			SetSelectable(false)
 		if header != "ID" {
 			tableCell.SetExpansion(1)
@@ -319,6 +542,10 @@ func (w *Work) setBody(startTime, endTime time.Time) error {
 	}
 	if len(activeTrackingChronoWorks) > 0 {
 		for _, activeTrackingChronoWork := range activeTrackingChronoWorks {
+			// a tracking work of another day does not belong to the shown range
+			if activeTrackingChronoWork.CreatedAt.Before(startTime) || activeTrackingChronoWork.CreatedAt.After(endTime) {
+				continue
+			}
 			isInclude := false
 			for _, cw := range chronoWorks {
 				if cw.ID == activeTrackingChronoWork.ID {
@@ -334,6 +561,7 @@ func (w *Work) setBody(startTime, endTime time.Time) error {
 			return chronoWorks[i].CreatedAt.After(chronoWorks[j].CreatedAt)
 		})
 	}
+	chronoWorks = w.filter.Apply(chronoWorks)
 
 	groupedChronoWorks := map[string][]domain.ChronoWork{}
 	for _, work := range chronoWorks {
@@ -348,6 +576,8 @@ func (w *Work) setBody(startTime, endTime time.Time) error {
 
 	today := time.Now()
 	rowCount := 1
+	// the table row of the tracking work, below the date, blank and total rows
+	trackingRow := 0
 	for _, dateStr := range sortedKeys {
 		chronoWorks := groupedChronoWorks[dateStr]
 		totalSecondsByDay := 0
@@ -361,11 +591,14 @@ func (w *Work) setBody(startTime, endTime time.Time) error {
 				w.insertBlankRow(rowCount)
 				rowCount++
 			}
-			w.insertDateRow(rowCount, date.Format("2006/01/02"), date.Weekday())
+			w.insertDateRow(rowCount, date)
 			rowCount++
 		}
 		for _, chronoWork := range chronoWorks {
 			w.configureTable(rowCount, chronoWork, setting)
+			if trackingRow == 0 && len(activeTrackingChronoWorks) > 0 && chronoWork.ID == activeTrackingChronoWorks[0].ID {
+				trackingRow = rowCount
+			}
 			rowCount++
 			totalSecondsByDay += chronoWork.TotalSeconds
 		}
@@ -373,13 +606,8 @@ func (w *Work) setBody(startTime, endTime time.Time) error {
 		rowCount++
 	}
 
-	if len(activeTrackingChronoWorks) > 0 {
-		for i, chronoWork := range chronoWorks {
-			if chronoWork.ID == activeTrackingChronoWorks[0].ID {
-				w.Table.Select(i+1, 0)
-				break
-			}
-		}
+	if trackingRow > 0 {
+		w.Table.Select(trackingRow, 0)
 	}
 	return nil
 }
@@ -392,56 +620,156 @@ func (w *Work) insertBlankRow(rowCount int) {
 
 func (w *Work) insertTotalSecondsByDayRow(rowCount, totalSecondsByDay, count int, setting *domain.Setting) {
 	w.Table.SetCell(rowCount, 0,
-		tview.NewTableCell("Total").
+		tview.NewTableCell(i18n.T("Total")).
 			SetAlign(tview.AlignLeft).
-			SetTextColor(tcell.ColorWhite).
-			SetBackgroundColor(tcell.ColorRebeccaPurple).
+			SetTextColor(w.theme.AccentText).
+			SetBackgroundColor(w.theme.Summary).
 			SetSelectable(false))
 	w.Table.SetCell(rowCount, 1,
 		tview.NewTableCell(timeutil.FormatWithPersonDay(totalSecondsByDay, setting.PersonDay, setting.DisplayAsPersonDay)).
 			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite).
-			SetBackgroundColor(tcell.ColorRebeccaPurple).
+			SetTextColor(w.theme.AccentText).
+			SetBackgroundColor(w.theme.Summary).
 			SetSelectable(false))
 	w.Table.SetCell(rowCount, 2,
-		tview.NewTableCell(fmt.Sprintf("count:%d", count)).
+		tview.NewTableCell(i18n.T("count:%d", count)).
 			SetAlign(tview.AlignLeft).
-			SetTextColor(tcell.ColorWhite).
-			SetBackgroundColor(tcell.ColorRebeccaPurple).
+			SetTextColor(w.theme.AccentText).
+			SetBackgroundColor(w.theme.Summary).
 			SetSelectable(false))
 
 	for i := 3; i < len(workHeader); i++ {
 		w.Table.SetCell(rowCount, i,
 			tview.NewTableCell("").
-				SetBackgroundColor(tcell.ColorRebeccaPurple).
+				SetBackgroundColor(w.theme.Summary).
 				SetSelectable(false))
 	}
 }
 
-func (w *Work) insertDateRow(rowCount int, date string, weekday time.Weekday) {
+func (w *Work) insertDateRow(rowCount int, date time.Time) {
 	w.Table.SetCell(rowCount, 0,
-		tview.NewTableCell(fmt.Sprintf("%s %s", date, weekday)).
+		tview.NewTableCell(fmt.Sprintf("%s %s", i18n.FormatDate(date), i18n.Weekday(date.Weekday()))).
 			SetAlign(tview.AlignCenter).
-			SetTextColor(tcell.ColorWhite).
-			SetBackgroundColor(tcell.ColorMediumPurple.TrueColor()).
+			SetTextColor(w.theme.AccentText).
+			SetBackgroundColor(w.theme.DateRow).
 			SetSelectable(false))
 
 	for i := 1; i < len(workHeader); i++ {
 		if workHeader[i] == "TRACKING" {
 			w.Table.SetCell(rowCount, i,
-				tview.NewTableCell("Copy to Today").
+				tview.NewTableCell(i18n.T("Copy to Today")).
 					SetAlign(tview.AlignCenter).
-					SetBackgroundColor(tcell.ColorMediumPurple.TrueColor()).
+					SetTextColor(w.theme.AccentText).
+					SetBackgroundColor(w.theme.DateRow).
 					SetSelectable(false))
 		} else {
 			w.Table.SetCell(rowCount, i,
 				tview.NewTableCell("").
-					SetBackgroundColor(tcell.ColorMediumPurple.TrueColor()).
+					SetBackgroundColor(w.theme.DateRow).
 					SetSelectable(false))
 		}
 	}
 }
 
+// ClearMarks unmarks every work and ends visual range selection.
+func (w *Work) ClearMarks() {
+	w.marked = map[uint]bool{}
+	w.visualAnchor = -1
+	w.visualBase = nil
+	w.paintMarks()
+}
+
+// markedIDs returns the marked work IDs, or the selected work if none is marked.
+func (w *Work) markedIDs() []uint {
+	ids := make([]uint, 0, len(w.marked))
+	for id := range w.marked {
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		row, _ := w.Table.GetSelection()
+		if id := w.rowWorkID(row); id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// rowWorkID returns the ID of the work in the row, or 0 for header, date and total rows.
+func (w *Work) rowWorkID(row int) uint {
+	cell := w.Table.GetCell(row, 0)
+	if cell == nil || cell.Text == "" {
+		return 0
+	}
+	intId, err := strconv.ParseUint(cell.Text, 10, 0)
+	if err != nil {
+		return 0
+	}
+	return uint(intId)
+}
+
+func (w *Work) toggleMark(row int) {
+	id := w.rowWorkID(row)
+	if id == 0 {
+		return
+	}
+	if w.marked[id] {
+		delete(w.marked, id)
+	} else {
+		w.marked[id] = true
+	}
+	w.paintMarks()
+}
+
+func (w *Work) toggleVisual(row int) {
+	if w.visualAnchor >= 0 {
+		w.visualAnchor = -1
+		w.visualBase = nil
+		return
+	}
+	w.visualAnchor = row
+	w.visualBase = map[uint]bool{}
+	for id := range w.marked {
+		w.visualBase[id] = true
+	}
+	w.extendVisual(row)
+}
+
+// extendVisual marks every work between the visual anchor and the row, on top of earlier marks.
+func (w *Work) extendVisual(row int) {
+	from, to := w.visualAnchor, row
+	if from > to {
+		from, to = to, from
+	}
+	w.marked = map[uint]bool{}
+	for id := range w.visualBase {
+		w.marked[id] = true
+	}
+	for r := from; r <= to; r++ {
+		if id := w.rowWorkID(r); id != 0 {
+			w.marked[id] = true
+		}
+	}
+	w.paintMarks()
+}
+
+func (w *Work) paintMarks() {
+	for row := 1; row < w.Table.GetRowCount(); row++ {
+		id := w.rowWorkID(row)
+		if id == 0 {
+			continue
+		}
+		backgroundColor := tcell.ColorDefault
+		if w.marked[id] {
+			backgroundColor = w.theme.Marked
+		}
+		for column := 0; column < len(workHeader); column++ {
+			w.Table.GetCell(row, column).SetBackgroundColor(backgroundColor)
+		}
+	}
+	w.setTitle()
+}
+
 func (w *Work) goToTop() {
 	w.Table.ScrollToBeginning().Select(1, 0)
 }
@@ -452,9 +780,9 @@ func (w *Work) goToBottom() {
 
 func (w *Work) configureTable(row int, chronoWork domain.ChronoWork, setting *domain.Setting) {
 	// ID
-	confirmedColor := tcell.ColorGreen
+	confirmedColor := w.theme.Positive
 	if !chronoWork.Confirmed {
-		confirmedColor = tcell.ColorRed
+		confirmedColor = w.theme.Negative
 	}
 	w.Table.SetCell(row, 0,
 		tview.
@@ -470,7 +798,7 @@ func (w *Work) configureTable(row int, chronoWork domain.ChronoWork, setting *do
 	// Title
 	w.Table.SetCell(row, 2,
 		tview.
-			NewTableCell(chronoWork.Title).
+			NewTableCell(w.highlightMatch(chronoWork.Title, w.filter.Title)).
 			SetAlign(tview.AlignLeft).
 			SetExpansion(1))
 	// Project
@@ -480,7 +808,7 @@ func (w *Work) configureTable(row int, chronoWork domain.ChronoWork, setting *do
 	}
 	w.Table.SetCell(row, 3,
 		tview.
-			NewTableCell(projectName).
+			NewTableCell(w.highlightMatch(projectName, w.filter.Project)).
 			SetAlign(tview.AlignLeft).
 			SetExpansion(1))
 	// Tags
@@ -490,24 +818,38 @@ func (w *Work) configureTable(row int, chronoWork domain.ChronoWork, setting *do
 	}
 	w.Table.SetCell(row, 4,
 		tview.
-			NewTableCell(tagName).
+			NewTableCell(w.highlightMatch(tagName, w.filter.Tag)).
 			SetAlign(tview.AlignLeft).
 			SetExpansion(1))
 	// TRACKING
 	trackingCell := tview.NewTableCell("").SetAlign(tview.AlignCenter).SetExpansion(0)
-	setText := "Yes"
-	setColor := tcell.ColorGreen
+	setText := i18n.T("Yes")
+	setColor := w.theme.Positive
 	if timeutil.IsToday(chronoWork.CreatedAt) {
 		if !chronoWork.IsTracking {
-			setText = "No"
-			setColor = tcell.ColorRed
+			setText = i18n.T("No")
+			setColor = w.theme.Negative
 		}
 	} else {
-		setText = "Copy"
+		setText = i18n.T("Copy")
 		if !chronoWork.IsTracking {
-			setColor = tcell.ColorRed
+			setColor = w.theme.Negative
 		}
 	}
 	trackingCell.SetText(setText).SetTextColor(setColor)
 	w.Table.SetCell(row, 5, trackingCell)
 }
+
+// highlightMatch escapes text for a table cell and colors the first
+// case-insensitive occurrence of substr.
+func (w *Work) highlightMatch(text, substr string) string {
+	lowerText := strings.ToLower(text)
+	index := strings.Index(lowerText, strings.ToLower(substr))
+	if substr == "" || index < 0 || len(lowerText) != len(text) {
+		return tview.Escape(text)
+	}
+	end := index + len(substr)
+	return tview.Escape(text[:index]) +
+		w.theme.MatchTag() + tview.Escape(text[index:end]) + "[-:-]" +
+		tview.Escape(text[end:])
+}